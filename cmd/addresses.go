@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/willoma/recherche-maison/core/geo"
+)
+
+// runAddresses manages the addresses used for geocoding: import FILE...
+// imports BAN CSV files, locate sets the coordinates of the houses which
+// have none
+func runAddresses(args []string) error {
+	fs, loader := newFlagSet("addresses", "import FILE... | locate")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx := context.Background()
+
+	action := fs.Arg(0)
+	switch {
+	case action == "import" && fs.NArg() >= 2:
+	case action == "locate" && fs.NArg() == 1:
+	default:
+		fs.Usage()
+		return errors.New("invalid addresses command")
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	switch action {
+	case "import":
+		for _, path := range fs.Args()[1:] {
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open addresses file: %w", err)
+			}
+			count, err := a.geoService.ImportAddresses(ctx, f)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("failed to import %s: %w", path, err)
+			}
+			fmt.Printf("%d addresses imported from %s\n", count, path)
+		}
+	case "locate":
+		houses, err := a.houseService.ListHouses(ctx)
+		if err != nil {
+			return err
+		}
+		for _, h := range houses {
+			if !h.Coordinates().IsZero() {
+				continue
+			}
+			c, err := a.geoService.LocateHouse(ctx, h.ID)
+			if errors.Is(err, geo.ErrNotFound) {
+				fmt.Printf("%d\t%s: address not found\n", h.ID, h.Title)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d\t%s: %s\n", h.ID, h.Title, c)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/backup"
+	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/dossier"
+	"github.com/willoma/recherche-maison/core/duplicate"
+	"github.com/willoma/recherche-maison/core/export"
+	"github.com/willoma/recherche-maison/core/file"
+	"github.com/willoma/recherche-maison/core/geo"
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/core/importer"
+	"github.com/willoma/recherche-maison/core/journal"
+	"github.com/willoma/recherche-maison/core/maintenance"
+	"github.com/willoma/recherche-maison/core/scraper"
+	"github.com/willoma/recherche-maison/core/tag"
+	"github.com/willoma/recherche-maison/core/task"
+	"github.com/willoma/recherche-maison/core/watcher"
+	"github.com/willoma/recherche-maison/db"
+)
+
+// app holds the configuration and the services shared by all commands
+type app struct {
+	config config.Config
+	db     *sql.DB
+
+	fileService        *file.Service
+	houseService       *house.Service
+	cityService        *city.Service
+	journalService     *journal.Service
+	taskService        *task.Service
+	tagService         *tag.Service
+	customFieldService *customfield.Service
+	dossierService     *dossier.Service
+	duplicateService   *duplicate.Service
+	geoService         *geo.Service
+	backupService      *backup.Service
+	exportService      *export.Service
+	importService      *importer.Service
+	maintenanceService *maintenance.Service
+	scraperService     *scraper.Service
+	watcherService     *watcher.Service
+}
+
+// newFlagSet returns the flag set of a command, with the configuration flags
+func newFlagSet(name, args string) (*flag.FlagSet, *config.Loader) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s [options] %s\n\nOptions:\n", os.Args[0], name, args)
+		fs.PrintDefaults()
+	}
+	return fs, config.NewLoader(fs)
+}
+
+// newApp opens the database and initializes the services
+func newApp(cfg config.Config) (*app, error) {
+	// Ensure uploads directory exists
+	if err := os.MkdirAll(cfg.UploadsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create uploads directory: %w", err)
+	}
+
+	// Initialize database
+	dbConn, err := db.Init(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	// Initialize services
+	queries := db.New(dbConn)
+	a := &app{
+		config:             cfg,
+		db:                 dbConn,
+		fileService:        file.NewService(cfg),
+		houseService:       house.NewService(queries, dbConn, cfg.UploadsDir),
+		cityService:        city.NewService(queries, dbConn),
+		journalService:     journal.NewService(queries),
+		taskService:        task.NewService(queries),
+		tagService:         tag.NewService(queries),
+		customFieldService: customfield.NewService(queries),
+		backupService:      backup.NewService(dbConn, cfg.UploadsDir),
+		maintenanceService: maintenance.NewService(queries, dbConn, cfg.UploadsDir),
+		scraperService:     scraper.NewService(&http.Client{Timeout: 30 * time.Second}, cfg.UploadsDir, cfg.MaxUploadSize),
+	}
+	a.dossierService = dossier.NewService(a.houseService, a.customFieldService, cfg.UploadsDir)
+	a.duplicateService = duplicate.NewService(queries, a.houseService, cfg.UploadsDir)
+	a.exportService = export.NewService(a.houseService, a.customFieldService)
+	a.importService = importer.NewService(a.houseService, a.cityService, a.tagService, a.customFieldService)
+	a.watcherService = watcher.NewService(queries, dbConn, a.scraperService)
+
+	var geocoder geo.Geocoder = geo.NewBANGeocoder(queries)
+	if cfg.Geocoder == config.GeocoderHTTP {
+		geocoder = geo.NewHTTPGeocoder(&http.Client{Timeout: 10 * time.Second}, cfg.GeocoderURL)
+	}
+	a.geoService = geo.NewService(queries, dbConn, geocoder)
+
+	return a, nil
+}
+
+// Close releases the database connection
+func (a *app) Close() error {
+	return a.db.Close()
+}
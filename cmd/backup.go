@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/backup"
+)
+
+// runBackup writes a backup archive of the database and the uploads to the
+// file given as argument, or to a timestamped file in the backups directory
+func runBackup(args []string) error {
+	fs, loader := newFlagSet("backup", "[FILE]")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	dest := filepath.Join(cfg.BackupDir, backup.ArchiveName(time.Now()))
+	if fs.NArg() > 0 {
+		dest = fs.Arg(0)
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := a.backupService.BackupToFile(context.Background(), dest); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup written to %s\n", dest)
+	return nil
+}
+
+// runRestore replaces the database and the uploads with the backup archive
+// given as argument
+func runRestore(args []string) error {
+	fs, loader := newFlagSet("restore", "FILE")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("missing backup file")
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if err := backup.Restore(context.Background(), cfg, fs.Arg(0)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup %s restored\n", fs.Arg(0))
+	return nil
+}
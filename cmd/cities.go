@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// runCities manages the cities: add NAME [POSTAL_CODE], list, rename CITY NEW_NAME,
+// merge CITY TARGET, import FILE (official list of communes, restricted with
+// the -departments, -center and -radius flags)
+func runCities(args []string) error {
+	fs, loader := newFlagSet("cities", "add NAME [POSTAL_CODE] | list | rename CITY NEW_NAME | merge CITY TARGET | import FILE")
+	departments := fs.String("departments", "", "import: codes of the departments to import, separated with commas")
+	center := fs.String("center", "", "import: center of the area to import, as \"latitude, longitude\"")
+	radius := fs.Float64("radius", 0, "import: radius of the area to import around -center, in kilometers")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx := context.Background()
+
+	action := fs.Arg(0)
+	switch {
+	case action == "add" && (fs.NArg() == 2 || fs.NArg() == 3):
+	case action == "list" && fs.NArg() == 1:
+	case action == "rename" && fs.NArg() == 3:
+	case action == "merge" && fs.NArg() == 3:
+	case action == "import" && fs.NArg() == 2:
+	default:
+		fs.Usage()
+		return errors.New("invalid cities command")
+	}
+
+	selection := models.CommuneSelection{
+		Departments: models.ParseDepartments(*departments),
+		Radius:      *radius,
+	}
+	if *radius < 0 || (*radius > 0) != (*center != "") {
+		return errors.New("-center and -radius must be given together")
+	}
+	if selection.Center, err = models.ParseCoordinates(*center); err != nil {
+		return err
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	switch action {
+	case "add":
+		c := models.City{Name: fs.Arg(1), PostalCode: fs.Arg(2)}
+		if err := a.cityService.CreateCity(ctx, c); err != nil {
+			return err
+		}
+		fmt.Printf("City %q added\n", c.DisplayName())
+	case "list":
+		cities, err := a.cityService.ListCities(ctx)
+		if err != nil {
+			return err
+		}
+		for _, c := range cities {
+			used := ""
+			if c.IsUsed {
+				used = " (used)"
+			}
+			fmt.Printf("%d\t%s%s\n", c.ID, c.DisplayName(), used)
+		}
+	case "rename":
+		c, err := findCity(ctx, a, fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		oldName := c.DisplayName()
+		c.Name = fs.Arg(2)
+		if err := a.cityService.UpdateCity(ctx, c); err != nil {
+			return err
+		}
+		fmt.Printf("City %q renamed to %q\n", oldName, c.DisplayName())
+	case "merge":
+		source, err := findCity(ctx, a, fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		target, err := findCity(ctx, a, fs.Arg(2))
+		if err != nil {
+			return err
+		}
+		if err := a.cityService.MergeCities(ctx, target.ID, source.ID); err != nil {
+			return err
+		}
+		fmt.Printf("City %q merged into %q\n", source.DisplayName(), target.DisplayName())
+	case "import":
+		f, err := os.Open(fs.Arg(1))
+		if err != nil {
+			return fmt.Errorf("failed to open communes file: %w", err)
+		}
+		defer f.Close()
+		created, completed, err := a.cityService.ImportCommunes(ctx, f, selection)
+		if err != nil {
+			return fmt.Errorf("failed to import %s: %w", fs.Arg(1), err)
+		}
+		fmt.Printf("%d cities created, %d cities completed\n", created, completed)
+	}
+
+	return nil
+}
+
+// findCity returns the city designated by its ID, by its name or by its name
+// followed by its postal code, as in "Saint-Martin (44000)"
+func findCity(ctx context.Context, a *app, ref string) (models.City, error) {
+	cities, err := a.cityService.ListCities(ctx)
+	if err != nil {
+		return models.City{}, err
+	}
+
+	id, idErr := strconv.ParseInt(ref, 10, 64)
+	var found []models.City
+	for _, c := range cities {
+		if idErr == nil && c.ID == id {
+			return c, nil
+		}
+		if c.Name == ref || c.DisplayName() == ref {
+			found = append(found, c)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return models.City{}, fmt.Errorf("city %q not found", ref)
+	case 1:
+		return found[0], nil
+	default:
+		return models.City{}, fmt.Errorf("several cities are named %q, use the ID or the name with the postal code", ref)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/importer"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// runExport exports all houses to the file given as argument, or to the
+// standard output. The format is given by the -format flag, or else by the
+// extension of the file, and defaults to JSON.
+func runExport(args []string) error {
+	fs, loader := newFlagSet("export", "[FILE]")
+	format := fs.String("format", "", "export format: json, csv or ods (defaults to the file extension, or json)")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(fs.Arg(0)), ".")
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	var write func(context.Context, io.Writer, models.HouseFilter) error
+	switch *format {
+	case "", "json":
+		write = a.exportService.WriteJSON
+	case "csv":
+		write = a.exportService.WriteCSV
+	case "ods":
+		write = a.exportService.WriteODS
+	default:
+		return fmt.Errorf("unknown export format %q", *format)
+	}
+
+	if fs.NArg() == 0 {
+		return write(context.Background(), os.Stdout, models.HouseFilter{})
+	}
+
+	f, err := os.Create(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := write(context.Background(), f, models.HouseFilter{}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// runImport imports houses from the JSON export or the CSV file given as
+// argument. The format is given by the -format flag, or else by the
+// extension of the file, and defaults to JSON.
+func runImport(args []string) error {
+	fs, loader := newFlagSet("import", "FILE")
+	format := fs.String("format", "", "import format: json or csv (defaults to the file extension, or json)")
+	var columns columnMappings
+	fs.Var(&columns, "map", "map a CSV column to a house field, as COLUMN=FIELD or COLUMN= to ignore it (repeatable)")
+	dryRun := fs.Bool("dry-run", false, "only check the CSV file, without importing it")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("missing import file")
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(fs.Arg(0)), ".")
+	}
+
+	switch *format {
+	case "", "json":
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("failed to open import file: %w", err)
+		}
+		defer f.Close()
+
+		a, err := newApp(cfg)
+		if err != nil {
+			return err
+		}
+		defer a.Close()
+
+		count, err := a.importService.ImportJSON(context.Background(), f)
+		if err != nil {
+			return fmt.Errorf("imported %d houses before failing: %w", count, err)
+		}
+
+		fmt.Printf("%d houses imported\n", count)
+		return nil
+	case "csv":
+		return importCSV(cfg, fs.Arg(0), columns, *dryRun)
+	default:
+		return fmt.Errorf("unknown import format %q", *format)
+	}
+}
+
+// importCSV imports the houses of a CSV file, after checking all of them
+func importCSV(cfg config.Config, path string, columns columnMappings, dryRun bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	headers, _, err := importer.ReadCSV(data)
+	if err != nil {
+		return err
+	}
+
+	mapping := importer.GuessCSVMapping(headers)
+	for column, field := range columns {
+		index := slices.Index(headers, column)
+		if index < 0 {
+			return fmt.Errorf("unknown CSV column %q", column)
+		}
+		if field != "" && !slices.ContainsFunc(models.CSVImportColumns, func(c models.CSVImportColumn) bool { return c.Name == field }) {
+			return fmt.Errorf("unknown house field %q", field)
+		}
+		mapping[index] = field
+	}
+
+	preview, err := importer.PreviewCSV(data, mapping)
+	if err != nil {
+		return err
+	}
+
+	for i, header := range preview.Headers {
+		field := preview.Mapping[i]
+		if field == "" {
+			field = "(ignored)"
+		}
+		fmt.Printf("%s -> %s\n", header, field)
+	}
+	for _, message := range preview.Errors {
+		fmt.Println(message)
+	}
+	for _, row := range preview.Rows {
+		for i := range preview.Headers {
+			if message, ok := row.Errors[i]; ok {
+				fmt.Printf("line %d, column %q: %s\n", row.Line, preview.Headers[i], message)
+			}
+		}
+	}
+	if preview.HasErrors() {
+		return fmt.Errorf("%w, nothing imported", importer.ErrInvalidCSV)
+	}
+
+	if dryRun {
+		fmt.Printf("%d houses can be imported\n", len(preview.Rows))
+		return nil
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if _, err := a.importService.ImportCSV(context.Background(), data, mapping); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d houses imported\n", len(preview.Rows))
+	return nil
+}
+
+// columnMappings is a repeatable COLUMN=FIELD command-line flag
+type columnMappings map[string]string
+
+func (m *columnMappings) String() string {
+	pairs := make([]string, 0, len(*m))
+	for column, field := range *m {
+		pairs = append(pairs, column+"="+field)
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func (m *columnMappings) Set(value string) error {
+	column, field, ok := strings.Cut(value, "=")
+	if !ok {
+		return errors.New("expected COLUMN=FIELD")
+	}
+	if *m == nil {
+		*m = columnMappings{}
+	}
+	(*m)[column] = field
+	return nil
+}
@@ -1,40 +1,67 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 
 	_ "modernc.org/sqlite"
-
-	"github.com/willoma/recherche-maison/config"
-	"github.com/willoma/recherche-maison/core/city"
-	"github.com/willoma/recherche-maison/core/file"
-	"github.com/willoma/recherche-maison/core/house"
-	"github.com/willoma/recherche-maison/core/http"
-	"github.com/willoma/recherche-maison/db"
 )
 
+// command is a subcommand of the application
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"serve", "start the web server (default command)", runServe},
+	{"backup", "back up the database and the uploads to an archive", runBackup},
+	{"restore", "restore the database and the uploads from an archive (server must be stopped)", runRestore},
+	{"export", "export houses as JSON, CSV or ODS", runExport},
+	{"import", "import houses from a JSON export or a CSV file", runImport},
+	{"check", "check the consistency of the database and uploaded files", runCheck},
+	{"vacuum", "compact the database", runVacuum},
+	{"cities", "manage cities (add, list, rename)", runCities},
+	{"addresses", "import addresses for offline geocoding, locate houses", runAddresses},
+}
+
 func main() {
-	// Ensure uploads directory exists
-	if err := os.MkdirAll(config.UploadsDir, 0o755); err != nil {
-		slog.Error("Failed to create uploads directory", "error", err)
-		os.Exit(1)
+	args := os.Args[1:]
+
+	// Without a subcommand, or with flags only, start the server as before
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
 	}
 
-	// Initialize database
-	dbConn, err := db.Init()
-	if err != nil {
-		slog.Error("Failed to initialize database", "error", err)
-		os.Exit(1)
+	if name == "help" {
+		usage()
+		return
 	}
-	defer dbConn.Close()
 
-	// Initialize services
-	queries := db.New(dbConn)
-	fileService := file.NewService()
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(args); err != nil {
+				slog.Error("Command failed", "command", name, "error", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
 
-	houseService := house.NewService(queries, dbConn)
-	cityService := city.NewService(queries)
+	slog.Error("Unknown command", "command", name)
+	usage()
+	os.Exit(2)
+}
 
-	http.Run(fileService, houseService, cityService)
+// usage prints the list of available commands
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [command] [options]\n\nCommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun \"%s <command> -h\" to list the options of a command.\n", os.Args[0])
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// runCheck checks the consistency of the database and of the uploaded files
+func runCheck(args []string) error {
+	fs, loader := newFlagSet("check", "")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	problems, err := a.maintenanceService.Check(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("No problem found")
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	return fmt.Errorf("%d problems found", len(problems))
+}
+
+// runVacuum compacts the database
+func runVacuum(args []string) error {
+	fs, loader := newFlagSet("vacuum", "")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	if err := a.maintenanceService.Vacuum(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Println("Database compacted")
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/http"
+)
+
+// runServe starts the web server
+func runServe(args []string) error {
+	fs, loader := newFlagSet("serve", "")
+	printConfig := fs.Bool("print-config", false, "print the effective configuration as JSON and exit")
+	fs.Parse(args)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if *printConfig {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(cfg)
+	}
+
+	a, err := newApp(cfg)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	// Compute task reminders in the background
+	go a.taskService.RunReminders(context.Background(), time.Duration(cfg.TaskRemindersInterval))
+
+	// Back up the data periodically
+	if cfg.BackupInterval > 0 {
+		go a.backupService.RunScheduled(context.Background(), time.Duration(cfg.BackupInterval), cfg.BackupDir, cfg.BackupRetention)
+	}
+
+	// Check the publication URLs periodically
+	if cfg.ListingsCheckInterval > 0 {
+		go a.watcherService.RunChecks(context.Background(), time.Duration(cfg.ListingsCheckInterval))
+	}
+
+	http.Run(cfg, a.fileService, a.houseService, a.cityService, a.journalService, a.taskService, a.tagService, a.customFieldService, a.dossierService, a.exportService, a.importService, a.scraperService, a.watcherService, a.duplicateService, a.geoService)
+	return nil
+}
@@ -1,11 +1,123 @@
 package config
 
-const (
-	DBPath     = "recherche-maison.db"
-	DBOptions  = "_pragma=foreign_keys(1)&_time_format=sqlite"
-	UploadsDir = "uploads"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DBOptions are the connection options of the SQLite database
+const DBOptions = "_pragma=foreign_keys(1)&_time_format=sqlite"
 
-	Port = 8910
+// Config holds the runtime configuration of the application
+type Config struct {
+	DBPath                string   `json:"db_path"`
+	UploadsDir            string   `json:"uploads_dir"`
+	Port                  int      `json:"port"`
+	MaxUploadSize         int64    `json:"max_upload_size"` // In bytes
+	TaskRemindersInterval Duration `json:"task_reminders_interval"`
+	BackupDir             string   `json:"backup_dir"`
+	BackupInterval        Duration `json:"backup_interval"`         // Scheduled backups are disabled when zero
+	BackupRetention       int      `json:"backup_retention"`        // Number of scheduled backups to keep
+	ListingsCheckInterval Duration `json:"listings_check_interval"` // Listings checks are disabled when zero
+	Geocoder              string   `json:"geocoder"`                // GeocoderBAN or GeocoderHTTP
+	GeocoderURL           string   `json:"geocoder_url"`            // Base URL of the API used by GeocoderHTTP
+}
 
-	MaxUploadSize = 100 * 1024 * 1024 // 100 MB
+// Geocoders locating the addresses of the houses
+const (
+	GeocoderBAN  = "ban"  // Offline, with the imported addresses of the Base Adresse Nationale
+	GeocoderHTTP = "http" // With the geocoding API of the Base Adresse Nationale, or a compatible one
 )
+
+// Default returns the default configuration
+func Default() Config {
+	return Config{
+		DBPath:                "recherche-maison.db",
+		UploadsDir:            "uploads",
+		Port:                  8910,
+		MaxUploadSize:         100 * 1024 * 1024, // 100 MB
+		TaskRemindersInterval: Duration(time.Hour),
+		BackupDir:             "backups",
+		BackupInterval:        0,
+		BackupRetention:       7,
+		ListingsCheckInterval: Duration(24 * time.Hour),
+		Geocoder:              GeocoderBAN,
+		GeocoderURL:           "https://api-adresse.data.gouv.fr",
+	}
+}
+
+// DSN returns the data source name used to open the database
+func (c Config) DSN() string {
+	return "file:" + c.DBPath + "?" + DBOptions
+}
+
+// Validate checks that the configuration values are usable
+func (c Config) Validate() error {
+	if c.DBPath == "" {
+		return fmt.Errorf("database path must not be empty")
+	}
+	if c.UploadsDir == "" {
+		return fmt.Errorf("uploads directory must not be empty")
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("invalid port %d", c.Port)
+	}
+	if c.MaxUploadSize <= 0 {
+		return fmt.Errorf("invalid maximum upload size %d", c.MaxUploadSize)
+	}
+	if c.TaskRemindersInterval <= 0 {
+		return fmt.Errorf("invalid task reminders interval %s", c.TaskRemindersInterval)
+	}
+	if c.BackupInterval > 0 && c.BackupDir == "" {
+		return fmt.Errorf("backup directory must not be empty when scheduled backups are enabled")
+	}
+	if c.BackupInterval < 0 {
+		return fmt.Errorf("invalid backup interval %s", c.BackupInterval)
+	}
+	if c.BackupRetention < 1 {
+		return fmt.Errorf("invalid backup retention %d", c.BackupRetention)
+	}
+	if c.ListingsCheckInterval < 0 {
+		return fmt.Errorf("invalid listings check interval %s", c.ListingsCheckInterval)
+	}
+	if c.Geocoder != GeocoderBAN && c.Geocoder != GeocoderHTTP {
+		return fmt.Errorf("invalid geocoder %q, must be %q or %q", c.Geocoder, GeocoderBAN, GeocoderHTTP)
+	}
+	if c.Geocoder == GeocoderHTTP && c.GeocoderURL == "" {
+		return fmt.Errorf("geocoder URL must not be empty when the HTTP geocoder is used")
+	}
+	return nil
+}
+
+// Duration is a time.Duration written as a string such as "1h30m" in configuration files
+type Duration time.Duration
+
+// String returns the duration formatted like time.Duration
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Set parses a duration, so that Duration can be used as a command-line flag
+func (d *Duration) Set(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads the duration from a string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.Set(value)
+}
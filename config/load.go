@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// EnvPrefix prefixes the environment variables read by the loader
+const EnvPrefix = "RECHERCHE_MAISON_"
+
+// Loader loads the configuration from the defaults, an optional JSON
+// configuration file, environment variables and command-line flags, each
+// source overriding the previous ones
+type Loader struct {
+	fs     *flag.FlagSet
+	file   string
+	values Config
+}
+
+// NewLoader creates a loader and registers the configuration flags in fs
+func NewLoader(fs *flag.FlagSet) *Loader {
+	l := &Loader{fs: fs}
+	defaults := Default()
+
+	fs.StringVar(&l.file, "config", "", "JSON configuration file (env "+EnvPrefix+"CONFIG)")
+	fs.StringVar(&l.values.DBPath, "db", defaults.DBPath, "database file (env "+EnvPrefix+"DB_PATH)")
+	fs.StringVar(&l.values.UploadsDir, "uploads", defaults.UploadsDir, "uploads directory (env "+EnvPrefix+"UPLOADS_DIR)")
+	fs.IntVar(&l.values.Port, "port", defaults.Port, "HTTP port (env "+EnvPrefix+"PORT)")
+	fs.Int64Var(&l.values.MaxUploadSize, "max-upload-size", defaults.MaxUploadSize, "maximum upload size in bytes (env "+EnvPrefix+"MAX_UPLOAD_SIZE)")
+	l.values.TaskRemindersInterval = defaults.TaskRemindersInterval
+	fs.Var(&l.values.TaskRemindersInterval, "task-reminders-interval", "interval between task reminders computations (env "+EnvPrefix+"TASK_REMINDERS_INTERVAL)")
+	fs.StringVar(&l.values.BackupDir, "backup-dir", defaults.BackupDir, "backups directory (env "+EnvPrefix+"BACKUP_DIR)")
+	fs.Var(&l.values.BackupInterval, "backup-interval", "interval between scheduled backups, 0 to disable them (env "+EnvPrefix+"BACKUP_INTERVAL)")
+	fs.IntVar(&l.values.BackupRetention, "backup-retention", defaults.BackupRetention, "number of scheduled backups to keep (env "+EnvPrefix+"BACKUP_RETENTION)")
+	l.values.ListingsCheckInterval = defaults.ListingsCheckInterval
+	fs.Var(&l.values.ListingsCheckInterval, "listings-check-interval", "interval between checks of the publication URLs, 0 to disable them (env "+EnvPrefix+"LISTINGS_CHECK_INTERVAL)")
+
+	fs.StringVar(&l.values.Geocoder, "geocoder", defaults.Geocoder, "geocoder locating the addresses, \""+GeocoderBAN+"\" (offline, imported addresses) or \""+GeocoderHTTP+"\" (env "+EnvPrefix+"GEOCODER)")
+	fs.StringVar(&l.values.GeocoderURL, "geocoder-url", defaults.GeocoderURL, "base URL of the geocoding API used by the HTTP geocoder (env "+EnvPrefix+"GEOCODER_URL)")
+
+	return l
+}
+
+// Load builds the configuration, it must be called after the flags are parsed
+func (l *Loader) Load() (Config, error) {
+	cfg := Default()
+
+	// Configuration file
+	file := l.file
+	if !l.isSet("config") {
+		file = os.Getenv(EnvPrefix + "CONFIG")
+	}
+	if file != "" {
+		if err := loadFile(file, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+
+	// Environment variables
+	if err := loadEnv(&cfg); err != nil {
+		return cfg, err
+	}
+
+	// Command-line flags
+	l.fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "db":
+			cfg.DBPath = l.values.DBPath
+		case "uploads":
+			cfg.UploadsDir = l.values.UploadsDir
+		case "port":
+			cfg.Port = l.values.Port
+		case "max-upload-size":
+			cfg.MaxUploadSize = l.values.MaxUploadSize
+		case "task-reminders-interval":
+			cfg.TaskRemindersInterval = l.values.TaskRemindersInterval
+		case "backup-dir":
+			cfg.BackupDir = l.values.BackupDir
+		case "backup-interval":
+			cfg.BackupInterval = l.values.BackupInterval
+		case "backup-retention":
+			cfg.BackupRetention = l.values.BackupRetention
+		case "listings-check-interval":
+			cfg.ListingsCheckInterval = l.values.ListingsCheckInterval
+		case "geocoder":
+			cfg.Geocoder = l.values.Geocoder
+		case "geocoder-url":
+			cfg.GeocoderURL = l.values.GeocoderURL
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// isSet reports whether a flag has been explicitly set on the command line
+func (l *Loader) isSet(name string) bool {
+	set := false
+	l.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// loadFile overrides cfg with the values defined in a JSON configuration file
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv overrides cfg with the values defined in environment variables
+func loadEnv(cfg *Config) error {
+	if value, ok := os.LookupEnv(EnvPrefix + "DB_PATH"); ok {
+		cfg.DBPath = value
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "UPLOADS_DIR"); ok {
+		cfg.UploadsDir = value
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %sPORT: %w", EnvPrefix, err)
+		}
+		cfg.Port = port
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "MAX_UPLOAD_SIZE"); ok {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %sMAX_UPLOAD_SIZE: %w", EnvPrefix, err)
+		}
+		cfg.MaxUploadSize = size
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "TASK_REMINDERS_INTERVAL"); ok {
+		if err := cfg.TaskRemindersInterval.Set(value); err != nil {
+			return fmt.Errorf("invalid %sTASK_REMINDERS_INTERVAL: %w", EnvPrefix, err)
+		}
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "BACKUP_DIR"); ok {
+		cfg.BackupDir = value
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "BACKUP_INTERVAL"); ok {
+		if err := cfg.BackupInterval.Set(value); err != nil {
+			return fmt.Errorf("invalid %sBACKUP_INTERVAL: %w", EnvPrefix, err)
+		}
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "BACKUP_RETENTION"); ok {
+		retention, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %sBACKUP_RETENTION: %w", EnvPrefix, err)
+		}
+		cfg.BackupRetention = retention
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "LISTINGS_CHECK_INTERVAL"); ok {
+		if err := cfg.ListingsCheckInterval.Set(value); err != nil {
+			return fmt.Errorf("invalid %sLISTINGS_CHECK_INTERVAL: %w", EnvPrefix, err)
+		}
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "GEOCODER"); ok {
+		cfg.Geocoder = value
+	}
+	if value, ok := os.LookupEnv(EnvPrefix + "GEOCODER_URL"); ok {
+		cfg.GeocoderURL = value
+	}
+	return nil
+}
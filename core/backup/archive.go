@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Archive layout: the database snapshot, the uploads tree, then the manifest,
+// which is written last because it holds the checksums computed while
+// archiving the other files
+const (
+	manifestVersion  = 1
+	manifestName     = "manifest.json"
+	databaseName     = "database.db"
+	uploadsDirectory = "uploads"
+)
+
+// Manifest describes the content of a backup archive
+type Manifest struct {
+	Version       int            `json:"version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	SchemaVersion int            `json:"schema_version"`
+	Files         []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a file of a backup archive
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Backup writes a tar.gz archive containing a consistent snapshot of the
+// database and the uploads directory to w. It can safely be used while the
+// server is running.
+func (s *Service) Backup(ctx context.Context, w io.Writer) error {
+	schemaVersion, err := db.SchemaVersion(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	// VACUUM INTO gives a consistent snapshot, even while the database is written to
+	tmpDir, err := os.MkdirTemp("", "recherche-maison-backup-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	snapshotPath := filepath.Join(tmpDir, databaseName)
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", snapshotPath); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	manifest := Manifest{
+		Version:       manifestVersion,
+		CreatedAt:     time.Now().UTC(),
+		SchemaVersion: schemaVersion,
+	}
+
+	file, err := addFile(tw, databaseName, snapshotPath)
+	if err != nil {
+		return err
+	}
+	manifest.Files = append(manifest.Files, file)
+
+	err = filepath.WalkDir(s.uploadsDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.uploadsDir, filePath)
+		if err != nil {
+			return err
+		}
+		name := path.Join(uploadsDirectory, filepath.ToSlash(rel))
+
+		switch {
+		case entry.IsDir():
+			// Directories are archived too, empty ones are expected by the application
+			return tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     name + "/",
+				Mode:     0o755,
+				ModTime:  time.Now(),
+			})
+		case entry.Type().IsRegular():
+			file, err := addFile(tw, name, filePath)
+			if err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive uploads: %w", err)
+	}
+
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     manifestName,
+		Mode:     0o644,
+		Size:     int64(len(manifestContent)),
+		ModTime:  manifest.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if _, err := tw.Write(manifestContent); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return nil
+}
+
+// addFile writes the file at filePath into the archive as name, and returns
+// its manifest entry
+func addFile(tw *tar.Writer, name, filePath string) (ManifestFile, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return ManifestFile{}, err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}); err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+
+	hash := sha256.New()
+	if _, err := io.CopyN(io.MultiWriter(tw, hash), f, info.Size()); err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+
+	return ManifestFile{
+		Path:   name,
+		Size:   info.Size(),
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
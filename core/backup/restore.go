@@ -0,0 +1,259 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Restore replaces the database and the uploads directory with the content of
+// the backup archive at src. The archive is fully extracted and validated
+// before anything is replaced, and the current data is kept next to the
+// restored one. The server must not be running while restoring.
+func Restore(ctx context.Context, cfg config.Config, src string) error {
+	// Extract next to the current data, so that it is replaced by renaming
+	dbStaging := cfg.DBPath + ".restore"
+	uploadsStaging := filepath.Clean(cfg.UploadsDir) + ".restore"
+	for _, staging := range []string{dbStaging, uploadsStaging} {
+		if err := os.RemoveAll(staging); err != nil {
+			return fmt.Errorf("failed to clean previous restoration: %w", err)
+		}
+		defer os.RemoveAll(staging)
+	}
+
+	if err := extract(src, dbStaging, uploadsStaging); err != nil {
+		return err
+	}
+
+	if err := validateDatabase(ctx, dbStaging); err != nil {
+		return err
+	}
+
+	// An archive without uploads still replaces the uploads directory
+	if err := os.MkdirAll(uploadsStaging, 0o755); err != nil {
+		return fmt.Errorf("failed to prepare uploads directory: %w", err)
+	}
+
+	// Replace the database, then the uploads directory. If a rename fails,
+	// the previous ones are undone so that the current data is left as it was.
+	suffix := ".avant-restauration-" + time.Now().Format("20060102-150405")
+	var r renames
+
+	if _, err := os.Stat(cfg.DBPath); err == nil {
+		slog.Info("Keeping current database", "path", cfg.DBPath+suffix)
+		if err := r.rename(cfg.DBPath, cfg.DBPath+suffix); err != nil {
+			return fmt.Errorf("failed to keep current database: %w", err)
+		}
+	}
+	if err := r.rename(dbStaging, cfg.DBPath); err != nil {
+		r.undo()
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+
+	uploadsDir := filepath.Clean(cfg.UploadsDir)
+	if _, err := os.Stat(uploadsDir); err == nil {
+		slog.Info("Keeping current uploads directory", "path", uploadsDir+suffix)
+		if err := r.rename(uploadsDir, uploadsDir+suffix); err != nil {
+			r.undo()
+			return fmt.Errorf("failed to keep current uploads directory: %w", err)
+		}
+	}
+	if err := r.rename(uploadsStaging, uploadsDir); err != nil {
+		r.undo()
+		return fmt.Errorf("failed to replace uploads directory: %w", err)
+	}
+
+	return nil
+}
+
+// renames records the renames made while restoring, so that they can be undone
+type renames [][2]string
+
+// rename renames oldPath to newPath, recording it if it succeeds
+func (r *renames) rename(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	*r = append(*r, [2]string{oldPath, newPath})
+	return nil
+}
+
+// undo renames back the recorded renames, the most recent first
+func (r renames) undo() {
+	for i := len(r) - 1; i >= 0; i-- {
+		oldPath, newPath := r[i][0], r[i][1]
+		if err := os.Rename(newPath, oldPath); err != nil {
+			slog.Error("Failed to undo restoration", "path", newPath, "original", oldPath, "error", err)
+		}
+	}
+}
+
+// extract extracts the archive at src, writing the database to dbPath and the
+// uploads to uploadsDir, and checks its content against its manifest
+func extract(src, dbPath, uploadsDir string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	var manifest *Manifest
+	extracted := make(map[string]ManifestFile)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+
+		name := strings.TrimSuffix(header.Name, "/")
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("%w: invalid path %q", ErrInvalidBackup, header.Name)
+		}
+
+		var dest string
+		switch {
+		case name == manifestName && header.Typeflag == tar.TypeReg:
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return fmt.Errorf("%w: invalid manifest: %w", ErrInvalidBackup, err)
+			}
+			continue
+		case name == databaseName:
+			dest = dbPath
+		case name == uploadsDirectory:
+			dest = uploadsDir
+		case strings.HasPrefix(name, uploadsDirectory+"/"):
+			dest = filepath.Join(uploadsDir, filepath.FromSlash(strings.TrimPrefix(name, uploadsDirectory+"/")))
+		default:
+			return fmt.Errorf("%w: unexpected file %q", ErrInvalidBackup, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			file, err := extractFile(tr, name, dest)
+			if err != nil {
+				return err
+			}
+			extracted[name] = file
+		default:
+			return fmt.Errorf("%w: unsupported entry type for %q", ErrInvalidBackup, header.Name)
+		}
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("%w: missing manifest", ErrInvalidBackup)
+	}
+	if manifest.Version != manifestVersion {
+		return fmt.Errorf("%w: unsupported manifest version %d", ErrInvalidBackup, manifest.Version)
+	}
+
+	for _, expected := range manifest.Files {
+		file, ok := extracted[expected.Path]
+		if !ok {
+			return fmt.Errorf("%w: missing file %q", ErrInvalidBackup, expected.Path)
+		}
+		if file != expected {
+			return fmt.Errorf("%w: checksum mismatch for %q", ErrInvalidBackup, expected.Path)
+		}
+		delete(extracted, expected.Path)
+	}
+	for name := range extracted {
+		return fmt.Errorf("%w: file %q is not listed in the manifest", ErrInvalidBackup, name)
+	}
+
+	if _, err := os.Stat(dbPath); err != nil {
+		return fmt.Errorf("%w: missing database", ErrInvalidBackup)
+	}
+
+	return nil
+}
+
+// extractFile writes the current archive entry to dest, and returns its
+// manifest entry as computed from the extracted content
+func extractFile(r io.Reader, name, dest string) (ManifestFile, error) {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return ManifestFile{}, err
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), r)
+	if err != nil {
+		out.Close()
+		return ManifestFile{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	if err := out.Close(); err != nil {
+		return ManifestFile{}, err
+	}
+
+	return ManifestFile{
+		Path:   name,
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// validateDatabase checks that the file at path is a sane database that the
+// application is able to use
+func validateDatabase(ctx context.Context, path string) error {
+	conn, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("%w: integrity check failed: %s", ErrInvalidBackup, result)
+	}
+
+	version, err := db.SchemaVersion(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	latest, err := db.LatestVersion()
+	if err != nil {
+		return err
+	}
+	if version > latest {
+		return fmt.Errorf("%w: %w", ErrInvalidBackup, db.ErrDatabaseTooRecent)
+	}
+
+	return nil
+}
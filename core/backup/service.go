@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidBackup is returned when a backup cannot be restored
+var ErrInvalidBackup = errors.New("invalid backup")
+
+const (
+	archivePrefix          = "recherche-maison-"
+	scheduledArchivePrefix = "recherche-maison-auto-"
+	archiveExtension       = ".tar.gz"
+)
+
+// Service provides methods for backing up the data
+type Service struct {
+	db         *sql.DB
+	uploadsDir string
+}
+
+// NewService creates a new backup service
+func NewService(dbConn *sql.DB, uploadsDir string) *Service {
+	return &Service{
+		db:         dbConn,
+		uploadsDir: uploadsDir,
+	}
+}
+
+// ArchiveName returns the file name of a manual backup archive made at t
+func ArchiveName(t time.Time) string {
+	return archivePrefix + t.Format("20060102-150405") + archiveExtension
+}
+
+// BackupToFile writes a backup archive to dest, which is only created once
+// the archive is complete
+func (s *Service) BackupToFile(ctx context.Context, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	tmpPath := dest + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	if err := s.Backup(ctx, f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	return nil
+}
+
+// RunScheduled writes a backup archive into dir every interval, keeping only
+// the retention most recent scheduled backups, until ctx is cancelled
+func (s *Service) RunScheduled(ctx context.Context, interval time.Duration, dir string, retention int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		dest := filepath.Join(dir, scheduledArchivePrefix+time.Now().Format("20060102-150405")+archiveExtension)
+		if err := s.BackupToFile(ctx, dest); err != nil {
+			slog.Error("Failed to run scheduled backup", "error", err)
+			continue
+		}
+		slog.Info("Scheduled backup done", "path", dest)
+
+		if err := pruneScheduled(dir, retention); err != nil {
+			slog.Error("Failed to remove old scheduled backups", "error", err)
+		}
+	}
+}
+
+// pruneScheduled removes the oldest scheduled backups in dir, keeping the
+// retention most recent ones. Manual backups are never removed.
+func pruneScheduled(dir string, retention int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var archives []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, scheduledArchivePrefix) && strings.HasSuffix(name, archiveExtension) {
+			archives = append(archives, name)
+		}
+	}
+
+	if len(archives) <= retention {
+		return nil
+	}
+
+	// Names contain the timestamp, sorting them sorts the backups by date
+	sort.Strings(archives)
+	for _, name := range archives[:len(archives)-retention] {
+		slog.Info("Removing old scheduled backup", "name", name)
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package city
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Columns of the usual lists of communes, such as the La Poste postal codes
+// file and the communes-departement-region file of data.gouv.fr, as
+// lower-case letters and digits, by order of preference
+var (
+	communeNameColumns        = []string{"nomcommunecomplet", "nomcommune", "nomdelacommune", "libellecommune", "nom"}
+	communePostalCodeColumns  = []string{"codepostal", "cp"}
+	communeINSEECodeColumns   = []string{"codecommuneinsee", "codeinsee", "insee", "codgeo"}
+	communeDepartmentColumns  = []string{"codedepartement", "codedep", "departement", "dep"}
+	communePopulationColumns  = []string{"population", "pop"}
+	communeLatitudeColumns    = []string{"latitude", "lat"}
+	communeLongitudeColumns   = []string{"longitude", "lon", "lng"}
+	communeCoordinatesColumns = []string{"coordonneesgps", "coordonneesgeographiques", "geopoint"}
+)
+
+// ImportCommunes creates the selected communes of a CSV list of communes as
+// cities, in a single transaction, and returns the numbers of created cities
+// and of existing cities completed with the details of the commune. A city
+// without postal code is completed by the commune with the same name.
+func (s *Service) ImportCommunes(ctx context.Context, r io.Reader, selection models.CommuneSelection) (int, int, error) {
+	communes, err := readCommunes(r, selection)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txService := s.WithTx(tx)
+
+	created, completed := 0, 0
+	for _, commune := range communes {
+		existing, err := txService.queries.GetCityByNameAndPostalCode(ctx, commune.Name, commune.PostalCode)
+		if err != nil && commune.PostalCode != "" {
+			existing, err = txService.queries.GetCityByNameAndPostalCode(ctx, commune.Name, "")
+		}
+		if err != nil {
+			if err := txService.CreateCity(ctx, commune.City()); err != nil {
+				return 0, 0, err
+			}
+			created++
+			continue
+		}
+
+		city := models.FromDBCity(existing)
+		merged := mergeCity(city, commune.City())
+		if merged == city {
+			continue
+		}
+		if err := txService.UpdateCity(ctx, merged); err != nil {
+			return 0, 0, err
+		}
+		completed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, completed, nil
+}
+
+// readCommunes reads the selected communes of a CSV list of communes. The
+// separator is detected among semicolons, commas and tabs. Communes listed
+// several times with the same postal code are returned once.
+func readCommunes(r io.Reader, selection models.CommuneSelection) ([]models.Commune, error) {
+	buffered := bufio.NewReader(r)
+	firstLine, _ := buffered.Peek(4096)
+	firstLine, _, _ = bytes.Cut(firstLine, []byte("\n"))
+	separator := ';'
+	count := bytes.Count(firstLine, []byte(";"))
+	for _, candidate := range []rune{',', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(candidate))); n > count {
+			separator, count = candidate, n
+		}
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCommunesFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := columns[communeColumnName(name)]; !ok {
+			columns[communeColumnName(name)] = i
+		}
+	}
+	column := func(names []string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	nameColumn := column(communeNameColumns)
+	postalCodeColumn := column(communePostalCodeColumns)
+	inseeCodeColumn := column(communeINSEECodeColumns)
+	departmentColumn := column(communeDepartmentColumns)
+	populationColumn := column(communePopulationColumns)
+	latitudeColumn := column(communeLatitudeColumns)
+	longitudeColumn := column(communeLongitudeColumns)
+	coordinatesColumn := column(communeCoordinatesColumns)
+	if nameColumn < 0 || postalCodeColumn < 0 {
+		return nil, fmt.Errorf("%w: missing name or postal code column", ErrInvalidCommunesFile)
+	}
+
+	var communes []models.Commune
+	seen := map[[2]string]bool{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCommunesFile, err)
+		}
+
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		commune := models.Commune{
+			Name:       field(nameColumn),
+			PostalCode: field(postalCodeColumn),
+			INSEECode:  field(inseeCodeColumn),
+			Department: models.NormalizeDepartment(field(departmentColumn)),
+		}
+		if commune.Name == "" {
+			continue
+		}
+		// Postal codes starting with a zero may have lost it in a spreadsheet
+		if len(commune.PostalCode) == 4 {
+			commune.PostalCode = "0" + commune.PostalCode
+		}
+		if len(commune.INSEECode) == 4 {
+			commune.INSEECode = "0" + commune.INSEECode
+		}
+		if commune.Department == "" {
+			commune.Department = models.DepartmentFromINSEECode(commune.INSEECode)
+		}
+		if population, err := strconv.ParseInt(field(populationColumn), 10, 64); err == nil && population > 0 {
+			commune.Population = population
+		}
+		if coordinatesColumn >= 0 {
+			commune.Coordinates, _ = models.ParseCoordinates(field(coordinatesColumn))
+		} else {
+			commune.Coordinates, _ = models.ParseCoordinates(field(latitudeColumn) + ";" + field(longitudeColumn))
+		}
+
+		key := [2]string{commune.Name, commune.PostalCode}
+		if seen[key] || !selection.Matches(commune) {
+			continue
+		}
+		seen[key] = true
+		communes = append(communes, commune)
+	}
+
+	return communes, nil
+}
+
+// communeColumnName returns the name of a column as lower-case letters and
+// digits, without BOM nor accents
+func communeColumnName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch r {
+		case 'é', 'è', 'ê':
+			r = 'e'
+		case 'ô':
+			r = 'o'
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
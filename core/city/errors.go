@@ -6,4 +6,18 @@ import "errors"
 var (
 	// ErrCityInUse is returned when attempting to delete a city that is used by houses
 	ErrCityInUse = errors.New("la ville est utilisée par des maisons et ne peut pas être supprimée")
+	// ErrCityExists is returned when another city has the same name and postal code
+	ErrCityExists = errors.New("une ville porte déjà ce nom avec ce code postal")
+	// ErrCityNotFound is returned when a city does not exist, or when no city matches a name
+	ErrCityNotFound = errors.New("la ville est introuvable")
+	// ErrCityAmbiguous is returned when several cities match a name without postal code
+	ErrCityAmbiguous = errors.New("plusieurs villes portent ce nom, précisez le code postal")
+	// ErrInvalidCommunesFile is returned when an imported file is not a list of communes
+	ErrInvalidCommunesFile = errors.New("le fichier n'est pas une liste de communes")
+	// ErrNeighbourhoodInUse is returned when attempting to delete a neighbourhood that is used by houses
+	ErrNeighbourhoodInUse = errors.New("le quartier est utilisé par des maisons et ne peut pas être supprimé")
+	// ErrNeighbourhoodExists is returned when another neighbourhood of the city has the same name
+	ErrNeighbourhoodExists = errors.New("un quartier de la ville porte déjà ce nom")
+	// ErrMergeSameCity is returned when attempting to merge a city into itself
+	ErrMergeSameCity = errors.New("une ville ne peut pas être fusionnée avec elle-même")
 )
@@ -0,0 +1,90 @@
+package city
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// MergeCities moves all the houses and neighbourhoods of the source city to
+// the target city, then deletes the source city, in a single transaction.
+// The details of the target city are kept, completed with those of the
+// source city, and the notes of both cities are combined.
+func (s *Service) MergeCities(ctx context.Context, targetID, sourceID int64) error {
+	if targetID == sourceID {
+		return ErrMergeSameCity
+	}
+
+	return s.inTx(ctx, func(s *Service) error {
+		target, err := s.GetCity(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		source, err := s.GetCity(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+
+		merged := mergeCity(target, source)
+
+		// The postal code of the source city is not used when a third city
+		// already has the same name and postal code
+		if merged.PostalCode != target.PostalCode {
+			existing, err := s.queries.GetCityByNameAndPostalCode(ctx, merged.Name, merged.PostalCode)
+			if err == nil && existing.ID != sourceID {
+				merged.PostalCode = target.PostalCode
+			}
+		}
+
+		if err := s.queries.MoveCityHouses(ctx, targetID, sourceID); err != nil {
+			return fmt.Errorf("failed to move houses: %w", err)
+		}
+		if err := moveNeighbourhoods(ctx, s.queries, targetID, sourceID); err != nil {
+			return fmt.Errorf("failed to move neighbourhoods: %w", err)
+		}
+		if err := s.queries.DeleteCity(ctx, sourceID); err != nil {
+			return fmt.Errorf("failed to delete city: %w", err)
+		}
+		if err := s.queries.UpdateCity(ctx, db.UpdateCityParams{
+			ID:         merged.ID,
+			Name:       merged.Name,
+			PostalCode: merged.PostalCode,
+			INSEECode:  merged.INSEECode,
+			Department: merged.Department,
+			Population: merged.Population,
+			Notes:      merged.Notes,
+		}); err != nil {
+			return fmt.Errorf("failed to update city: %w", err)
+		}
+		return nil
+	})
+}
+
+// mergeCity returns the target city, completed with the details of the
+// source city. The postal code may be completed too, because the source city
+// is deleted before the target city is updated.
+func mergeCity(target, source models.City) models.City {
+	merged := target
+	if merged.PostalCode == "" {
+		merged.PostalCode = source.PostalCode
+	}
+	if merged.INSEECode == "" {
+		merged.INSEECode = source.INSEECode
+	}
+	if merged.Department == "" {
+		merged.Department = source.Department
+	}
+	if merged.Population == 0 {
+		merged.Population = source.Population
+	}
+	switch {
+	case source.Notes == "" || source.Notes == target.Notes:
+	case target.Notes == "":
+		merged.Notes = source.Notes
+	default:
+		merged.Notes = target.Notes + "\n\n" + source.Notes
+	}
+	return merged
+}
@@ -0,0 +1,158 @@
+package city
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// GetNeighbourhood retrieves a neighbourhood by ID
+func (s *Service) GetNeighbourhood(ctx context.Context, id int64) (models.Neighbourhood, error) {
+	neighbourhood, err := s.queries.GetNeighbourhood(ctx, id)
+	if err != nil {
+		slog.Error("Failed to get neighbourhood", "id", id, "error", err)
+		return models.Neighbourhood{}, err
+	}
+	return models.FromDBNeighbourhood(neighbourhood), nil
+}
+
+// ListNeighbourhoods retrieves the neighbourhoods of all cities
+func (s *Service) ListNeighbourhoods(ctx context.Context) ([]models.Neighbourhood, error) {
+	neighbourhoods, err := s.queries.ListNeighbourhoods(ctx)
+	if err != nil {
+		slog.Error("Failed to list neighbourhoods", "error", err)
+		return nil, err
+	}
+	return models.FromDBNeighbourhoods(neighbourhoods), nil
+}
+
+// ListCityNeighbourhoods retrieves the neighbourhoods of a city
+func (s *Service) ListCityNeighbourhoods(ctx context.Context, cityID int64) ([]models.Neighbourhood, error) {
+	neighbourhoods, err := s.queries.ListCityNeighbourhoods(ctx, cityID)
+	if err != nil {
+		slog.Error("Failed to list neighbourhoods", "city_id", cityID, "error", err)
+		return nil, err
+	}
+	return models.FromDBNeighbourhoods(neighbourhoods), nil
+}
+
+// FindOrCreateNeighbourhood returns the ID of the neighbourhood of the city
+// with the given name, creating it if needed
+func (s *Service) FindOrCreateNeighbourhood(ctx context.Context, cityID int64, name string) (int64, error) {
+	neighbourhood, err := s.queries.GetNeighbourhoodByName(ctx, cityID, name)
+	if err == nil {
+		return neighbourhood.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		slog.Error("Failed to get neighbourhood", "city_id", cityID, "name", name, "error", err)
+		return 0, err
+	}
+
+	if err := s.CreateNeighbourhood(ctx, cityID, name); err != nil {
+		return 0, err
+	}
+
+	created, err := s.queries.GetNeighbourhoodByName(ctx, cityID, name)
+	if err != nil {
+		slog.Error("Failed to get neighbourhood", "city_id", cityID, "name", name, "error", err)
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// CreateNeighbourhood creates a new neighbourhood in a city
+func (s *Service) CreateNeighbourhood(ctx context.Context, cityID int64, name string) error {
+	if err := s.checkNeighbourhoodUnique(ctx, cityID, name, 0); err != nil {
+		return err
+	}
+
+	if err := s.queries.CreateNeighbourhood(ctx, cityID, name); err != nil {
+		slog.Error("Failed to create neighbourhood", "city_id", cityID, "name", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// RenameNeighbourhood renames an existing neighbourhood
+func (s *Service) RenameNeighbourhood(ctx context.Context, id int64, name string) error {
+	neighbourhood, err := s.GetNeighbourhood(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkNeighbourhoodUnique(ctx, neighbourhood.CityID, name, id); err != nil {
+		return err
+	}
+
+	if err := s.queries.RenameNeighbourhood(ctx, name, id); err != nil {
+		slog.Error("Failed to rename neighbourhood", "id", id, "name", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// checkNeighbourhoodUnique returns ErrNeighbourhoodExists if another
+// neighbourhood of the city has the same name
+func (s *Service) checkNeighbourhoodUnique(ctx context.Context, cityID int64, name string, id int64) error {
+	existing, err := s.queries.GetNeighbourhoodByName(ctx, cityID, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Error("Failed to get neighbourhood", "city_id", cityID, "name", name, "error", err)
+		return err
+	}
+	if existing.ID != id {
+		return ErrNeighbourhoodExists
+	}
+	return nil
+}
+
+// DeleteNeighbourhood deletes a neighbourhood if it's not used by any house
+func (s *Service) DeleteNeighbourhood(ctx context.Context, id int64) error {
+	neighbourhood, err := s.queries.GetNeighbourhood(ctx, id)
+	if err != nil {
+		slog.Error("Failed to get neighbourhood", "id", id, "error", err)
+		return err
+	}
+	if neighbourhood.IsUsed {
+		return ErrNeighbourhoodInUse
+	}
+
+	if err := s.queries.DeleteNeighbourhood(ctx, id); err != nil {
+		slog.Error("Failed to delete neighbourhood", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
+
+// moveNeighbourhoods moves the neighbourhoods of the source city to the
+// target city. A neighbourhood named like a neighbourhood of the target city
+// is merged into it.
+func moveNeighbourhoods(ctx context.Context, queries *db.Queries, targetID, sourceID int64) error {
+	neighbourhoods, err := queries.ListCityNeighbourhoods(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	for _, neighbourhood := range neighbourhoods {
+		existing, err := queries.GetNeighbourhoodByName(ctx, targetID, neighbourhood.Name)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := queries.MoveNeighbourhoodHouses(ctx, models.NeighbourhoodID(existing.ID), models.NeighbourhoodID(neighbourhood.ID)); err != nil {
+			return err
+		}
+		if err := queries.DeleteNeighbourhood(ctx, neighbourhood.ID); err != nil {
+			return err
+		}
+	}
+
+	return queries.MoveCityNeighbourhoods(ctx, targetID, sourceID)
+}
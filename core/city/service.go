@@ -2,7 +2,10 @@ package city
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
+	"strings"
 
 	"github.com/willoma/recherche-maison/db"
 	"github.com/willoma/recherche-maison/models"
@@ -11,18 +14,59 @@ import (
 // Service provides methods for managing cities
 type Service struct {
 	queries *db.Queries
+	db      *sql.DB
+	tx      *sql.Tx // Current transaction, nil outside of a transaction
 }
 
 // NewService creates a new city service
-func NewService(queries *db.Queries) *Service {
+func NewService(queries *db.Queries, dbConn *sql.DB) *Service {
 	return &Service{
 		queries: queries,
+		db:      dbConn,
 	}
 }
 
-// GetCity retrieves a city by ID
+// WithTx returns a service running its queries in the given transaction
+func (s *Service) WithTx(tx *sql.Tx) *Service {
+	return &Service{
+		queries: s.queries.WithTx(tx),
+		db:      s.db,
+		tx:      tx,
+	}
+}
+
+// inTx runs fn with a service running its queries in a transaction, which is
+// committed if fn succeeds. The current transaction of the service is used
+// if any, and is then committed by its owner.
+func (s *Service) inTx(ctx context.Context, fn func(*Service) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.Error("Failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(s.WithTx(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.Error("Failed to commit transaction", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetCity retrieves a city by ID, returning ErrCityNotFound if it does not exist
 func (s *Service) GetCity(ctx context.Context, id int64) (models.City, error) {
 	city, err := s.queries.GetCity(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.City{}, ErrCityNotFound
+	}
 	if err != nil {
 		slog.Error("Failed to get city", "id", id, "error", err)
 		return models.City{}, err
@@ -30,6 +74,97 @@ func (s *Service) GetCity(ctx context.Context, id int64) (models.City, error) {
 	return models.FromDBCity(city), nil
 }
 
+// FindCity retrieves a city by name and postal code, returning
+// ErrCityNotFound if it does not exist
+func (s *Service) FindCity(ctx context.Context, name, postalCode string) (models.City, error) {
+	city, err := s.queries.GetCityByNameAndPostalCode(ctx, name, postalCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.City{}, ErrCityNotFound
+	}
+	if err != nil {
+		slog.Error("Failed to get city", "name", name, "postal_code", postalCode, "error", err)
+		return models.City{}, err
+	}
+	return models.FromDBCity(city), nil
+}
+
+// LookupCity retrieves a city by its display name, as in "Saint-Martin
+// (44000)", or by its name when no other city has the same name. The case is
+// ignored when no city matches exactly.
+func (s *Service) LookupCity(ctx context.Context, ref string) (models.City, error) {
+	ref = strings.TrimSpace(ref)
+	cities, err := s.ListCities(ctx)
+	if err != nil {
+		return models.City{}, err
+	}
+
+	// Exact matches first, then matches ignoring the case
+	var found, folded []models.City
+	for _, city := range cities {
+		if city.DisplayName() == ref {
+			return city, nil
+		}
+		if city.Name == ref {
+			found = append(found, city)
+		} else if strings.EqualFold(city.DisplayName(), ref) || strings.EqualFold(city.Name, ref) {
+			folded = append(folded, city)
+		}
+	}
+	if len(found) == 0 {
+		found = folded
+	}
+
+	switch len(found) {
+	case 0:
+		return models.City{}, ErrCityNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return models.City{}, ErrCityAmbiguous
+	}
+}
+
+// SearchCities retrieves the first cities whose name contains the search, or
+// whose postal code starts with it, the cities used by houses first
+func (s *Service) SearchCities(ctx context.Context, search string) ([]models.City, error) {
+	cities, err := s.queries.SearchCities(ctx, search)
+	if err != nil {
+		slog.Error("Failed to search cities", "search", search, "error", err)
+		return nil, err
+	}
+	return models.FromDBCities(cities), nil
+}
+
+// FindOrCreateCity returns the ID of the city with the given name and postal
+// code, creating it if needed. Without postal code, the first city with the
+// given name is used.
+func (s *Service) FindOrCreateCity(ctx context.Context, name, postalCode string) (int64, error) {
+	var city db.City
+	var err error
+	if postalCode == "" {
+		city, err = s.queries.GetCityByName(ctx, name)
+	} else {
+		city, err = s.queries.GetCityByNameAndPostalCode(ctx, name, postalCode)
+	}
+	if err == nil {
+		return city.ID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		slog.Error("Failed to get city", "name", name, "error", err)
+		return 0, err
+	}
+
+	if err := s.CreateCity(ctx, models.City{Name: name, PostalCode: postalCode}); err != nil {
+		return 0, err
+	}
+
+	created, err := s.FindCity(ctx, name, postalCode)
+	if err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
 // ListCities retrieves all cities
 func (s *Service) ListCities(ctx context.Context) ([]models.City, error) {
 	cities, err := s.queries.ListCities(ctx)
@@ -40,32 +175,91 @@ func (s *Service) ListCities(ctx context.Context) ([]models.City, error) {
 	return models.FromDBCities(cities), nil
 }
 
-// CreateCity creates a new city
-func (s *Service) CreateCity(ctx context.Context, name string) error {
-	err := s.queries.CreateCity(ctx, name)
-	if err != nil {
-		slog.Error("Failed to create city", "name", name, "error", err)
-		return err
-	}
-	return nil
+// CreateCity creates a new city, returning ErrCityExists if another city has
+// the same name and postal code
+func (s *Service) CreateCity(ctx context.Context, city models.City) error {
+	return s.inTx(ctx, func(s *Service) error {
+		if err := s.checkUnique(ctx, city); err != nil {
+			return err
+		}
+
+		err := s.queries.CreateCity(ctx, db.CreateCityParams{
+			Name:       city.Name,
+			PostalCode: city.PostalCode,
+			INSEECode:  city.INSEECode,
+			Department: city.Department,
+			Population: city.Population,
+			Notes:      city.Notes,
+		})
+		if err != nil {
+			slog.Error("Failed to create city", "name", city.Name, "error", err)
+			return err
+		}
+		return nil
+	})
 }
 
-// UpdateCity updates an existing city
-func (s *Service) UpdateCity(ctx context.Context, id int64, name string) error {
-	err := s.queries.UpdateCity(ctx, name, id)
+// UpdateCity updates an existing city, returning ErrCityNotFound if it does
+// not exist and ErrCityExists if another city has the same name and postal code
+func (s *Service) UpdateCity(ctx context.Context, city models.City) error {
+	return s.inTx(ctx, func(s *Service) error {
+		if _, err := s.GetCity(ctx, city.ID); err != nil {
+			return err
+		}
+		if err := s.checkUnique(ctx, city); err != nil {
+			return err
+		}
+
+		err := s.queries.UpdateCity(ctx, db.UpdateCityParams{
+			ID:         city.ID,
+			Name:       city.Name,
+			PostalCode: city.PostalCode,
+			INSEECode:  city.INSEECode,
+			Department: city.Department,
+			Population: city.Population,
+			Notes:      city.Notes,
+		})
+		if err != nil {
+			slog.Error("Failed to update city", "id", city.ID, "name", city.Name, "error", err)
+			return err
+		}
+		return nil
+	})
+}
+
+// checkUnique returns ErrCityExists if another city has the same name and postal code
+func (s *Service) checkUnique(ctx context.Context, city models.City) error {
+	existing, err := s.queries.GetCityByNameAndPostalCode(ctx, city.Name, city.PostalCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
 	if err != nil {
-		slog.Error("Failed to update city", "id", id, "name", name, "error", err)
+		slog.Error("Failed to get city", "name", city.Name, "postal_code", city.PostalCode, "error", err)
 		return err
 	}
+	if existing.ID != city.ID {
+		return ErrCityExists
+	}
 	return nil
 }
 
-// DeleteCity deletes a city if it's not used by any house
+// DeleteCity deletes a city if it's not used by any house, returning
+// ErrCityNotFound if it does not exist and ErrCityInUse if it is used. The
+// use is checked in the same transaction as the deletion.
 func (s *Service) DeleteCity(ctx context.Context, id int64) error {
-	if err := s.queries.DeleteCity(ctx, id); err != nil {
-		slog.Error("Failed to delete city", "id", id, "error", err)
-		return err
-	}
+	return s.inTx(ctx, func(s *Service) error {
+		city, err := s.GetCity(ctx, id)
+		if err != nil {
+			return err
+		}
+		if city.IsUsed {
+			return ErrCityInUse
+		}
 
-	return nil
+		if err := s.queries.DeleteCity(ctx, id); err != nil {
+			slog.Error("Failed to delete city", "id", id, "error", err)
+			return err
+		}
+		return nil
+	})
 }
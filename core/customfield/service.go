@@ -0,0 +1,157 @@
+package customfield
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+var (
+	// ErrFieldExists is returned when another custom field has the same name
+	ErrFieldExists = errors.New("un critère porte déjà ce nom")
+	// ErrChoicesRequired is returned when a choice field has no choices
+	ErrChoicesRequired = errors.New("un critère de type choix doit avoir des choix possibles")
+)
+
+// ChoicesInUseError is returned when an update removes choices that are
+// still the value of the field for some houses
+type ChoicesInUseError struct {
+	Choices []string
+}
+
+func (e ChoicesInUseError) Error() string {
+	return "choices still used by houses: " + strings.Join(e.Choices, ", ")
+}
+
+// Service provides methods for managing custom field definitions
+type Service struct {
+	queries *db.Queries
+}
+
+// NewService creates a new custom field service
+func NewService(queries *db.Queries) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
+
+// ListFields retrieves all custom field definitions
+func (s *Service) ListFields(ctx context.Context) ([]models.CustomField, error) {
+	fields, err := s.queries.ListCustomFields(ctx)
+	if err != nil {
+		slog.Error("Failed to list custom fields", "error", err)
+		return nil, err
+	}
+	return models.FromDBCustomFields(fields), nil
+}
+
+// CreateField creates a new custom field definition, returning
+// ErrFieldExists if another field has the same name and ErrChoicesRequired if
+// a choice field has no choices
+func (s *Service) CreateField(ctx context.Context, field models.CustomField) error {
+	if field.Type == models.CustomFieldChoice && len(field.Choices) == 0 {
+		return ErrChoicesRequired
+	}
+	if err := s.checkUnique(ctx, 0, field.Name); err != nil {
+		return err
+	}
+
+	if err := s.queries.CreateCustomField(ctx, db.CreateCustomFieldParams{
+		Name:      field.Name,
+		FieldType: field.Type,
+		Unit:      field.Unit,
+		Choices:   models.FormatCustomFieldChoices(field.Choices),
+	}); err != nil {
+		slog.Error("Failed to create custom field", "name", field.Name, "type", field.Type, "error", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateField updates the name, unit and choices of an existing custom field definition,
+// its type cannot be changed. ErrFieldExists is returned if another field has
+// the same name, ErrChoicesRequired if a choice field is left without choices
+// and ChoicesInUseError if choices that houses still use are removed.
+func (s *Service) UpdateField(ctx context.Context, field models.CustomField) error {
+	dbField, err := s.queries.GetCustomField(ctx, field.ID)
+	if err != nil {
+		slog.Error("Failed to get custom field", "id", field.ID, "error", err)
+		return err
+	}
+	if dbField.FieldType == models.CustomFieldChoice {
+		if err := s.checkChoices(ctx, field); err != nil {
+			return err
+		}
+	}
+	if err := s.checkUnique(ctx, field.ID, field.Name); err != nil {
+		return err
+	}
+
+	if err := s.queries.UpdateCustomField(ctx, db.UpdateCustomFieldParams{
+		ID:      field.ID,
+		Name:    field.Name,
+		Unit:    field.Unit,
+		Choices: models.FormatCustomFieldChoices(field.Choices),
+	}); err != nil {
+		slog.Error("Failed to update custom field", "id", field.ID, "name", field.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// checkChoices returns ErrChoicesRequired if a choice field has no choices,
+// and ChoicesInUseError if some houses have a value which is not one of them
+func (s *Service) checkChoices(ctx context.Context, field models.CustomField) error {
+	if len(field.Choices) == 0 {
+		return ErrChoicesRequired
+	}
+
+	used, err := s.queries.ListCustomFieldUsedValues(ctx, field.ID)
+	if err != nil {
+		slog.Error("Failed to list custom field values", "id", field.ID, "error", err)
+		return err
+	}
+
+	var removed []string
+	for _, value := range used {
+		if !slices.Contains(field.Choices, value) {
+			removed = append(removed, value)
+		}
+	}
+	if len(removed) > 0 {
+		return ChoicesInUseError{Choices: removed}
+	}
+	return nil
+}
+
+// checkUnique returns ErrFieldExists if a field other than the one with the
+// given ID has the given name
+func (s *Service) checkUnique(ctx context.Context, id int64, name string) error {
+	existing, err := s.queries.GetCustomFieldByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Error("Failed to get custom field", "name", name, "error", err)
+		return err
+	}
+	if existing.ID != id {
+		return ErrFieldExists
+	}
+	return nil
+}
+
+// DeleteField deletes a custom field definition and all its values
+func (s *Service) DeleteField(ctx context.Context, id int64) error {
+	if err := s.queries.DeleteCustomField(ctx, id); err != nil {
+		slog.Error("Failed to delete custom field", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package dossier
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Service provides methods for building house dossiers, ZIP archives
+// gathering everything known about a house
+type Service struct {
+	houseService       *house.Service
+	customFieldService *customfield.Service
+	uploadsDir         string
+}
+
+// NewService creates a new dossier service
+func NewService(houseService *house.Service, customFieldService *customfield.Service, uploadsDir string) *Service {
+	return &Service{
+		houseService:       houseService,
+		customFieldService: customFieldService,
+		uploadsDir:         uploadsDir,
+	}
+}
+
+// WriteZIP streams the dossier of a house to w, as a ZIP archive containing a
+// Markdown summary, the photos and the attachments of the house. Nothing is
+// written to disk.
+func (s *Service) WriteZIP(ctx context.Context, w io.Writer, h models.House) error {
+	publicationURLs, err := s.houseService.GetPublicationURLs(ctx, h.ID)
+	if err != nil {
+		return err
+	}
+	fields, err := s.customFieldService.ListFields(ctx)
+	if err != nil {
+		return err
+	}
+	photos, err := s.houseService.GetPhotos(ctx, h.ID)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.houseService.GetAttachments(ctx, h.ID)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+
+	summaryFile, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     "resume.md",
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	if _, err := io.WriteString(summaryFile, summary(h, publicationURLs, fields, photos, attachments)); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	houseDir := filepath.Join(s.uploadsDir, strconv.FormatInt(h.ID, 10))
+	for _, photo := range photos {
+		// Photos are already compressed
+		if err := addFile(zw, "photos/"+photo, filepath.Join(houseDir, "photos", photo), zip.Store); err != nil {
+			return err
+		}
+	}
+	for _, attachment := range attachments {
+		if err := addFile(zw, "pieces-jointes/"+attachment, filepath.Join(houseDir, "attachments", attachment), zip.Deflate); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write dossier: %w", err)
+	}
+	return nil
+}
+
+// addFile copies the file at filePath into the archive as name
+func addFile(zw *zip.Writer, name, filePath string, method uint16) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+
+	dest, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   method,
+		Modified: info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := io.Copy(dest, f); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	return nil
+}
+
+// summary returns the Markdown summary of a house
+func summary(h models.House, publicationURLs []models.PublicationURL, fields []models.CustomField, photos, attachments []string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", h.Title)
+
+	b.WriteString("## Informations générales\n\n")
+	writeRow(&b, "Ville", h.CityName)
+	writeRow(&b, "Adresse", h.Address)
+	writeRow(&b, "Coordonnées", h.Coordinates().String())
+	writeRow(&b, "Prix", formatUnit(h.Price, "€"))
+	writeRow(&b, "Surface", formatUnit(h.Surface, "m²"))
+	if h.Price > 0 && h.Surface > 0 {
+		writeRow(&b, "Prix au m²", formatUnit(h.Price/h.Surface, "€/m²"))
+	}
+	writeRow(&b, "Pièces", formatUnit(h.Rooms, ""))
+	writeRow(&b, "Chambres", formatUnit(h.Bedrooms, ""))
+	writeRow(&b, "Salles de bain", formatUnit(h.Bathrooms, ""))
+	writeRow(&b, "Niveaux", formatUnit(h.Floors, ""))
+	writeRow(&b, "Année de construction", formatUnit(h.ConstructionYear, ""))
+	writeRow(&b, "Type de maison", h.HouseType)
+	writeRow(&b, "Surface du terrain", formatUnit(h.LandSurface, "m²"))
+	if h.HasGarage {
+		writeRow(&b, "Garage", "Oui")
+	} else {
+		writeRow(&b, "Garage", "Non")
+	}
+	writeRow(&b, "Places de stationnement extérieures", formatUnit(h.OutdoorParkingSpaces, ""))
+	if len(h.Tags) > 0 {
+		names := make([]string, len(h.Tags))
+		for i, tag := range h.Tags {
+			names[i] = tag.Name
+		}
+		writeRow(&b, "Étiquettes", strings.Join(names, ", "))
+	}
+	writeRow(&b, "Date d'ajout", h.CreatedAt.Format("02/01/2006"))
+	writeRow(&b, "Dernière modification", h.UpdatedAt.Format("02/01/2006"))
+	b.WriteString("\n")
+
+	if len(fields) > 0 {
+		b.WriteString("## Critères personnalisés\n\n")
+		for _, field := range fields {
+			writeRow(&b, field.Name, field.FormatValue(h.CustomValues[field.ID]))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Publications\n\n")
+	if len(publicationURLs) == 0 {
+		b.WriteString("Aucune publication\n")
+	}
+	for _, pub := range publicationURLs {
+		fmt.Fprintf(&b, "- <%s> (%s)\n", pub.URL, pub.PublicationDate.Format("02/01/2006"))
+	}
+	b.WriteString("\n")
+
+	if h.Notes != "" {
+		fmt.Fprintf(&b, "## Notes\n\n%s\n\n", h.Notes)
+	}
+
+	if len(photos) > 0 {
+		b.WriteString("## Photos\n\n")
+		for _, photo := range photos {
+			if photo == h.MainPhoto {
+				fmt.Fprintf(&b, "- [%s](photos/%s) (photo principale)\n", photo, photo)
+			} else {
+				fmt.Fprintf(&b, "- [%s](photos/%s)\n", photo, photo)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if len(attachments) > 0 {
+		b.WriteString("## Pièces jointes\n\n")
+		for _, attachment := range attachments {
+			fmt.Fprintf(&b, "- [%s](pieces-jointes/%s)\n", attachment, attachment)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// writeRow writes a "label: value" line, or "-" when the value is empty
+func writeRow(b *strings.Builder, label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	fmt.Fprintf(b, "- **%s** : %s\n", label, value)
+}
+
+// formatUnit formats an optional integer value with its unit
+func formatUnit(value int64, unit string) string {
+	if value == 0 {
+		return ""
+	}
+	if unit == "" {
+		return strconv.FormatInt(value, 10)
+	}
+	return strconv.FormatInt(value, 10) + " " + unit
+}
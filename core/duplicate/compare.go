@@ -0,0 +1,77 @@
+package duplicate
+
+import (
+	"fmt"
+
+	"github.com/willoma/recherche-maison/core/geo"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Weights of the similarities between two houses, and the score from which
+// they are considered possible duplicates
+const (
+	surfaceWeight  = 2
+	priceWeight    = 2
+	roomsWeight    = 1
+	bedroomsWeight = 1
+	addressWeight  = 3
+	photosWeight   = 4
+
+	duplicateThreshold = 5
+)
+
+// maxPositionDistance is the maximum distance between the positions of two
+// houses considered at the same address, in meters
+const maxPositionDistance = 30
+
+// compare returns the similarity score of two houses of the same city, with
+// the description of their similarities. Values unknown for one of the
+// houses are not compared.
+func compare(a, b models.House) (int, []string) {
+	score := 0
+	var reasons []string
+
+	if a.Surface > 0 && b.Surface > 0 && closeValues(a.Surface, b.Surface, 0.05, 3) {
+		score += surfaceWeight
+		reasons = append(reasons, fmt.Sprintf("Surfaces proches (%d m² et %d m²)", a.Surface, b.Surface))
+	}
+
+	// Agencies may add their fees to the price
+	if a.Price > 0 && b.Price > 0 && closeValues(a.Price, b.Price, 0.10, 0) {
+		score += priceWeight
+		reasons = append(reasons, fmt.Sprintf("Prix proches (%d € et %d €)", a.Price, b.Price))
+	}
+
+	if a.Rooms > 0 && a.Rooms == b.Rooms {
+		score += roomsWeight
+		reasons = append(reasons, fmt.Sprintf("Même nombre de pièces (%d)", a.Rooms))
+	}
+
+	if a.Bedrooms > 0 && a.Bedrooms == b.Bedrooms {
+		score += bedroomsWeight
+		reasons = append(reasons, fmt.Sprintf("Même nombre de chambres (%d)", a.Bedrooms))
+	}
+
+	if address := geo.NormalizeAddress(a.Address); address != "" && address == geo.NormalizeAddress(b.Address) {
+		score += addressWeight
+		reasons = append(reasons, "Même adresse")
+	} else if !a.Coordinates().IsZero() && !b.Coordinates().IsZero() {
+		// Addresses may be written differently, or not given at all
+		if distance := a.Coordinates().DistanceTo(b.Coordinates()); distance <= maxPositionDistance {
+			score += addressWeight
+			reasons = append(reasons, fmt.Sprintf("Positions voisines (%.0f m)", distance))
+		}
+	}
+
+	return score, reasons
+}
+
+// closeValues reports whether two values differ by at most the given ratio
+// of the largest one, or by at most the given margin
+func closeValues(a, b int64, ratio float64, margin int64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= margin || float64(diff) <= ratio*float64(max(a, b))
+}
@@ -0,0 +1,140 @@
+package duplicate
+
+import (
+	"image"
+	_ "image/gif"  // Register the GIF decoder
+	_ "image/jpeg" // Register the JPEG decoder
+	_ "image/png"  // Register the PNG decoder
+	"math/bits"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxPhotoDistance is the maximum number of different bits between the
+// hashes of two photos of the same property
+const maxPhotoDistance = 6
+
+// hashCache keeps the perceptual hashes of the photos, by path, as long as
+// their files are not modified
+type hashCache struct {
+	mu     sync.Mutex
+	hashes map[string]cachedHash
+}
+
+// cachedHash is the perceptual hash of a photo file
+type cachedHash struct {
+	modTime time.Time
+	size    int64
+	hash    uint64
+	ok      bool // False if the photo could not be decoded
+}
+
+// hash returns the perceptual hash of a photo, computing it only if the file
+// changed since the previous call
+func (c *hashCache) hash(path string) (uint64, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	cached, found := c.hashes[path]
+	c.mu.Unlock()
+	if found && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.hash, cached.ok
+	}
+
+	hash, ok := hashFile(path)
+
+	c.mu.Lock()
+	c.hashes[path] = cachedHash{modTime: info.ModTime(), size: info.Size(), hash: hash, ok: ok}
+	c.mu.Unlock()
+
+	return hash, ok
+}
+
+// hashFile computes the perceptual hash of a photo file
+func hashFile(path string) (uint64, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return 0, false
+	}
+
+	hash := differenceHash(img)
+
+	// Uniform images all have the same hash, it tells nothing about them
+	if hash == 0 || hash == ^uint64(0) {
+		return 0, false
+	}
+	return hash, true
+}
+
+// differenceHash computes the dHash of an image: the image is reduced to
+// 9×8 gray cells, and each bit tells whether a cell is brighter than its
+// right neighbour. Resized or recompressed copies of a photo have close hashes.
+func differenceHash(img image.Image) uint64 {
+	const width, height = 9, 8
+	var cells [height][width]float64
+
+	bounds := img.Bounds()
+	for y := range height {
+		for x := range width {
+			cells[y][x] = cellLuminance(img,
+				bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height,
+				bounds.Min.X+(x+1)*bounds.Dx()/width, bounds.Min.Y+(y+1)*bounds.Dy()/height,
+			)
+		}
+	}
+
+	var hash uint64
+	for y := range height {
+		for x := range width - 1 {
+			hash <<= 1
+			if cells[y][x] > cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// cellLuminance returns the average luminance of a rectangle of an image,
+// sampling at most 8×8 of its pixels
+func cellLuminance(img image.Image, x0, y0, x1, y1 int) float64 {
+	const samples = 8
+	stepX := max((x1-x0)/samples, 1)
+	stepY := max((y1-y0)/samples, 1)
+
+	var sum float64
+	var count int
+	for y := y0; y < y1; y += stepY {
+		for x := x0; x < x1; x += stepX {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// similarPhotos reports whether two sets of photo hashes share a photo
+func similarPhotos(a, b []uint64) bool {
+	for _, hashA := range a {
+		for _, hashB := range b {
+			if bits.OnesCount64(hashA^hashB) <= maxPhotoDistance {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Service provides methods for detecting houses which describe the same property
+type Service struct {
+	queries      *db.Queries
+	houseService *house.Service
+	uploadsDir   string
+	hashes       hashCache
+}
+
+// NewService creates a new duplicate detection service
+func NewService(queries *db.Queries, houseService *house.Service, uploadsDir string) *Service {
+	return &Service{
+		queries:      queries,
+		houseService: houseService,
+		uploadsDir:   uploadsDir,
+		hashes:       hashCache{hashes: map[string]cachedHash{}},
+	}
+}
+
+// FindDuplicates returns the houses of the same city which are likely to
+// describe the same property as the given one, the most likely first. The
+// house may not be created yet, its ID being 0: its photos are then not compared.
+func (s *Service) FindDuplicates(ctx context.Context, h models.House) ([]models.DuplicateCandidate, error) {
+	houses, err := s.houseService.ListHouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dismissed, err := s.dismissals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var photos []uint64
+	if h.ID != 0 {
+		if photos, err = s.photoHashes(ctx, h.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var candidates []models.DuplicateCandidate
+	for _, other := range houses {
+		if other.ID == h.ID || other.CityID != h.CityID || dismissed[pairOf(h.ID, other.ID)] {
+			continue
+		}
+
+		score, reasons := compare(h, other)
+
+		// Photos are only compared when they can make a difference
+		if len(photos) > 0 && score < duplicateThreshold && score+photosWeight >= duplicateThreshold {
+			otherPhotos, err := s.photoHashes(ctx, other.ID)
+			if err != nil {
+				return nil, err
+			}
+			if similarPhotos(photos, otherPhotos) {
+				score += photosWeight
+				reasons = append(reasons, "Photos similaires")
+			}
+		}
+
+		if score >= duplicateThreshold {
+			candidates = append(candidates, models.DuplicateCandidate{
+				House:   other,
+				Score:   score,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b models.DuplicateCandidate) int {
+		return b.Score - a.Score
+	})
+
+	return candidates, nil
+}
+
+// Dismiss records that two houses are not duplicates, so that they are not
+// reported anymore
+func (s *Service) Dismiss(ctx context.Context, houseID, otherHouseID int64) error {
+	pair := pairOf(houseID, otherHouseID)
+	if err := s.queries.CreateDuplicateDismissal(ctx, pair[0], pair[1]); err != nil {
+		return fmt.Errorf("failed to dismiss duplicate: %w", err)
+	}
+	return nil
+}
+
+// dismissals returns the pairs of houses which are not duplicates
+func (s *Service) dismissals(ctx context.Context) (map[[2]int64]bool, error) {
+	dbDismissals, err := s.queries.ListDuplicateDismissals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate dismissals: %w", err)
+	}
+
+	dismissed := make(map[[2]int64]bool, len(dbDismissals))
+	for _, dismissal := range dbDismissals {
+		dismissed[[2]int64{dismissal.HouseID, dismissal.OtherHouseID}] = true
+	}
+	return dismissed, nil
+}
+
+// pairOf returns a pair of house IDs, the smallest first
+func pairOf(a, b int64) [2]int64 {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int64{a, b}
+}
+
+// photoHashes returns the perceptual hashes of the photos of a house which
+// can be decoded
+func (s *Service) photoHashes(ctx context.Context, houseID int64) ([]uint64, error) {
+	photos, err := s.houseService.GetPhotos(ctx, houseID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(s.uploadsDir, strconv.FormatInt(houseID, 10), "photos")
+	hashes := make([]uint64, 0, len(photos))
+	for _, photo := range photos {
+		if hash, ok := s.hashes.hash(filepath.Join(dir, photo)); ok {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes, nil
+}
@@ -0,0 +1,51 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// utf8BOM lets spreadsheets detect that the CSV file is encoded in UTF-8
+const utf8BOM = "\uFEFF"
+
+// WriteCSV writes the houses matching the filter to w as CSV, formatted for
+// spreadsheets in french: UTF-8 with a BOM, semicolon separators and decimal
+// commas
+func (s *Service) WriteCSV(ctx context.Context, w io.Writer, filter models.HouseFilter) error {
+	t, err := s.buildTable(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+	cw.UseCRLF = true
+
+	if err := cw.Write(t.Headers); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	record := make([]string, len(t.Headers))
+	for _, row := range t.Rows {
+		for i, c := range row {
+			record[i] = c.Text
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+ <manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>
+ <manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>
+`
+
+// The cell styles display dates and decimals in the french format, and the
+// headers in bold
+const odsContentHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" office:version="1.2">
+<office:automatic-styles>
+<number:date-style style:name="N1"><number:day number:style="long"/><number:text>/</number:text><number:month number:style="long"/><number:text>/</number:text><number:year number:style="long"/><number:text> </number:text><number:hours number:style="long"/><number:text>:</number:text><number:minutes number:style="long"/></number:date-style>
+<number:number-style style:name="N2"><number:number number:decimal-places="2" number:min-integer-digits="1"/></number:number-style>
+<style:style style:name="date" style:family="table-cell" style:data-style-name="N1"/>
+<style:style style:name="decimal" style:family="table-cell" style:data-style-name="N2"/>
+<style:style style:name="header" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>
+</office:automatic-styles>
+<office:body>
+<office:spreadsheet>
+<table:table table:name="Maisons">
+`
+
+const odsContentFooter = `</table:table>
+</office:spreadsheet>
+</office:body>
+</office:document-content>
+`
+
+// WriteODS writes the houses matching the filter to w as an OpenDocument
+// spreadsheet, with typed cells
+func (s *Service) WriteODS(ctx context.Context, w io.Writer, filter models.HouseFilter) error {
+	t, err := s.buildTable(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	now := time.Now()
+
+	// The mimetype must be the first entry, uncompressed
+	mimetype, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store, Modified: now})
+	if err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+	if _, err := io.WriteString(mimetype, odsMimeType); err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+
+	manifest, err := zw.CreateHeader(&zip.FileHeader{Name: "META-INF/manifest.xml", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+	if _, err := io.WriteString(manifest, odsManifest); err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+
+	content, err := zw.CreateHeader(&zip.FileHeader{Name: "content.xml", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+	bw := bufio.NewWriter(content)
+
+	bw.WriteString(odsContentHeader)
+	fmt.Fprintf(bw, "<table:table-column table:number-columns-repeated=\"%d\"/>\n", len(t.Headers))
+
+	bw.WriteString("<table:table-row>")
+	for _, header := range t.Headers {
+		bw.WriteString(`<table:table-cell table:style-name="header" office:value-type="string">`)
+		writeODSText(bw, header)
+		bw.WriteString("</table:table-cell>")
+	}
+	bw.WriteString("</table:table-row>\n")
+
+	for _, row := range t.Rows {
+		bw.WriteString("<table:table-row>")
+		for _, c := range row {
+			writeODSCell(bw, c)
+		}
+		bw.WriteString("</table:table-row>\n")
+	}
+
+	bw.WriteString(odsContentFooter)
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write ODS: %w", err)
+	}
+	return nil
+}
+
+// writeODSCell writes a typed cell
+func writeODSCell(bw *bufio.Writer, c cell) {
+	if c.Text == "" {
+		bw.WriteString("<table:table-cell/>")
+		return
+	}
+
+	switch c.Type {
+	case integerCell:
+		fmt.Fprintf(bw, `<table:table-cell office:value-type="float" office:value="%s">`, c.Value)
+	case decimalCell:
+		fmt.Fprintf(bw, `<table:table-cell table:style-name="decimal" office:value-type="float" office:value="%s">`, c.Value)
+	case dateTimeCell:
+		fmt.Fprintf(bw, `<table:table-cell table:style-name="date" office:value-type="date" office:date-value="%s">`, c.Value)
+	case booleanCell:
+		fmt.Fprintf(bw, `<table:table-cell office:value-type="boolean" office:boolean-value="%s">`, c.Value)
+	default:
+		bw.WriteString(`<table:table-cell office:value-type="string">`)
+	}
+	writeODSText(bw, c.Text)
+	bw.WriteString("</table:table-cell>")
+}
+
+// writeODSText writes the paragraphs of a cell, one per line
+func writeODSText(bw *bufio.Writer, s string) {
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		bw.WriteString("<text:p>")
+		xml.EscapeText(bw, []byte(line))
+		bw.WriteString("</text:p>")
+	}
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// DumpVersion is the version of the JSON dump format
+const DumpVersion = 1
+
+// Dump is the JSON representation of all houses, readable by the importer.
+// References to cities, tags and custom fields are made by name, so that a
+// dump can be imported into another database.
+type Dump struct {
+	Version    int         `json:"version"`
+	ExportedAt time.Time   `json:"exported_at"`
+	Houses     []DumpHouse `json:"houses"`
+}
+
+// DumpHouse is the JSON representation of a house in a dump
+type DumpHouse struct {
+	Title                string            `json:"title"`
+	City                 string            `json:"city"`
+	CityPostalCode       string            `json:"city_postal_code,omitempty"`
+	Neighbourhood        string            `json:"neighbourhood,omitempty"`
+	Address              string            `json:"address"`
+	Latitude             float64           `json:"latitude,omitempty"`
+	Longitude            float64           `json:"longitude,omitempty"`
+	Price                int64             `json:"price"`
+	Surface              int64             `json:"surface"`
+	Rooms                int64             `json:"rooms"`
+	Bedrooms             int64             `json:"bedrooms"`
+	Bathrooms            int64             `json:"bathrooms"`
+	Floors               int64             `json:"floors"`
+	ConstructionYear     int64             `json:"construction_year"`
+	HouseType            string            `json:"house_type"`
+	LandSurface          int64             `json:"land_surface"`
+	HasGarage            bool              `json:"has_garage"`
+	OutdoorParkingSpaces int64             `json:"outdoor_parking_spaces"`
+	Notes                string            `json:"notes"`
+	Tags                 []string          `json:"tags,omitempty"`
+	CustomFields         map[string]string `json:"custom_fields,omitempty"`
+	Publications         []DumpPublication `json:"publications,omitempty"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+}
+
+// DumpPublication is the JSON representation of a publication URL in a dump
+type DumpPublication struct {
+	URL             string `json:"url"`
+	PublicationDate string `json:"publication_date"` // YYYY-MM-DD
+}
+
+// Service provides methods for exporting houses
+type Service struct {
+	houseService       *house.Service
+	customFieldService *customfield.Service
+}
+
+// NewService creates a new export service
+func NewService(houseService *house.Service, customFieldService *customfield.Service) *Service {
+	return &Service{
+		houseService:       houseService,
+		customFieldService: customFieldService,
+	}
+}
+
+// WriteJSON writes a JSON dump of the houses matching the filter to w
+func (s *Service) WriteJSON(ctx context.Context, w io.Writer, filter models.HouseFilter) error {
+	houses, err := s.houseService.ListFilteredHouses(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	fields, err := s.customFieldService.ListFields(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list custom fields: %w", err)
+	}
+
+	dump := Dump{
+		Version:    DumpVersion,
+		ExportedAt: time.Now(),
+		Houses:     make([]DumpHouse, len(houses)),
+	}
+
+	for i, h := range houses {
+		publicationURLs, err := s.houseService.GetPublicationURLs(ctx, h.ID)
+		if err != nil {
+			return err
+		}
+
+		dumpHouse := DumpHouse{
+			Title:                h.Title,
+			City:                 h.CityName,
+			CityPostalCode:       h.CityPostalCode,
+			Neighbourhood:        h.NeighbourhoodName,
+			Address:              h.Address,
+			Latitude:             h.Latitude,
+			Longitude:            h.Longitude,
+			Price:                h.Price,
+			Surface:              h.Surface,
+			Rooms:                h.Rooms,
+			Bedrooms:             h.Bedrooms,
+			Bathrooms:            h.Bathrooms,
+			Floors:               h.Floors,
+			ConstructionYear:     h.ConstructionYear,
+			HouseType:            h.HouseType,
+			LandSurface:          h.LandSurface,
+			HasGarage:            h.HasGarage,
+			OutdoorParkingSpaces: h.OutdoorParkingSpaces,
+			Notes:                h.Notes,
+			CreatedAt:            h.CreatedAt,
+			UpdatedAt:            h.UpdatedAt,
+		}
+
+		for _, tag := range h.Tags {
+			dumpHouse.Tags = append(dumpHouse.Tags, tag.Name)
+		}
+
+		for _, field := range fields {
+			if value := h.CustomValues[field.ID]; value != "" {
+				if dumpHouse.CustomFields == nil {
+					dumpHouse.CustomFields = map[string]string{}
+				}
+				dumpHouse.CustomFields[field.Name] = value
+			}
+		}
+
+		for _, pub := range publicationURLs {
+			dumpHouse.Publications = append(dumpHouse.Publications, DumpPublication{
+				URL:             pub.URL,
+				PublicationDate: pub.PublicationDate.Format("2006-01-02"),
+			})
+		}
+
+		dump.Houses[i] = dumpHouse
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dump); err != nil {
+		return fmt.Errorf("failed to write JSON dump: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// cellType is the type of a value in a tabular export
+type cellType int
+
+const (
+	textCell cellType = iota
+	integerCell
+	decimalCell
+	dateTimeCell
+	booleanCell
+)
+
+// cell is a value in a tabular export. Text is the value formatted for french
+// readers, Value is its machine-readable form, used by typed formats.
+type cell struct {
+	Type  cellType
+	Text  string
+	Value string
+}
+
+// table is the tabular representation of a list of houses
+type table struct {
+	Headers []string
+	Rows    [][]cell
+}
+
+func text(value string) cell {
+	return cell{Type: textCell, Text: value, Value: value}
+}
+
+func integer(value int64) cell {
+	s := strconv.FormatInt(value, 10)
+	return cell{Type: integerCell, Text: s, Value: s}
+}
+
+// optionalInteger returns an empty cell for zero, which means "not set"
+func optionalInteger(value int64) cell {
+	if value == 0 {
+		return text("")
+	}
+	return integer(value)
+}
+
+// decimal formats a number with two decimals and a decimal comma. Thousands
+// are not grouped, so that spreadsheets recognize the values as numbers.
+func decimal(value float64) cell {
+	s := strconv.FormatFloat(value, 'f', 2, 64)
+	return cell{Type: decimalCell, Text: strings.Replace(s, ".", ",", 1), Value: s}
+}
+
+func dateTime(value time.Time) cell {
+	return cell{Type: dateTimeCell, Text: value.Format("02/01/2006 15:04"), Value: value.Format("2006-01-02T15:04:05")}
+}
+
+func boolean(value bool) cell {
+	if value {
+		return cell{Type: booleanCell, Text: "Oui", Value: "true"}
+	}
+	return cell{Type: booleanCell, Text: "Non", Value: "false"}
+}
+
+// customValue returns the cell for the value of a custom field
+func customValue(field models.CustomField, value string) cell {
+	if value == "" {
+		return text("")
+	}
+	switch field.Type {
+	case models.CustomFieldInteger:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return integer(n)
+		}
+	case models.CustomFieldBoolean:
+		return boolean(value == "true")
+	}
+	return text(value)
+}
+
+// coordinate formats a latitude or longitude with six decimals, about ten
+// centimeters, and returns an empty cell for zero, which means "not set"
+func coordinate(value float64) cell {
+	if value == 0 {
+		return text("")
+	}
+	s := strconv.FormatFloat(value, 'f', 6, 64)
+	return cell{Type: decimalCell, Text: strings.Replace(s, ".", ",", 1), Value: s}
+}
+
+// pricePerSquareMeter returns the price per square meter of a house, or an
+// empty cell when it cannot be computed
+func pricePerSquareMeter(h models.House) cell {
+	if h.Price == 0 || h.Surface == 0 {
+		return text("")
+	}
+	return decimal(float64(h.Price) / float64(h.Surface))
+}
+
+// buildTable returns the table of the houses matching the filter, with every
+// stored column, the computed ones, the tags, the publications and the
+// custom fields
+func (s *Service) buildTable(ctx context.Context, filter models.HouseFilter) (table, error) {
+	houses, err := s.houseService.ListFilteredHouses(ctx, filter)
+	if err != nil {
+		return table{}, err
+	}
+
+	fields, err := s.customFieldService.ListFields(ctx)
+	if err != nil {
+		return table{}, fmt.Errorf("failed to list custom fields: %w", err)
+	}
+
+	t := table{
+		Headers: []string{
+			"Identifiant",
+			"Titre",
+			"Identifiant de la ville",
+			"Ville",
+			"Quartier",
+			"Adresse",
+			"Latitude",
+			"Longitude",
+			"Prix (€)",
+			"Surface (m²)",
+			"Prix au m² (€)",
+			"Pièces",
+			"Chambres",
+			"Salles de bain",
+			"Niveaux",
+			"Année de construction",
+			"Type",
+			"Surface du terrain (m²)",
+			"Garage",
+			"Places de stationnement extérieures",
+			"Photo principale",
+			"Notes",
+			"Date d'ajout",
+			"Dernière modification",
+			"Étiquettes",
+			"Publications",
+		},
+		Rows: make([][]cell, len(houses)),
+	}
+	for _, field := range fields {
+		if field.Unit != "" {
+			t.Headers = append(t.Headers, field.Name+" ("+field.Unit+")")
+		} else {
+			t.Headers = append(t.Headers, field.Name)
+		}
+	}
+
+	for i, h := range houses {
+		publicationURLs, err := s.houseService.GetPublicationURLs(ctx, h.ID)
+		if err != nil {
+			return table{}, err
+		}
+		urls := make([]string, len(publicationURLs))
+		for j, pub := range publicationURLs {
+			urls[j] = pub.URL
+		}
+
+		tags := make([]string, len(h.Tags))
+		for j, tag := range h.Tags {
+			tags[j] = tag.Name
+		}
+
+		row := []cell{
+			integer(h.ID),
+			text(h.Title),
+			integer(h.CityID),
+			text(h.CityName),
+			text(h.NeighbourhoodName),
+			text(h.Address),
+			coordinate(h.Latitude),
+			coordinate(h.Longitude),
+			integer(h.Price),
+			integer(h.Surface),
+			pricePerSquareMeter(h),
+			integer(h.Rooms),
+			integer(h.Bedrooms),
+			integer(h.Bathrooms),
+			integer(h.Floors),
+			optionalInteger(h.ConstructionYear),
+			text(h.HouseType),
+			optionalInteger(h.LandSurface),
+			boolean(h.HasGarage),
+			integer(h.OutdoorParkingSpaces),
+			text(h.MainPhoto),
+			text(h.Notes),
+			dateTime(h.CreatedAt),
+			dateTime(h.UpdatedAt),
+			text(strings.Join(tags, ", ")),
+			text(strings.Join(urls, "\n")),
+		}
+		for _, field := range fields {
+			row = append(row, customValue(field, h.CustomValues[field.ID]))
+		}
+
+		t.Rows[i] = row
+	}
+
+	return t, nil
+}
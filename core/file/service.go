@@ -2,6 +2,8 @@ package file
 
 import (
 	"io"
+
+	"github.com/willoma/recherche-maison/config"
 )
 
 // Service provides methods for managing files
@@ -10,8 +12,10 @@ type Service struct {
 }
 
 // NewService creates a new file service
-func NewService() *Service {
-	return &Service{}
+func NewService(cfg config.Config) *Service {
+	return &Service{
+		uploadsDir: cfg.UploadsDir,
+	}
 }
 
 // EnsureHouseDir ensures that the directory for a house exists
@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// accents maps the accented letters of french to their base letter
+var accents = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i",
+	"ô", "o", "ö", "o",
+	"ù", "u", "û", "u", "ü", "u",
+	"ÿ", "y",
+	"œ", "oe", "æ", "ae",
+)
+
+// addressWords maps the usual abbreviations of addresses to their full words
+var addressWords = map[string]string{
+	"av":  "avenue",
+	"ave": "avenue",
+	"bd":  "boulevard",
+	"bld": "boulevard",
+	"ch":  "chemin",
+	"che": "chemin",
+	"imp": "impasse",
+	"pl":  "place",
+	"r":   "rue",
+	"rte": "route",
+	"sq":  "square",
+	"st":  "saint",
+	"ste": "sainte",
+	"all": "allee",
+	"crs": "cours",
+	"fg":  "faubourg",
+	"lot": "lotissement",
+	"res": "residence",
+}
+
+// numberSuffixes are the words which may follow a house number
+var numberSuffixes = map[string]string{
+	"b":      "bis",
+	"bis":    "bis",
+	"t":      "ter",
+	"ter":    "ter",
+	"q":      "quater",
+	"quater": "quater",
+}
+
+// NormalizeAddress returns an address, or a city name, in lower case,
+// without accents nor punctuation, with its abbreviations expanded
+func NormalizeAddress(address string) string {
+	return strings.Join(addressFields(address), " ")
+}
+
+// addressFields returns the normalized words of an address
+func addressFields(address string) []string {
+	address = accents.Replace(strings.ToLower(address))
+
+	words := strings.FieldsFunc(address, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if full, ok := addressWords[word]; ok {
+			words[i] = full
+		}
+	}
+
+	return words
+}
+
+// splitAddress separates the house number, with its suffix such as "12bis",
+// from the normalized words of the street
+func splitAddress(address string) (string, []string) {
+	words := addressFields(address)
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	// The suffix may be stuck to the number, as in "12bis"
+	number := words[0]
+	digits := strings.TrimRightFunc(number, unicode.IsLetter)
+	if digits == "" || strings.TrimLeftFunc(digits, unicode.IsDigit) != "" {
+		return "", words
+	}
+	words = words[1:]
+
+	suffix := number[len(digits):]
+	if suffix == "" && len(words) > 1 {
+		if _, ok := numberSuffixes[words[0]]; ok {
+			suffix, words = words[0], words[1:]
+		}
+	}
+	if suffix != "" {
+		full, ok := numberSuffixes[suffix]
+		if !ok {
+			return "", words
+		}
+		suffix = full
+	}
+
+	return strings.TrimLeft(digits, "0") + suffix, words
+}
+
+// banNumber returns the house number of a BAN address, in the format of splitAddress
+func banNumber(numero, rep string) string {
+	numero = strings.TrimLeft(strings.TrimSpace(numero), "0")
+	if numero == "" || numero == "99999" { // 99999 is used for places without numbers
+		return ""
+	}
+	rep = strings.ToLower(strings.TrimSpace(rep))
+	if full, ok := numberSuffixes[rep]; ok {
+		rep = full
+	}
+	return numero + rep
+}
@@ -0,0 +1,167 @@
+package geo
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// BANGeocoder locates addresses offline, using the addresses imported from
+// the Base Adresse Nationale
+type BANGeocoder struct {
+	queries *db.Queries
+}
+
+// NewBANGeocoder creates a geocoder using the imported BAN addresses
+func NewBANGeocoder(queries *db.Queries) *BANGeocoder {
+	return &BANGeocoder{queries: queries}
+}
+
+// Geocode returns the coordinates of an address in a city
+func (g *BANGeocoder) Geocode(ctx context.Context, address, city string) (models.Coordinates, error) {
+	cityName := NormalizeAddress(city)
+	number, words := splitAddress(address)
+	if cityName == "" || len(words) == 0 {
+		return models.Coordinates{}, ErrNotFound
+	}
+
+	// The address may end with the postal code and the city, the last words
+	// are removed until a street is found
+	for end := len(words); end > 0; end-- {
+		addresses, err := g.queries.ListBANStreetAddresses(ctx, cityName, strings.Join(words[:end], " "))
+		if err != nil {
+			return models.Coordinates{}, fmt.Errorf("failed to search addresses: %w", err)
+		}
+		if len(addresses) == 0 {
+			continue
+		}
+
+		for _, a := range addresses {
+			if number != "" && a.Number == number {
+				return models.Coordinates{Latitude: a.Latitude, Longitude: a.Longitude}, nil
+			}
+		}
+
+		// Without the house number, the street is located at the center of its addresses
+		var center models.Coordinates
+		for _, a := range addresses {
+			center.Latitude += a.Latitude
+			center.Longitude += a.Longitude
+		}
+		center.Latitude /= float64(len(addresses))
+		center.Longitude /= float64(len(addresses))
+		return center, nil
+	}
+
+	return models.Coordinates{}, ErrNotFound
+}
+
+// ErrInvalidBANFile is returned when an imported file is not a BAN addresses CSV file
+var ErrInvalidBANFile = errors.New("invalid BAN addresses file")
+
+// banColumns are the columns of the BAN addresses CSV files which are imported
+var banColumns = []string{"id", "numero", "rep", "nom_voie", "code_postal", "nom_commune", "lon", "lat"}
+
+// importBAN imports the addresses of a BAN CSV file, such as
+// adresses-44.csv, optionally compressed with gzip, and returns the number of
+// imported addresses. Addresses already imported are replaced.
+func importBAN(ctx context.Context, dbConn *sql.DB, queries *db.Queries, r io.Reader) (int, error) {
+	// Files are distributed compressed with gzip
+	uncompressed, err := gunzip(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidBANFile, err)
+	}
+
+	reader := csv.NewReader(uncompressed)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidBANFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\uFEFF")] = i
+	}
+	for _, name := range banColumns {
+		if _, ok := columns[name]; !ok {
+			return 0, fmt.Errorf("%w: missing column %q", ErrInvalidBANFile, name)
+		}
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txQueries := queries.WithTx(tx)
+
+	count := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidBANFile, err)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		latitude, latErr := strconv.ParseFloat(field("lat"), 64)
+		longitude, lonErr := strconv.ParseFloat(field("lon"), 64)
+		street := NormalizeAddress(field("nom_voie"))
+		city := NormalizeAddress(field("nom_commune"))
+		if latErr != nil || lonErr != nil || street == "" || city == "" || field("id") == "" {
+			// Incomplete addresses are useless for geocoding
+			continue
+		}
+
+		if err := txQueries.UpsertBANAddress(ctx, db.UpsertBANAddressParams{
+			ID:         field("id"),
+			Number:     banNumber(field("numero"), field("rep")),
+			Street:     street,
+			PostalCode: field("code_postal"),
+			CityName:   city,
+			Latitude:   latitude,
+			Longitude:  longitude,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to import address %s: %w", field("id"), err)
+		}
+		count++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return count, nil
+}
+
+// gunzip returns a reader of the uncompressed content of r when it is
+// compressed with gzip, or of r itself
+func gunzip(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(buffered)
+	}
+	return buffered, nil
+}
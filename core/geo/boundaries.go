@@ -0,0 +1,221 @@
+package geo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// ErrInvalidGeoJSONFile is returned when a file is not a GeoJSON feature collection
+var ErrInvalidGeoJSONFile = errors.New("invalid GeoJSON file")
+
+// Properties holding the name and the INSEE code of a city, in the usual
+// GeoJSON files of the french cities
+var (
+	boundaryNameProperties = []string{"nom", "name", "NOM", "NOM_COM", "nom_commune", "libelle"}
+	boundaryCodeProperties = []string{"code", "insee", "INSEE_COM", "code_insee", "codgeo"}
+)
+
+// geoJSONFeature is a feature of a GeoJSON feature collection
+type geoJSONFeature struct {
+	Properties map[string]any `json:"properties"`
+	Geometry   *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// importBoundaries imports the boundaries of the cities of a GeoJSON feature
+// collection, optionally compressed with gzip, and returns the number of
+// imported boundaries. Features which are not polygons are ignored.
+func importBoundaries(ctx context.Context, dbConn *sql.DB, queries *db.Queries, r io.Reader) (int, error) {
+	uncompressed, err := gunzip(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidGeoJSONFile, err)
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txQueries := queries.WithTx(tx)
+
+	count := 0
+	if err := decodeFeatures(uncompressed, func(feature geoJSONFeature) error {
+		rings, ok := featureRings(feature)
+		name := featureProperty(feature, boundaryNameProperties)
+		if !ok || name == "" {
+			return nil
+		}
+
+		ringsJSON, err := json.Marshal(rings)
+		if err != nil {
+			return fmt.Errorf("failed to encode boundary of %s: %w", name, err)
+		}
+
+		box := ringsBox(rings)
+		if err := txQueries.UpsertMapBoundary(ctx, db.UpsertMapBoundaryParams{
+			Name:         name,
+			Code:         featureProperty(feature, boundaryCodeProperties),
+			Rings:        string(ringsJSON),
+			MinLatitude:  box.minLatitude,
+			MaxLatitude:  box.maxLatitude,
+			MinLongitude: box.minLongitude,
+			MaxLongitude: box.maxLongitude,
+		}); err != nil {
+			return fmt.Errorf("failed to import boundary of %s: %w", name, err)
+		}
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return count, nil
+}
+
+// decodeFeatures calls fn for each feature of a GeoJSON feature collection,
+// without loading the whole file in memory
+func decodeFeatures(r io.Reader, fn func(geoJSONFeature) error) error {
+	decoder := json.NewDecoder(r)
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return fmt.Errorf("%w: not a JSON object", ErrInvalidGeoJSONFile)
+	}
+
+	found := false
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidGeoJSONFile, err)
+		}
+
+		if key != "features" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidGeoJSONFile, err)
+			}
+			continue
+		}
+
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return fmt.Errorf("%w: features is not an array", ErrInvalidGeoJSONFile)
+		}
+		for decoder.More() {
+			var feature geoJSONFeature
+			if err := decoder.Decode(&feature); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidGeoJSONFile, err)
+			}
+			if err := fn(feature); err != nil {
+				return err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidGeoJSONFile, err)
+		}
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("%w: no features", ErrInvalidGeoJSONFile)
+	}
+	return nil
+}
+
+// featureProperty returns the first non-empty property among names
+func featureProperty(feature geoJSONFeature, names []string) string {
+	for _, name := range names {
+		switch value := feature.Properties[name].(type) {
+		case string:
+			if value = strings.TrimSpace(value); value != "" {
+				return value
+			}
+		case float64:
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+// featureRings returns the rings of a polygon or multipolygon feature, as
+// [longitude, latitude] positions
+func featureRings(feature geoJSONFeature) ([][][2]float64, bool) {
+	if feature.Geometry == nil {
+		return nil, false
+	}
+
+	var rings [][][2]float64
+	switch feature.Geometry.Type {
+	case "Polygon":
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &rings); err != nil {
+			return nil, false
+		}
+	case "MultiPolygon":
+		var polygons [][][][2]float64
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &polygons); err != nil {
+			return nil, false
+		}
+		for _, polygon := range polygons {
+			rings = append(rings, polygon...)
+		}
+	default:
+		return nil, false
+	}
+
+	return rings, len(rings) > 0
+}
+
+// box is a bounding box of positions
+type box struct {
+	minLatitude  float64
+	maxLatitude  float64
+	minLongitude float64
+	maxLongitude float64
+}
+
+// emptyBox returns a box which any position extends
+func emptyBox() box {
+	return box{
+		minLatitude:  math.Inf(1),
+		maxLatitude:  math.Inf(-1),
+		minLongitude: math.Inf(1),
+		maxLongitude: math.Inf(-1),
+	}
+}
+
+// extend extends the box to include a position
+func (b *box) extend(latitude, longitude float64) {
+	b.minLatitude = math.Min(b.minLatitude, latitude)
+	b.maxLatitude = math.Max(b.maxLatitude, latitude)
+	b.minLongitude = math.Min(b.minLongitude, longitude)
+	b.maxLongitude = math.Max(b.maxLongitude, longitude)
+}
+
+// isEmpty reports whether no position was added to the box
+func (b box) isEmpty() bool {
+	return b.minLatitude > b.maxLatitude
+}
+
+// ringsBox returns the bounding box of rings of [longitude, latitude] positions
+func ringsBox(rings [][][2]float64) box {
+	b := emptyBox()
+	for _, ring := range rings {
+		for _, position := range ring {
+			b.extend(position[1], position[0])
+		}
+	}
+	return b
+}
@@ -0,0 +1,18 @@
+package geo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrNotFound is returned when an address cannot be located
+var ErrNotFound = errors.New("address not found")
+
+// Geocoder locates addresses. When the house number cannot be found,
+// geocoders fall back to the street. They do not fall back to the center of
+// the city, which would give the same position to all houses of a city.
+type Geocoder interface {
+	Geocode(ctx context.Context, address, city string) (models.Coordinates, error)
+}
@@ -0,0 +1,91 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// minScore is the minimum score of a result of the geocoding API for it to be used
+const minScore = 0.5
+
+// HTTPGeocoder locates addresses with an API compatible with the geocoding
+// API of the Base Adresse Nationale
+type HTTPGeocoder struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewHTTPGeocoder creates a geocoder using the API at the given base URL,
+// queried with the given client
+func NewHTTPGeocoder(client *http.Client, baseURL string) *HTTPGeocoder {
+	return &HTTPGeocoder{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// searchResponse is the GeoJSON response of the search endpoint
+type searchResponse struct {
+	Features []struct {
+		Geometry struct {
+			Coordinates []float64 `json:"coordinates"` // Longitude, latitude
+		} `json:"geometry"`
+		Properties struct {
+			Score float64 `json:"score"`
+			Type  string  `json:"type"` // "housenumber", "street", "locality" or "municipality"
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// Geocode returns the coordinates of an address in a city
+func (g *HTTPGeocoder) Geocode(ctx context.Context, address, city string) (models.Coordinates, error) {
+	if strings.TrimSpace(address) == "" || strings.TrimSpace(city) == "" {
+		return models.Coordinates{}, ErrNotFound
+	}
+
+	query := url.Values{}
+	query.Set("limit", "1")
+	query.Set("q", address+" "+city)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search/?"+query.Encode(), nil)
+	if err != nil {
+		return models.Coordinates{}, fmt.Errorf("failed to create geocoding request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return models.Coordinates{}, fmt.Errorf("failed to query geocoder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.Coordinates{}, fmt.Errorf("geocoder answered %s", resp.Status)
+	}
+
+	var result searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return models.Coordinates{}, fmt.Errorf("failed to decode geocoder response: %w", err)
+	}
+
+	if len(result.Features) == 0 || result.Features[0].Properties.Score < minScore ||
+		result.Features[0].Properties.Type == "municipality" {
+		return models.Coordinates{}, ErrNotFound
+	}
+	position := result.Features[0].Geometry.Coordinates
+	if len(position) < 2 {
+		return models.Coordinates{}, fmt.Errorf("invalid geocoder response: %d coordinates", len(position))
+	}
+
+	c := models.Coordinates{Latitude: position[1], Longitude: position[0]}
+	if err := c.Validate(); err != nil {
+		return models.Coordinates{}, err
+	}
+	return c, nil
+}
@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// searchResult returns a search response with a single feature
+func searchResult(score float64, resultType string, coordinates string) string {
+	return fmt.Sprintf(`{"type":"FeatureCollection","features":[{"type":"Feature",`+
+		`"geometry":{"type":"Point","coordinates":%s},"properties":{"score":%g,"type":%q}}]}`,
+		coordinates, score, resultType)
+}
+
+func TestHTTPGeocoder(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		want     models.Coordinates
+		wantErr  error // ErrNotFound, or nil for any other error when want is zero
+	}{
+		{"house number", searchResult(0.92, "housenumber", "[2.2419, 43.6061]"), models.Coordinates{Latitude: 43.6061, Longitude: 2.2419}, nil},
+		{"street", searchResult(0.5, "street", "[2.2419, 43.6061]"), models.Coordinates{Latitude: 43.6061, Longitude: 2.2419}, nil},
+		{"low score", searchResult(0.49, "housenumber", "[2.2419, 43.6061]"), models.Coordinates{}, ErrNotFound},
+		{"municipality", searchResult(0.95, "municipality", "[2.2419, 43.6061]"), models.Coordinates{}, ErrNotFound},
+		{"no result", `{"type":"FeatureCollection","features":[]}`, models.Coordinates{}, ErrNotFound},
+		{"short coordinates", searchResult(0.92, "housenumber", "[2.2419]"), models.Coordinates{}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/search/" {
+					http.NotFound(w, r)
+					return
+				}
+				query = r.URL.Query().Get("q")
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, tc.response)
+			}))
+			defer server.Close()
+
+			geocoder := NewHTTPGeocoder(server.Client(), server.URL+"/")
+			got, err := geocoder.Geocode(context.Background(), "12 rue Villegoudou", "Castres")
+
+			if query != "12 rue Villegoudou Castres" {
+				t.Errorf("queried %q, want the address followed by the city", query)
+			}
+			switch {
+			case !tc.want.IsZero():
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tc.want {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("got %v, %v, want error %v", got, err, tc.wantErr)
+				}
+			default:
+				if err == nil || errors.Is(err, ErrNotFound) {
+					t.Errorf("got %v, %v, want an invalid response error", got, err)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPGeocoderServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPGeocoder(server.Client(), server.URL).Geocode(context.Background(), "12 rue Villegoudou", "Castres")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, want a server error", err)
+	}
+}
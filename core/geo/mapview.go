@@ -0,0 +1,239 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Dimensions of the map, in SVG units
+const (
+	mapSize        = 1000 // Width or height of the map, whichever is larger
+	mapMinSpan     = 0.02 // Smallest area shown, in degrees, about 2 km
+	mapMargin      = 0.15 // Margin around the houses, relative to their area
+	markerMinSize  = 5    // Radius of the cheapest house
+	markerMaxSize  = 14   // Radius of the most expensive house
+	popupCharWidth = 7    // Approximate width of a character of the popups
+	popupHeight    = 40
+)
+
+// projection converts coordinates to the coordinates of the map, with an
+// equirectangular projection, precise enough at the scale of a few cities
+type projection struct {
+	box   box
+	scale float64 // SVG units per degree of latitude
+	ratio float64 // Length of a degree of longitude relative to a degree of latitude
+}
+
+// point returns the map coordinates of a position
+func (p projection) point(c models.Coordinates) (float64, float64) {
+	return (c.Longitude - p.box.minLongitude) * p.ratio * p.scale, (p.box.maxLatitude - c.Latitude) * p.scale
+}
+
+// Map returns the map of the houses which have coordinates, coloured by their
+// publication status, with the imported boundaries of the cities and the
+// points of interest of the mapped area
+func (s *Service) Map(ctx context.Context, houses []models.House, statuses map[int64]string) (models.HouseMap, error) {
+	var houseMap models.HouseMap
+
+	count, err := s.queries.CountMapBoundaries(ctx)
+	if err != nil {
+		return houseMap, fmt.Errorf("failed to count boundaries: %w", err)
+	}
+	houseMap.Boundaries = count
+
+	// Area of the houses
+	b := emptyBox()
+	var located []models.House
+	for _, house := range houses {
+		if house.Coordinates().IsZero() {
+			houseMap.Unlocated = append(houseMap.Unlocated, house)
+			continue
+		}
+		located = append(located, house)
+		b.extend(house.Latitude, house.Longitude)
+	}
+	if b.isEmpty() {
+		return houseMap, nil
+	}
+	b = withMargin(b)
+
+	p := projection{
+		box:   b,
+		ratio: math.Cos((b.minLatitude + b.maxLatitude) / 2 * math.Pi / 180),
+	}
+	width := (b.maxLongitude - b.minLongitude) * p.ratio
+	height := b.maxLatitude - b.minLatitude
+	p.scale = mapSize / math.Max(width, height)
+	houseMap.Width = math.Round(width * p.scale)
+	houseMap.Height = math.Round(height * p.scale)
+
+	// Boundaries of the cities
+	dbBoundaries, err := s.queries.ListMapBoundariesInBox(ctx, db.ListMapBoundariesInBoxParams{
+		MinLatitude:  b.minLatitude,
+		MaxLatitude:  b.maxLatitude,
+		MinLongitude: b.minLongitude,
+		MaxLongitude: b.maxLongitude,
+	})
+	if err != nil {
+		return houseMap, fmt.Errorf("failed to list boundaries: %w", err)
+	}
+	for _, dbBoundary := range dbBoundaries {
+		boundary, err := models.FromDBMapBoundary(dbBoundary)
+		if err != nil {
+			return houseMap, err
+		}
+		houseMap.Areas = append(houseMap.Areas, mapArea(p, boundary, houseMap.Width, houseMap.Height, located))
+	}
+
+	// Houses, sized by price
+	minPrice, maxPrice := priceRange(located)
+	for _, house := range located {
+		status, ok := statuses[house.ID]
+		if !ok {
+			status = models.PublicationStatusUnknown
+		}
+		x, y := p.point(house.Coordinates())
+		mapHouse := models.MapHouse{
+			House:  house,
+			Status: status,
+			X:      x,
+			Y:      y,
+			Radius: markerRadius(house.Price, minPrice, maxPrice),
+		}
+		placePopup(&mapHouse, houseMap.Width)
+		houseMap.Houses = append(houseMap.Houses, mapHouse)
+	}
+
+	// Points of interest within the area
+	points, err := s.ListPointsOfInterest(ctx)
+	if err != nil {
+		return houseMap, err
+	}
+	for _, point := range points {
+		if point.Latitude < b.minLatitude || point.Latitude > b.maxLatitude ||
+			point.Longitude < b.minLongitude || point.Longitude > b.maxLongitude {
+			continue
+		}
+		x, y := p.point(point.Coordinates())
+		houseMap.Points = append(houseMap.Points, models.MapPoint{PointOfInterest: point, X: x, Y: y})
+	}
+
+	return houseMap, nil
+}
+
+// withMargin returns the box enlarged with a margin, and to the smallest area
+func withMargin(b box) box {
+	latitudeMargin := math.Max(b.maxLatitude-b.minLatitude, mapMinSpan) * mapMargin
+	longitudeMargin := math.Max(b.maxLongitude-b.minLongitude, mapMinSpan) * mapMargin
+	if span := b.maxLatitude - b.minLatitude; span < mapMinSpan {
+		latitudeMargin += (mapMinSpan - span) / 2
+	}
+	if span := b.maxLongitude - b.minLongitude; span < mapMinSpan {
+		longitudeMargin += (mapMinSpan - span) / 2
+	}
+	return box{
+		minLatitude:  b.minLatitude - latitudeMargin,
+		maxLatitude:  b.maxLatitude + latitudeMargin,
+		minLongitude: b.minLongitude - longitudeMargin,
+		maxLongitude: b.maxLongitude + longitudeMargin,
+	}
+}
+
+// mapArea returns the SVG path of a boundary, labelled at the center of the
+// visible part of its largest ring. Positions closer than one unit to the
+// previous one are skipped.
+func mapArea(p projection, boundary models.MapBoundary, width, height float64, houses []models.House) models.MapArea {
+	area := models.MapArea{Name: boundary.Name}
+
+	var path strings.Builder
+	largest := 0
+	for _, ring := range boundary.Rings {
+		var lastX, lastY float64
+		minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		n := 0
+		for i, c := range ring {
+			x, y := p.point(c)
+			if i > 0 && math.Abs(x-lastX) < 1 && math.Abs(y-lastY) < 1 {
+				continue
+			}
+			if i == 0 {
+				path.WriteString("M")
+			} else {
+				path.WriteString("L")
+			}
+			path.WriteString(strconv.FormatFloat(x, 'f', 1, 64))
+			path.WriteString(",")
+			path.WriteString(strconv.FormatFloat(y, 'f', 1, 64))
+			lastX, lastY = x, y
+			minX, minY = math.Min(minX, x), math.Min(minY, y)
+			maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+			n++
+		}
+		path.WriteString("Z")
+
+		if n > largest {
+			largest = n
+			area.LabelX = (math.Max(minX, 0) + math.Min(maxX, width)) / 2
+			area.LabelY = (math.Max(minY, 0) + math.Min(maxY, height)) / 2
+		}
+	}
+	area.Path = path.String()
+
+	for _, house := range houses {
+		if boundary.Contains(house.Coordinates()) {
+			area.HasHouses = true
+			break
+		}
+	}
+
+	return area
+}
+
+// priceRange returns the lowest and highest known prices of the houses
+func priceRange(houses []models.House) (int64, int64) {
+	var minPrice, maxPrice int64
+	for _, house := range houses {
+		if house.Price == 0 {
+			continue
+		}
+		if minPrice == 0 || house.Price < minPrice {
+			minPrice = house.Price
+		}
+		maxPrice = max(maxPrice, house.Price)
+	}
+	return minPrice, maxPrice
+}
+
+// markerRadius returns the radius of the marker of a house, its area growing
+// with the price. Houses without price have the smallest marker.
+func markerRadius(price, minPrice, maxPrice int64) float64 {
+	switch {
+	case price == 0:
+		return markerMinSize
+	case minPrice == maxPrice:
+		return (markerMinSize + markerMaxSize) / 2.0
+	default:
+		ratio := float64(price-minPrice) / float64(maxPrice-minPrice)
+		return markerMinSize + (markerMaxSize-markerMinSize)*math.Sqrt(ratio)
+	}
+}
+
+// placePopup places the popup of a house above its marker, or below it
+// near the top of the map, within the width of the map
+func placePopup(house *models.MapHouse, mapWidth float64) {
+	length := max(utf8.RuneCountInString(house.House.Title), utf8.RuneCountInString(house.House.CityName)+16)
+	house.Width = float64(length*popupCharWidth + 16)
+
+	house.PopupX = math.Max(0, math.Min(house.X-house.Width/2, mapWidth-house.Width))
+	house.PopupY = house.Y - house.Radius - popupHeight - 4
+	if house.PopupY < 0 {
+		house.PopupY = house.Y + house.Radius + 4
+	}
+}
@@ -0,0 +1,208 @@
+package geo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrPointOfInterestExists is returned when another point of interest has the same name
+var ErrPointOfInterestExists = errors.New("point of interest name already used")
+
+// Service provides methods for locating houses and computing their distances
+// to the points of interest
+type Service struct {
+	queries  *db.Queries
+	db       *sql.DB
+	geocoder Geocoder
+}
+
+// NewService creates a new geolocation service
+func NewService(queries *db.Queries, dbConn *sql.DB, geocoder Geocoder) *Service {
+	return &Service{
+		queries:  queries,
+		db:       dbConn,
+		geocoder: geocoder,
+	}
+}
+
+// Geocode returns the coordinates of the address of a house. The name of
+// its city is retrieved if it is not set.
+func (s *Service) Geocode(ctx context.Context, house models.House) (models.Coordinates, error) {
+	cityName := house.CityName
+	if cityName == "" {
+		city, err := s.queries.GetCity(ctx, house.CityID)
+		if err != nil {
+			return models.Coordinates{}, fmt.Errorf("failed to get city: %w", err)
+		}
+		cityName = city.Name
+	}
+
+	return s.geocoder.Geocode(ctx, house.Address, cityName)
+}
+
+// GeocodeAddress returns the coordinates of an address in a city
+func (s *Service) GeocodeAddress(ctx context.Context, address, city string) (models.Coordinates, error) {
+	return s.geocoder.Geocode(ctx, address, city)
+}
+
+// LocateHouse sets the coordinates of a house from its address, and returns them
+func (s *Service) LocateHouse(ctx context.Context, houseID int64) (models.Coordinates, error) {
+	dbHouse, err := s.queries.GetHouse(ctx, houseID)
+	if err != nil {
+		return models.Coordinates{}, fmt.Errorf("failed to get house: %w", err)
+	}
+
+	c, err := s.Geocode(ctx, models.FromDBHouse(dbHouse))
+	if err != nil {
+		return models.Coordinates{}, err
+	}
+
+	if err := s.queries.SetHouseCoordinates(ctx, db.SetHouseCoordinatesParams{
+		ID:        houseID,
+		Latitude:  c.Latitude,
+		Longitude: c.Longitude,
+	}); err != nil {
+		return models.Coordinates{}, fmt.Errorf("failed to set house coordinates: %w", err)
+	}
+
+	return c, nil
+}
+
+// ImportAddresses imports the addresses of a BAN CSV file, used by the
+// offline geocoder, and returns the number of imported addresses
+func (s *Service) ImportAddresses(ctx context.Context, r io.Reader) (int, error) {
+	return importBAN(ctx, s.db, s.queries, r)
+}
+
+// CountAddresses returns the number of imported BAN addresses
+func (s *Service) CountAddresses(ctx context.Context) (int64, error) {
+	count, err := s.queries.CountBANAddresses(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count addresses: %w", err)
+	}
+	return count, nil
+}
+
+// ImportBoundaries imports the boundaries of the cities of a GeoJSON file,
+// drawn on the map, and returns the number of imported boundaries
+func (s *Service) ImportBoundaries(ctx context.Context, r io.Reader) (int, error) {
+	return importBoundaries(ctx, s.db, s.queries, r)
+}
+
+// DeleteBoundaries deletes all the imported boundaries
+func (s *Service) DeleteBoundaries(ctx context.Context) error {
+	if err := s.queries.DeleteMapBoundaries(ctx); err != nil {
+		return fmt.Errorf("failed to delete boundaries: %w", err)
+	}
+	return nil
+}
+
+// ListPointsOfInterest retrieves all points of interest
+func (s *Service) ListPointsOfInterest(ctx context.Context) ([]models.PointOfInterest, error) {
+	dbPoints, err := s.queries.ListPointsOfInterest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list points of interest: %w", err)
+	}
+
+	points := make([]models.PointOfInterest, len(dbPoints))
+	for i, dbPoint := range dbPoints {
+		points[i] = models.FromDBPointOfInterest(dbPoint)
+	}
+	return points, nil
+}
+
+// CreatePointOfInterest creates a new point of interest, returning
+// ErrPointOfInterestExists if another point of interest has the same name
+func (s *Service) CreatePointOfInterest(ctx context.Context, point models.PointOfInterest) error {
+	if err := s.checkUnique(ctx, point); err != nil {
+		return err
+	}
+
+	if err := s.queries.CreatePointOfInterest(ctx, db.CreatePointOfInterestParams{
+		Name:          point.Name,
+		Category:      point.Category,
+		Latitude:      point.Latitude,
+		Longitude:     point.Longitude,
+		TravelMode:    point.TravelMode,
+		MaxTravelTime: point.MaxTravelTime,
+	}); err != nil {
+		return fmt.Errorf("failed to create point of interest: %w", err)
+	}
+	return nil
+}
+
+// UpdatePointOfInterest updates a point of interest, returning
+// ErrPointOfInterestExists if another point of interest has the same name
+func (s *Service) UpdatePointOfInterest(ctx context.Context, point models.PointOfInterest) error {
+	if err := s.checkUnique(ctx, point); err != nil {
+		return err
+	}
+
+	if err := s.queries.UpdatePointOfInterest(ctx, db.UpdatePointOfInterestParams{
+		ID:            point.ID,
+		Name:          point.Name,
+		Category:      point.Category,
+		Latitude:      point.Latitude,
+		Longitude:     point.Longitude,
+		TravelMode:    point.TravelMode,
+		MaxTravelTime: point.MaxTravelTime,
+	}); err != nil {
+		return fmt.Errorf("failed to update point of interest: %w", err)
+	}
+	return nil
+}
+
+// checkUnique returns ErrPointOfInterestExists if a point of interest other
+// than the given one has its name
+func (s *Service) checkUnique(ctx context.Context, point models.PointOfInterest) error {
+	existing, err := s.queries.GetPointOfInterestByName(ctx, point.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get point of interest: %w", err)
+	}
+	if existing.ID != point.ID {
+		return ErrPointOfInterestExists
+	}
+	return nil
+}
+
+// DeletePointOfInterest deletes a point of interest
+func (s *Service) DeletePointOfInterest(ctx context.Context, id int64) error {
+	if err := s.queries.DeletePointOfInterest(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete point of interest: %w", err)
+	}
+	return nil
+}
+
+// Proximity returns the distances from a house to the points of interest,
+// the nearest first, with its proximity score
+func (s *Service) Proximity(ctx context.Context, house models.House) (models.HouseProximity, error) {
+	points, err := s.ListPointsOfInterest(ctx)
+	if err != nil {
+		return models.HouseProximity{}, err
+	}
+	return models.NewHouseProximity(house, points), nil
+}
+
+// Proximities returns the proximity of each house, by house ID, along with
+// the points of interest
+func (s *Service) Proximities(ctx context.Context, houses []models.House) (map[int64]models.HouseProximity, []models.PointOfInterest, error) {
+	points, err := s.ListPointsOfInterest(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proximities := make(map[int64]models.HouseProximity, len(houses))
+	for _, house := range houses {
+		proximities[house.ID] = models.NewHouseProximity(house, points)
+	}
+	return proximities, points, nil
+}
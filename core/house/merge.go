@@ -0,0 +1,255 @@
+package house
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrMergeSameHouse is returned when attempting to merge a house into itself
+var ErrMergeSameHouse = errors.New("cannot merge a house into itself")
+
+// MergeHouses merges the source house into the target house, then deletes
+// the source house. The target keeps its values, its empty ones being
+// filled from the source; publication URLs, photos, attachments, journal
+// entries, tasks, tags and notes of both houses are combined.
+func (s *Service) MergeHouses(ctx context.Context, targetID, sourceID int64) error {
+	if targetID == sourceID {
+		return ErrMergeSameHouse
+	}
+
+	target, err := s.GetHouse(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	source, err := s.GetHouse(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	// Files are moved first, because the main photo may be renamed. They are
+	// moved back to the source house if the merge fails before its commit.
+	var moves movedFiles
+	committed := false
+	defer func() {
+		if !committed {
+			moves.undo()
+		}
+	}()
+
+	renamed := map[string]string{}
+	for _, sub := range []string{"photos", "attachments"} {
+		moved, err := moveFiles(
+			filepath.Join(s.uploadsDir, strconv.FormatInt(sourceID, 10), sub),
+			filepath.Join(s.uploadsDir, strconv.FormatInt(targetID, 10), sub),
+			&moves,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to move %s: %w", sub, err)
+		}
+		if sub == "photos" {
+			renamed = moved
+		}
+	}
+
+	merged := mergeHouse(target, source)
+	if merged.MainPhoto == "" && source.MainPhoto != "" {
+		merged.MainPhoto = renamed[source.MainPhoto]
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	queries := s.queries.WithTx(tx)
+
+	if err := updateHouse(ctx, queries, targetID, merged); err != nil {
+		return err
+	}
+
+	// The publication URLs both houses share are kept only once
+	if err := queries.DeleteDuplicatePublicationURLs(ctx, sourceID, targetID); err != nil {
+		return fmt.Errorf("failed to delete duplicate publication URLs: %w", err)
+	}
+	if err := queries.MovePublicationURLs(ctx, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to move publication URLs: %w", err)
+	}
+	if err := queries.MoveJournalEntries(ctx, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to move journal entries: %w", err)
+	}
+	if err := queries.MoveTasks(ctx, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to move tasks: %w", err)
+	}
+	if err := queries.MoveNotifications(ctx, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to move notifications: %w", err)
+	}
+
+	if err := queries.DeleteHouse(ctx, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged house: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+
+	// The uploads directory of the source house only contains empty directories now
+	sourceDir := filepath.Join(s.uploadsDir, strconv.FormatInt(sourceID, 10))
+	if err := os.RemoveAll(sourceDir); err != nil {
+		slog.Error("Failed to delete uploads directory", "error", err, "path", sourceDir)
+	}
+
+	return nil
+}
+
+// mergeHouse returns the target house completed with the source house
+func mergeHouse(target, source models.House) models.House {
+	merged := target
+
+	if merged.NeighbourhoodID == 0 && source.CityID == merged.CityID {
+		merged.NeighbourhoodID = source.NeighbourhoodID
+	}
+	if merged.Address == "" {
+		merged.Address = source.Address
+	}
+	if merged.Price == 0 {
+		merged.Price = source.Price
+	}
+	if merged.Surface == 0 {
+		merged.Surface = source.Surface
+	}
+	if merged.Rooms == 0 {
+		merged.Rooms = source.Rooms
+	}
+	if merged.Bedrooms == 0 {
+		merged.Bedrooms = source.Bedrooms
+	}
+	if merged.Bathrooms == 0 {
+		merged.Bathrooms = source.Bathrooms
+	}
+	if merged.Floors == 0 {
+		merged.Floors = source.Floors
+	}
+	if merged.ConstructionYear == 0 {
+		merged.ConstructionYear = source.ConstructionYear
+	}
+	if merged.HouseType == "" {
+		merged.HouseType = source.HouseType
+	}
+	if merged.LandSurface == 0 {
+		merged.LandSurface = source.LandSurface
+	}
+	if merged.OutdoorParkingSpaces == 0 {
+		merged.OutdoorParkingSpaces = source.OutdoorParkingSpaces
+	}
+	merged.HasGarage = merged.HasGarage || source.HasGarage
+	if merged.Coordinates().IsZero() {
+		merged.Latitude = source.Latitude
+		merged.Longitude = source.Longitude
+	}
+
+	// Notes of the source are appended, under the title of the source
+	if notes := strings.TrimSpace(source.Notes); notes != "" && notes != strings.TrimSpace(merged.Notes) {
+		if merged.Notes != "" {
+			merged.Notes = strings.TrimRight(merged.Notes, "\n") + "\n\n"
+		}
+		merged.Notes += "Notes de « " + source.Title + " » :\n" + notes
+	}
+
+	for _, tag := range source.Tags {
+		if !merged.HasTag(tag.ID) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+
+	values := make(map[int64]string, len(target.CustomValues)+len(source.CustomValues))
+	for fieldID, value := range source.CustomValues {
+		values[fieldID] = value
+	}
+	for fieldID, value := range target.CustomValues {
+		values[fieldID] = value
+	}
+	merged.CustomValues = values
+
+	return merged
+}
+
+// moveFiles moves the files of a directory into another one, renaming those
+// whose name is already used, records the moves and returns the new names of
+// the files by old name
+func moveFiles(srcDir, dstDir string, moves *movedFiles) (map[string]string, error) {
+	entries, err := os.ReadDir(srcDir)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	moved := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := availableName(dstDir, entry.Name())
+		if err := moves.move(filepath.Join(srcDir, entry.Name()), filepath.Join(dstDir, name)); err != nil {
+			return nil, err
+		}
+		moved[entry.Name()] = name
+	}
+
+	return moved, nil
+}
+
+// movedFiles records the files moved by a merge, as old and new paths
+type movedFiles [][2]string
+
+// move moves a file, recording it if it succeeds
+func (m *movedFiles) move(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	*m = append(*m, [2]string{oldPath, newPath})
+	return nil
+}
+
+// undo moves back the recorded files, the most recent first
+func (m movedFiles) undo() {
+	for i := len(m) - 1; i >= 0; i-- {
+		oldPath, newPath := m[i][0], m[i][1]
+		if err := os.Rename(newPath, oldPath); err != nil {
+			slog.Error("Failed to move back merged file", "path", newPath, "original", oldPath, "error", err)
+		}
+	}
+}
+
+// availableName returns the given file name if it is not used in dir yet,
+// or the name with a number added before its extension
+func availableName(dir, name string) string {
+	if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		candidate := base + "-" + strconv.Itoa(i) + ext
+		if _, err := os.Stat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
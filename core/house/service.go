@@ -3,6 +3,7 @@ package house
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,22 +11,24 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/city"
 	"github.com/willoma/recherche-maison/db"
 	"github.com/willoma/recherche-maison/models"
 )
 
 // Service provides house-related functionality
 type Service struct {
-	queries *db.Queries
-	db      *sql.DB // Direct access to the database for transactions
+	queries    *db.Queries
+	db         *sql.DB // Direct access to the database for transactions
+	uploadsDir string
 }
 
 // NewService creates a new house service
-func NewService(queries *db.Queries, dbConn *sql.DB) *Service {
+func NewService(queries *db.Queries, dbConn *sql.DB, uploadsDir string) *Service {
 	return &Service{
-		queries: queries,
-		db:      dbConn,
+		queries:    queries,
+		db:         dbConn,
+		uploadsDir: uploadsDir,
 	}
 }
 
@@ -35,7 +38,24 @@ func (s *Service) GetHouse(ctx context.Context, id int64) (models.House, error)
 	if err != nil {
 		return models.House{}, fmt.Errorf("failed to get house: %w", err)
 	}
-	return models.FromDBHouse(dbHouse), nil
+
+	dbTags, err := s.queries.ListHouseTags(ctx, id)
+	if err != nil {
+		return models.House{}, fmt.Errorf("failed to get house tags: %w", err)
+	}
+
+	dbValues, err := s.queries.ListHouseCustomValues(ctx, id)
+	if err != nil {
+		return models.House{}, fmt.Errorf("failed to get house custom values: %w", err)
+	}
+
+	house := models.FromDBHouse(dbHouse)
+	house.Tags = models.FromDBTags(dbTags)
+	house.CustomValues = make(map[int64]string, len(dbValues))
+	for _, dbValue := range dbValues {
+		house.CustomValues[dbValue.FieldID] = dbValue.Value
+	}
+	return house, nil
 }
 
 // ListHouses retrieves all houses
@@ -45,13 +65,57 @@ func (s *Service) ListHouses(ctx context.Context) ([]models.House, error) {
 		return nil, fmt.Errorf("failed to list houses: %w", err)
 	}
 
+	dbHouseTags, err := s.queries.ListAllHouseTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list house tags: %w", err)
+	}
+
+	tags := map[int64][]models.Tag{}
+	for _, dbHouseTag := range dbHouseTags {
+		tags[dbHouseTag.HouseID] = append(tags[dbHouseTag.HouseID], models.FromDBTag(dbHouseTag.Tag))
+	}
+
+	dbValues, err := s.queries.ListAllCustomValues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom values: %w", err)
+	}
+
+	values := map[int64]map[int64]string{}
+	for _, dbValue := range dbValues {
+		if values[dbValue.HouseID] == nil {
+			values[dbValue.HouseID] = map[int64]string{}
+		}
+		values[dbValue.HouseID][dbValue.FieldID] = dbValue.Value
+	}
+
 	houses := make([]models.House, len(dbHouses))
 	for i, dbHouse := range dbHouses {
 		houses[i] = models.FromDBHouse(dbHouse)
+		houses[i].Tags = tags[dbHouse.ID]
+		houses[i].CustomValues = values[dbHouse.ID]
 	}
 	return houses, nil
 }
 
+// ListFilteredHouses retrieves all houses matching the filter, in the same order as ListHouses
+func (s *Service) ListFilteredHouses(ctx context.Context, filter models.HouseFilter) ([]models.House, error) {
+	houses, err := s.ListHouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filter.IsEmpty() {
+		return houses, nil
+	}
+
+	filtered := make([]models.House, 0, len(houses))
+	for _, house := range houses {
+		if filter.Matches(house) {
+			filtered = append(filtered, house)
+		}
+	}
+	return filtered, nil
+}
+
 // CreateHouse creates a new house
 func (s *Service) CreateHouse(ctx context.Context, house models.House) (int64, error) {
 	tx, err := s.db.Begin()
@@ -61,7 +125,69 @@ func (s *Service) CreateHouse(ctx context.Context, house models.House) (int64, e
 
 	defer tx.Rollback()
 
+	id, err := s.createHouse(ctx, s.queries.WithTx(tx), house)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// ImportHouses creates several houses with their publication URLs in a single
+// transaction. The city of each house is found by its name, and created
+// through cityService if it does not exist yet.
+func (s *Service) ImportHouses(ctx context.Context, cityService *city.Service, houses []models.House, publicationURLs [][]models.PublicationURL) ([]int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer tx.Rollback()
+
 	queries := s.queries.WithTx(tx)
+	cities := cityService.WithTx(tx)
+
+	ids := make([]int64, len(houses))
+	for i, house := range houses {
+		house.CityID, err = cities.FindOrCreateCity(ctx, house.CityName, house.CityPostalCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get city %q: %w", house.CityName, err)
+		}
+
+		ids[i], err = s.createHouse(ctx, queries, house)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import house %q: %w", house.Title, err)
+		}
+
+		if i < len(publicationURLs) {
+			for _, pub := range publicationURLs[i] {
+				if _, err := queries.CreatePublicationURL(ctx, db.CreatePublicationURLParams{
+					HouseID:         ids[i],
+					URL:             pub.URL,
+					PublicationDate: pub.PublicationDate,
+				}); err != nil {
+					return nil, fmt.Errorf("failed to create publication URL: %w", err)
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return ids, nil
+}
+
+// createHouse creates a house with its tags, custom values and uploads directories
+func (s *Service) createHouse(ctx context.Context, queries *db.Queries, house models.House) (int64, error) {
+	if err := checkNeighbourhood(ctx, queries, house); err != nil {
+		return 0, err
+	}
 
 	id, err := queries.CreateHouse(ctx, db.CreateHouseParams{
 		Title:                house.Title,
@@ -80,32 +206,82 @@ func (s *Service) CreateHouse(ctx context.Context, house models.House) (int64, e
 		OutdoorParkingSpaces: house.OutdoorParkingSpaces,
 		Notes:                house.Notes,
 		MainPhoto:            house.MainPhoto,
+		Latitude:             house.Latitude,
+		Longitude:            house.Longitude,
+		NeighbourhoodID:      models.NeighbourhoodID(house.NeighbourhoodID),
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to create house: %w", err)
 	}
 
+	if err := setHouseTags(ctx, queries, id, house.Tags); err != nil {
+		return 0, err
+	}
+
+	if err := setHouseCustomValues(ctx, queries, id, house.CustomValues); err != nil {
+		return 0, err
+	}
+
 	// Create the uploads directories for this house
-	photosDir := filepath.Join(config.UploadsDir, strconv.FormatInt(id, 10), "photos")
+	photosDir := filepath.Join(s.uploadsDir, strconv.FormatInt(id, 10), "photos")
 	if err := os.MkdirAll(photosDir, 0o755); err != nil {
 		return 0, fmt.Errorf("failed to create photos directory: %w", err)
 	}
-	attachmentsDir := filepath.Join(config.UploadsDir, strconv.FormatInt(id, 10), "attachments")
+	attachmentsDir := filepath.Join(s.uploadsDir, strconv.FormatInt(id, 10), "attachments")
 	if err := os.MkdirAll(attachmentsDir, 0o755); err != nil {
 		return 0, fmt.Errorf("failed to create attachments directory: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return id, nil
 }
 
 // UpdateHouse updates an existing house
 func (s *Service) UpdateHouse(ctx context.Context, id int64, house models.House) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateHouse(ctx, s.queries.WithTx(tx), id, house); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkNeighbourhood returns models.ErrInvalidNeighbourhood if the
+// neighbourhood of the house is not in the city of the house
+func checkNeighbourhood(ctx context.Context, queries *db.Queries, house models.House) error {
+	if house.NeighbourhoodID == 0 {
+		return nil
+	}
+
+	neighbourhood, err := queries.GetNeighbourhood(ctx, house.NeighbourhoodID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrInvalidNeighbourhood
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhood: %w", err)
+	}
+	if neighbourhood.CityID != house.CityID {
+		return models.ErrInvalidNeighbourhood
+	}
+	return nil
+}
+
+// updateHouse updates a house with its tags and custom values
+func updateHouse(ctx context.Context, queries *db.Queries, id int64, house models.House) error {
+	if err := checkNeighbourhood(ctx, queries, house); err != nil {
+		return err
+	}
+
 	// Update the house in the database
-	if err := s.queries.UpdateHouse(ctx, db.UpdateHouseParams{
+	if err := queries.UpdateHouse(ctx, db.UpdateHouseParams{
 		ID:                   id,
 		Title:                house.Title,
 		CityID:               house.CityID,
@@ -123,10 +299,69 @@ func (s *Service) UpdateHouse(ctx context.Context, id int64, house models.House)
 		OutdoorParkingSpaces: house.OutdoorParkingSpaces,
 		MainPhoto:            house.MainPhoto,
 		Notes:                house.Notes,
+		Latitude:             house.Latitude,
+		Longitude:            house.Longitude,
+		NeighbourhoodID:      models.NeighbourhoodID(house.NeighbourhoodID),
 	}); err != nil {
 		return fmt.Errorf("failed to update house: %w", err)
 	}
 
+	if err := setHouseTags(ctx, queries, id, house.Tags); err != nil {
+		return err
+	}
+
+	if err := setHouseCustomValues(ctx, queries, id, house.CustomValues); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setHouseCustomValues replaces the custom field values of a house, after validating them
+// against their field definitions; values for unknown fields are ignored
+func setHouseCustomValues(ctx context.Context, queries *db.Queries, houseID int64, values map[int64]string) error {
+	if err := queries.DeleteHouseCustomValues(ctx, houseID); err != nil {
+		return fmt.Errorf("failed to delete house custom values: %w", err)
+	}
+
+	dbFields, err := queries.ListCustomFields(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list custom fields: %w", err)
+	}
+
+	for _, field := range models.FromDBCustomFields(dbFields) {
+		value, err := field.ParseValue(values[field.ID])
+		if err != nil {
+			return fmt.Errorf("invalid value %q for custom field %q: %w", values[field.ID], field.Name, err)
+		}
+		if value == "" {
+			continue
+		}
+
+		if err := queries.CreateCustomValue(ctx, db.CreateCustomValueParams{
+			HouseID: houseID,
+			FieldID: field.ID,
+			Value:   value,
+		}); err != nil {
+			return fmt.Errorf("failed to add house custom value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setHouseTags replaces the tags of a house
+func setHouseTags(ctx context.Context, queries *db.Queries, houseID int64, tags []models.Tag) error {
+	if err := queries.DeleteHouseTags(ctx, houseID); err != nil {
+		return fmt.Errorf("failed to delete house tags: %w", err)
+	}
+
+	for _, tag := range tags {
+		if err := queries.AddHouseTag(ctx, houseID, tag.ID); err != nil {
+			return fmt.Errorf("failed to add house tag: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -158,7 +393,7 @@ func (s *Service) DeleteHouse(ctx context.Context, id int64) error {
 	}
 
 	// Delete the uploads directory for this house
-	uploadsDir := filepath.Join(config.UploadsDir, fmt.Sprintf("%d", id))
+	uploadsDir := filepath.Join(s.uploadsDir, fmt.Sprintf("%d", id))
 	if err := os.RemoveAll(uploadsDir); err != nil {
 		slog.Error("Failed to delete uploads directory", "error", err, "path", uploadsDir)
 		// Continue even if directory deletion fails, as the database records are already deleted
@@ -187,19 +422,30 @@ func (s *Service) GetPublicationURLs(ctx context.Context, houseID int64) ([]mode
 	return publicationURLs, nil
 }
 
-// AddPublicationURL adds a new publication URL for a house
-func (s *Service) AddPublicationURL(ctx context.Context, houseID int64, url string, publicationDate time.Time) error {
+// GetPublicationURL retrieves a publication URL by ID
+func (s *Service) GetPublicationURL(ctx context.Context, id int64) (models.PublicationURL, error) {
+	dbPub, err := s.queries.GetPublicationURL(ctx, id)
+	if err != nil {
+		return models.PublicationURL{}, fmt.Errorf("failed to get publication URL: %w", err)
+	}
+
+	return models.FromDBPublicationURL(dbPub), nil
+}
+
+// AddPublicationURL adds a new publication URL for a house, and returns its ID
+func (s *Service) AddPublicationURL(ctx context.Context, houseID int64, url string, publicationDate time.Time) (int64, error) {
 	params := db.CreatePublicationURLParams{
 		HouseID:         houseID,
 		URL:             url,
 		PublicationDate: publicationDate,
 	}
 
-	if err := s.queries.CreatePublicationURL(ctx, params); err != nil {
-		return fmt.Errorf("failed to add publication URL: %w", err)
+	id, err := s.queries.CreatePublicationURL(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add publication URL: %w", err)
 	}
 
-	return nil
+	return id, nil
 }
 
 // UpdatePublicationURL updates an existing publication URL
@@ -228,7 +474,7 @@ func (s *Service) DeletePublicationURL(ctx context.Context, id int64) error {
 
 // GetPhotos retrieves all photos for a house
 func (s *Service) GetPhotos(ctx context.Context, houseID int64) ([]string, error) {
-	uploadsDir := filepath.Join(config.UploadsDir, fmt.Sprintf("%d", houseID))
+	uploadsDir := filepath.Join(s.uploadsDir, fmt.Sprintf("%d", houseID), "photos")
 
 	// Check if directory exists
 	if _, err := os.Stat(uploadsDir); os.IsNotExist(err) {
@@ -254,7 +500,7 @@ func (s *Service) GetPhotos(ctx context.Context, houseID int64) ([]string, error
 
 // GetAttachments retrieves all attachments for a house
 func (s *Service) GetAttachments(ctx context.Context, houseID int64) ([]string, error) {
-	uploadsDir := filepath.Join(config.UploadsDir, fmt.Sprintf("%d", houseID))
+	uploadsDir := filepath.Join(s.uploadsDir, fmt.Sprintf("%d", houseID), "attachments")
 
 	// Check if directory exists
 	if _, err := os.Stat(uploadsDir); os.IsNotExist(err) {
@@ -267,10 +513,9 @@ func (s *Service) GetAttachments(ctx context.Context, houseID int64) ([]string,
 		return nil, fmt.Errorf("failed to read attachments directory: %w", err)
 	}
 
-	// Filter for non-image files
 	var attachments []string
 	for _, entry := range entries {
-		if !entry.IsDir() && !isImageFile(entry.Name()) {
+		if !entry.IsDir() {
 			attachments = append(attachments, entry.Name())
 		}
 	}
@@ -282,7 +527,7 @@ func (s *Service) GetAttachments(ctx context.Context, houseID int64) ([]string,
 func isImageFile(filename string) bool {
 	ext := filepath.Ext(filename)
 	switch ext {
-	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".avif":
 		return true
 	default:
 		return false
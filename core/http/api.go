@@ -0,0 +1,140 @@
+package http
+
+import (
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/models"
+)
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// apiError is an error caused by an invalid API request, returned as is to
+// the client
+type apiError struct {
+	status  int
+	code    string
+	message string
+	fields  map[string]string // Error of each invalid field, by JSON property name
+}
+
+func (e apiError) Error() string {
+	return e.message
+}
+
+func apiBadRequest(format string, args ...any) error {
+	return apiError{status: http.StatusBadRequest, code: "invalid_request", message: fmt.Sprintf(format, args...)}
+}
+
+// apiInvalidFields returns an error listing the invalid fields of the request
+func apiInvalidFields(code, message string, fields map[string]string) error {
+	return apiError{status: http.StatusBadRequest, code: code, message: message, fields: fields}
+}
+
+func apiConflict(code, format string, args ...any) error {
+	return apiError{status: http.StatusConflict, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// registerAPIRoutes registers the routes of the JSON API
+func (s *Server) registerAPIRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/openapi.json", s.apiOpenAPI)
+
+	// Houses
+	mux.HandleFunc("GET /api/v1/maisons", s.apiListHouses)
+	mux.HandleFunc("POST /api/v1/maisons", s.apiCreateHouse)
+	mux.HandleFunc("GET /api/v1/maisons/{id}", s.apiGetHouse)
+	mux.HandleFunc("PUT /api/v1/maisons/{id}", s.apiUpdateHouse)
+	mux.HandleFunc("DELETE /api/v1/maisons/{id}", s.apiDeleteHouse)
+	mux.HandleFunc("GET /api/v1/maisons/{id}/fichiers", s.apiListHouseFiles)
+
+	// Publication URLs
+	mux.HandleFunc("GET /api/v1/maisons/{id}/publications", s.apiListPublicationURLs)
+	mux.HandleFunc("POST /api/v1/maisons/{id}/publications", s.apiCreatePublicationURL)
+	mux.HandleFunc("GET /api/v1/publications/{id}", s.apiGetPublicationURL)
+	mux.HandleFunc("PUT /api/v1/publications/{id}", s.apiUpdatePublicationURL)
+	mux.HandleFunc("DELETE /api/v1/publications/{id}", s.apiDeletePublicationURL)
+
+	// Cities
+	mux.HandleFunc("GET /api/v1/villes", s.apiListCities)
+	mux.HandleFunc("POST /api/v1/villes", s.apiCreateCity)
+	mux.HandleFunc("GET /api/v1/villes/{id}", s.apiGetCity)
+	mux.HandleFunc("PUT /api/v1/villes/{id}", s.apiUpdateCity)
+	mux.HandleFunc("DELETE /api/v1/villes/{id}", s.apiDeleteCity)
+}
+
+// apiOpenAPI serves the OpenAPI description of the API
+func (s *Server) apiOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDocument)
+}
+
+// writeJSON writes value as the JSON body of the response
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		slog.Error("Failed to write JSON response", "error", err)
+	}
+}
+
+// writeAPIError maps a service error to an HTTP status and writes the
+// matching error body
+func writeAPIError(w http.ResponseWriter, err error) {
+	var requestErr apiError
+
+	status, code, message := http.StatusInternalServerError, "internal_error", "Erreur interne du serveur"
+	var fields map[string]string
+	switch {
+	case errors.As(err, &requestErr):
+		status, code, message, fields = requestErr.status, requestErr.code, requestErr.message, requestErr.fields
+	case errors.Is(err, sql.ErrNoRows):
+		status, code, message = http.StatusNotFound, "not_found", "Ressource introuvable"
+	case errors.Is(err, city.ErrCityNotFound):
+		status, code, message = http.StatusNotFound, "not_found", "Ville introuvable"
+	case errors.Is(err, city.ErrCityExists):
+		status, code, message = http.StatusConflict, "city_exists", "Une ville porte déjà ce nom avec ce code postal"
+	case errors.Is(err, city.ErrCityInUse):
+		status, code, message = http.StatusConflict, "city_in_use", "La ville est utilisée par des maisons et ne peut pas être supprimée"
+	case errors.Is(err, models.ErrInvalidNeighbourhood):
+		status, code, message = http.StatusBadRequest, "invalid_neighbourhood", "Le quartier ne fait pas partie de la ville de la maison"
+	case errors.Is(err, models.ErrInvalidCustomValue):
+		status, code, message = http.StatusBadRequest, "invalid_custom_value", "Valeur invalide pour un critère personnalisé"
+	default:
+		slog.Error("API request failed", "error", err)
+	}
+
+	writeJSON(w, status, models.APIError{
+		Error: models.APIErrorDetails{
+			Code:    code,
+			Message: message,
+			Fields:  fields,
+		},
+	})
+}
+
+// decodeJSON decodes the JSON body of the request into value
+func decodeJSON(r *http.Request, value any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return apiBadRequest("Corps de la requête invalide : %v", err)
+	}
+	return nil
+}
+
+// apiPathID parses a numeric identifier from the request path
+func apiPathID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, apiBadRequest("Identifiant invalide : %s", r.PathValue(name))
+	}
+	return id, nil
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// checkAPICity checks that a city name is given and that no other city has
+// the same name and postal code
+func (s *Server) checkAPICity(r *http.Request, in models.APICityInput, id int64) error {
+	if in.Name == "" {
+		return apiBadRequest("Le nom de la ville est obligatoire")
+	}
+	if in.Population < 0 {
+		return apiBadRequest("La population ne peut pas être négative")
+	}
+
+	cities, err := s.cityService.ListCities(r.Context())
+	if err != nil {
+		return err
+	}
+	for _, c := range cities {
+		if c.Name == in.Name && c.PostalCode == in.PostalCode && c.ID != id {
+			return apiConflict("city_exists", "Une ville porte déjà ce nom avec ce code postal")
+		}
+	}
+	return nil
+}
+
+func (s *Server) apiListCities(w http.ResponseWriter, r *http.Request) {
+	cities, err := s.cityService.ListCities(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	apiCities := make([]models.APICity, len(cities))
+	for i, c := range cities {
+		apiCities[i] = models.NewAPICity(c)
+	}
+	writeJSON(w, http.StatusOK, apiCities)
+}
+
+func (s *Server) apiGetCity(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	c, err := s.cityService.GetCity(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPICity(c))
+}
+
+func (s *Server) apiCreateCity(w http.ResponseWriter, r *http.Request) {
+	var in models.APICityInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.checkAPICity(r, in, 0); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.cityService.CreateCity(r.Context(), in.City()); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	c, err := s.cityService.FindCity(r.Context(), in.Name, in.PostalCode)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/villes/"+strconv.FormatInt(c.ID, 10))
+	writeJSON(w, http.StatusCreated, models.NewAPICity(c))
+}
+
+func (s *Server) apiUpdateCity(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.cityService.GetCity(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var in models.APICityInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.checkAPICity(r, in, id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	city := in.City()
+	city.ID = id
+	if err := s.cityService.UpdateCity(r.Context(), city); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	c, err := s.cityService.GetCity(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPICity(c))
+}
+
+func (s *Server) apiDeleteCity(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.cityService.DeleteCity(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,394 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// validateAPIHouse checks a house sent to the API with the same rules as the
+// house form, and that its city, its tags and its custom fields exist
+func (s *Server) validateAPIHouse(r *http.Request, house models.House) error {
+	formErrors := house.Validate()
+
+	if house.CityID != 0 {
+		_, err := s.cityService.GetCity(r.Context(), house.CityID)
+		if errors.Is(err, city.ErrCityNotFound) {
+			formErrors.Add("city", fmt.Sprintf("Ville inconnue : %d", house.CityID))
+		} else if err != nil {
+			return err
+		}
+	}
+
+	tags, err := s.tagService.ListTags(r.Context())
+	if err != nil {
+		return err
+	}
+	for _, tag := range house.Tags {
+		if !slices.ContainsFunc(tags, func(t models.Tag) bool { return t.ID == tag.ID }) {
+			formErrors.Add("tag_ids", fmt.Sprintf("Étiquette inconnue : %d", tag.ID))
+		}
+	}
+
+	customFields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return err
+	}
+	for _, id := range slices.Sorted(maps.Keys(house.CustomValues)) {
+		i := slices.IndexFunc(customFields, func(f models.CustomField) bool { return f.ID == id })
+		if i < 0 {
+			formErrors.Add("custom_values", fmt.Sprintf("Critère inconnu : %d", id))
+		} else if _, err := customFields[i].ParseValue(house.CustomValues[id]); err != nil {
+			formErrors.Add("custom_values", fmt.Sprintf("Valeur invalide pour le critère « %s » : %s", customFields[i].Name, house.CustomValues[id]))
+		}
+	}
+
+	if formErrors.IsEmpty() {
+		return nil
+	}
+
+	// The errors are named after the properties of the API
+	fields := make(map[string]string, len(formErrors))
+	for name, message := range formErrors {
+		switch name {
+		case "city":
+			name = "city_id"
+		case "coordinates":
+			name = "latitude"
+		}
+		fields[name] = message
+	}
+	return apiInvalidFields("invalid_house", "La maison est invalide", fields)
+}
+
+func (s *Server) apiListHouses(w http.ResponseWriter, r *http.Request) {
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	// Same filter as on the main page
+	filter, err := parseHouseFilter(r, fields)
+	if err != nil {
+		writeAPIError(w, apiBadRequest("Filtre invalide"))
+		return
+	}
+
+	houses, err := s.houseService.ListFilteredHouses(r.Context(), filter)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	apiHouses := make([]models.APIHouse, len(houses))
+	for i, house := range houses {
+		apiHouses[i] = models.NewAPIHouse(house)
+	}
+	writeJSON(w, http.StatusOK, apiHouses)
+}
+
+func (s *Server) apiGetHouse(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	house, err := s.houseService.GetHouse(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPIHouse(house))
+}
+
+func (s *Server) apiCreateHouse(w http.ResponseWriter, r *http.Request) {
+	var in models.APIHouseInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	house := in.House()
+	if err := s.validateAPIHouse(r, house); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	id, err := s.houseService.CreateHouse(r.Context(), house)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	house, err = s.houseService.GetHouse(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/maisons/"+strconv.FormatInt(id, 10))
+	writeJSON(w, http.StatusCreated, models.NewAPIHouse(house))
+}
+
+func (s *Server) apiUpdateHouse(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetHouse(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var in models.APIHouseInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	house := in.House()
+	if err := s.validateAPIHouse(r, house); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.houseService.UpdateHouse(r.Context(), id, house); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	house, err = s.houseService.GetHouse(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPIHouse(house))
+}
+
+func (s *Server) apiDeleteHouse(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetHouse(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.houseService.DeleteHouse(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) apiListHouseFiles(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetHouse(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	photos, err := s.houseService.GetPhotos(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	attachments, err := s.houseService.GetAttachments(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	houseURL := "/maison/" + strconv.FormatInt(id, 10)
+	files := models.APIHouseFiles{
+		Photos:      make([]models.APIFile, len(photos)),
+		Attachments: make([]models.APIFile, len(attachments)),
+	}
+	for i, photo := range photos {
+		files.Photos[i] = models.APIFile{Name: photo, URL: houseURL + "/photos/" + url.PathEscape(photo)}
+	}
+	for i, attachment := range attachments {
+		files.Attachments[i] = models.APIFile{Name: attachment, URL: houseURL + "/piecesjointes/" + url.PathEscape(attachment)}
+	}
+
+	writeJSON(w, http.StatusOK, files)
+}
+
+// parseAPIPublicationURL checks a publication URL sent to the API and returns its date
+func parseAPIPublicationURL(in models.APIPublicationURLInput) (time.Time, error) {
+	fields := map[string]string{}
+	switch {
+	case in.URL == "":
+		fields["url"] = "L'URL est obligatoire"
+	case !models.IsValidPublicationURL(in.URL):
+		fields["url"] = fmt.Sprintf("URL invalide, adresse http ou https attendue : %s", in.URL)
+	}
+	date, err := time.Parse(models.APIDateLayout, in.PublicationDate)
+	if err != nil {
+		fields["publication_date"] = fmt.Sprintf("Date de publication invalide, AAAA-MM-JJ attendu : %s", in.PublicationDate)
+	}
+
+	if len(fields) > 0 {
+		return time.Time{}, apiInvalidFields("invalid_publication_url", "La publication est invalide", fields)
+	}
+	return date, nil
+}
+
+func (s *Server) apiListPublicationURLs(w http.ResponseWriter, r *http.Request) {
+	houseID, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetHouse(r.Context(), houseID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	publicationURLs, err := s.houseService.GetPublicationURLs(r.Context(), houseID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	apiPublicationURLs := make([]models.APIPublicationURL, len(publicationURLs))
+	for i, pub := range publicationURLs {
+		apiPublicationURLs[i] = models.NewAPIPublicationURL(pub)
+	}
+	writeJSON(w, http.StatusOK, apiPublicationURLs)
+}
+
+func (s *Server) apiGetPublicationURL(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	pub, err := s.houseService.GetPublicationURL(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPIPublicationURL(pub))
+}
+
+func (s *Server) apiCreatePublicationURL(w http.ResponseWriter, r *http.Request) {
+	houseID, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetHouse(r.Context(), houseID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var in models.APIPublicationURLInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	date, err := parseAPIPublicationURL(in)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	id, err := s.houseService.AddPublicationURL(r.Context(), houseID, in.URL, date)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	pub, err := s.houseService.GetPublicationURL(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/publications/"+strconv.FormatInt(id, 10))
+	writeJSON(w, http.StatusCreated, models.NewAPIPublicationURL(pub))
+}
+
+func (s *Server) apiUpdatePublicationURL(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetPublicationURL(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var in models.APIPublicationURLInput
+	if err := decodeJSON(r, &in); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	date, err := parseAPIPublicationURL(in)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.houseService.UpdatePublicationURL(r.Context(), id, in.URL, date); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	pub, err := s.houseService.GetPublicationURL(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.NewAPIPublicationURL(pub))
+}
+
+func (s *Server) apiDeletePublicationURL(w http.ResponseWriter, r *http.Request) {
+	id, err := apiPathID(r, "id")
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if _, err := s.houseService.GetPublicationURL(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err := s.houseService.DeletePublicationURL(r.Context(), id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,104 +2,422 @@ package http
 
 import (
 	"errors"
-	"log/slog"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/models"
 	"github.com/willoma/recherche-maison/web"
 )
 
 // modifyCitiesPage renders the page for managing cities
-func (s *Server) modifyCitiesPage(w http.ResponseWriter, r *http.Request) {
+func (s *Server) modifyCitiesPage(w http.ResponseWriter, r *http.Request) error {
+	// Result of a communes import
+	var notice string
+	if created := r.URL.Query().Get("creees"); created != "" {
+		notice = fmt.Sprintf("Villes créées : %s, villes complétées : %s.", created, r.URL.Query().Get("completees"))
+	}
+
+	return s.renderCityManagementPage(w, r, http.StatusOK, notice, models.CityFormError{})
+}
+
+// renderCityManagementPage renders the page for managing cities with the
+// given status, showing the error of a form if any
+func (s *Server) renderCityManagementPage(w http.ResponseWriter, r *http.Request, status int, notice string, formError models.CityFormError) error {
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
 	}
 
 	// Get all cities
 	cities, err := s.cityService.ListCities(r.Context())
 	if err != nil {
-		slog.Error("Failed to get cities", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get cities: %w", err)
 	}
 
 	// Render template
-	component := web.CityManagementPage(cities, houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render city management page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-	}
+	w.WriteHeader(status)
+	component := web.CityManagementPage(cities, notice, formError, houses)
+	return component.Render(r.Context(), w)
 }
 
-func (s *Server) modifyCities(w http.ResponseWriter, r *http.Request) {
+func (s *Server) modifyCities(w http.ResponseWriter, r *http.Request) error {
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		slog.Error("Failed to parse form", "error", err)
-		http.Error(w, "Erreur lors de la soumission du formulaire", http.StatusBadRequest)
-		return
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
 	}
 
 	// Get action type
 	action := r.FormValue("action")
+	formError := models.CityFormError{Action: action}
+
+	// Every action but the creation is about an existing city
+	var cityID int64
+	if action != "create" {
+		cityIDStr := r.FormValue("city_id")
+		var err error
+		cityID, err = strconv.ParseInt(cityIDStr, 10, 64)
+		if err != nil {
+			return badRequest("Identifiant de ville invalide", fmt.Errorf("invalid city ID: %w", err))
+		}
+		formError.CityID = cityID
+	}
 
 	switch action {
 	case "create":
 		// Handle city creation
-		cityName := r.FormValue("city_name")
-		if cityName == "" {
-			slog.Error("City name is required")
-			http.Error(w, "Le nom de la ville est obligatoire", http.StatusBadRequest)
-			return
+		formError.City, formError.Message = parseCityForm(r)
+		if formError.Message != "" {
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
 		}
 
 		// Create city
-		err := s.cityService.CreateCity(r.Context(), cityName)
-		if err != nil {
-			slog.Error("Failed to create city", "name", cityName, "error", err)
-			http.Error(w, "Erreur lors de la création de la ville", http.StatusInternalServerError)
-			return
+		if err := s.cityService.CreateCity(r.Context(), formError.City); err != nil {
+			return s.cityActionFailed(w, r, formError, err)
+		}
+
+	case "update":
+		// Handle city update
+		formError.City, formError.Message = parseCityForm(r)
+		if formError.Message != "" {
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
+		}
+		formError.City.ID = cityID
+
+		// Update city
+		if err := s.cityService.UpdateCity(r.Context(), formError.City); err != nil {
+			return s.cityActionFailed(w, r, formError, err)
+		}
+
+		// The city may be edited from its own page
+		if r.FormValue("redirect") == "ville" {
+			http.Redirect(w, r, "/ville/"+strconv.FormatInt(cityID, 10), http.StatusSeeOther)
+			return nil
 		}
 
+	case "merge":
+		// Handle city merge, after a confirmation
+		formError.Target = strings.TrimSpace(r.FormValue("target"))
+		if formError.Target == "" {
+			formError.Message = "Choisissez la ville dans laquelle fusionner"
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
+		}
+
+		target, err := s.cityService.LookupCity(r.Context(), formError.Target)
+		switch {
+		case errors.Is(err, city.ErrCityNotFound):
+			formError.Message = fmt.Sprintf("La ville « %s » ne fait pas partie de la liste des villes", formError.Target)
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
+		case errors.Is(err, city.ErrCityAmbiguous):
+			formError.Message = fmt.Sprintf("Plusieurs villes portent le nom « %s », choisissez-la avec son code postal", formError.Target)
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
+		case err != nil:
+			return s.cityActionFailed(w, r, formError, err)
+		case target.ID == cityID:
+			formError.Message = "Une ville ne peut pas être fusionnée avec elle-même"
+			return s.renderCityFormError(w, r, http.StatusBadRequest, formError)
+		}
+
+		http.Redirect(w, r, "/ville/"+strconv.FormatInt(target.ID, 10)+"/fusionner/"+strconv.FormatInt(cityID, 10), http.StatusSeeOther)
+		return nil
+
 	case "delete":
 		// Handle city deletion
-		cityIDStr := r.FormValue("city_id")
-		if cityIDStr == "" {
-			slog.Error("City ID is required")
-			http.Error(w, "L'identifiant de la ville est obligatoire", http.StatusBadRequest)
-			return
+		if err := s.cityService.DeleteCity(r.Context(), cityID); err != nil {
+			return s.cityActionFailed(w, r, formError, err)
+		}
+
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	// Redirect back to city management page
+	http.Redirect(w, r, "/villes", http.StatusSeeOther)
+	return nil
+}
+
+// cityActionFailed answers a city form whose action failed: the errors of
+// the city service are shown next to the form, other errors are internal
+// errors
+func (s *Server) cityActionFailed(w http.ResponseWriter, r *http.Request, formError models.CityFormError, err error) error {
+	status := http.StatusConflict
+	switch {
+	case errors.Is(err, city.ErrCityNotFound):
+		status, formError.Message = http.StatusNotFound, "La ville est introuvable, elle a peut-être été supprimée"
+	case errors.Is(err, city.ErrCityExists):
+		formError.Message = "Une ville porte déjà ce nom avec ce code postal"
+	case errors.Is(err, city.ErrCityInUse):
+		formError.Message = "La ville est utilisée par des maisons et ne peut pas être supprimée"
+	default:
+		return internalError("Erreur lors de la modification des villes", fmt.Errorf("failed to modify city: %w", err))
+	}
+
+	return s.renderCityFormError(w, r, status, formError)
+}
+
+// renderCityFormError renders the page of the form with its error: the page
+// of the city when the form was sent from there, the page for managing
+// cities otherwise
+func (s *Server) renderCityFormError(w http.ResponseWriter, r *http.Request, status int, formError models.CityFormError) error {
+	if r.FormValue("redirect") == "ville" && status != http.StatusNotFound {
+		return s.renderCityPage(w, r, formError.CityID, status, formError)
+	}
+	return s.renderCityManagementPage(w, r, status, "", formError)
+}
+
+// parseCityForm parses the city fields of the form, returning a message if
+// they are invalid
+func parseCityForm(r *http.Request) (models.City, string) {
+	cityForm := models.City{
+		Name:       strings.TrimSpace(r.FormValue("city_name")),
+		PostalCode: strings.TrimSpace(r.FormValue("city_postal_code")),
+		INSEECode:  strings.TrimSpace(r.FormValue("city_insee_code")),
+		Department: strings.TrimSpace(r.FormValue("city_department")),
+		Notes:      r.FormValue("city_notes"),
+	}
+
+	if cityForm.Name == "" {
+		return cityForm, "Le nom de la ville est obligatoire"
+	}
+
+	if populationStr := strings.TrimSpace(r.FormValue("city_population")); populationStr != "" {
+		population, err := strconv.ParseInt(populationStr, 10, 64)
+		if err != nil || population < 0 {
+			return cityForm, "La population doit être un nombre entier positif"
+		}
+		cityForm.Population = population
+	}
+
+	return cityForm, ""
+}
+
+// cityPage renders the details of a city, with its houses and their figures
+func (s *Server) cityPage(w http.ResponseWriter, r *http.Request) error {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de ville invalide", fmt.Errorf("invalid city ID: %w", err))
+	}
+
+	return s.renderCityPage(w, r, id, http.StatusOK, models.CityFormError{})
+}
+
+// renderCityPage renders the details of a city with the given status,
+// showing the error of its form if any
+func (s *Server) renderCityPage(w http.ResponseWriter, r *http.Request, id int64, status int, formError models.CityFormError) error {
+	c, err := s.cityService.GetCity(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get city: %w", err)
+	}
+
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	var cityHouses []models.House
+	for _, house := range houses {
+		if house.CityID == id {
+			cityHouses = append(cityHouses, house)
 		}
+	}
 
-		// Parse city ID
-		cityID, err := strconv.ParseInt(cityIDStr, 10, 64)
+	neighbourhoods, err := s.cityService.ListCityNeighbourhoods(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhoods: %w", err)
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.CityPage(c, cityHouses, models.NewCityStats(cityHouses), neighbourhoods, models.NewNeighbourhoodStats(neighbourhoods, cityHouses), formError, houses)
+	return component.Render(r.Context(), w)
+}
+
+// modifyNeighbourhoods creates, renames and deletes the neighbourhoods of a city
+func (s *Server) modifyNeighbourhoods(w http.ResponseWriter, r *http.Request) error {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de ville invalide", fmt.Errorf("invalid city ID: %w", err))
+	}
+
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	name := strings.TrimSpace(r.FormValue("neighbourhood_name"))
+	action := r.FormValue("action")
+	if (action == "create" || action == "update") && name == "" {
+		return badRequest("Le nom du quartier est obligatoire", nil)
+	}
+
+	var neighbourhoodID int64
+	if action == "update" || action == "delete" {
+		neighbourhoodID, err = strconv.ParseInt(r.FormValue("neighbourhood_id"), 10, 64)
 		if err != nil {
-			slog.Error("Invalid city ID", "id", cityIDStr, "error", err)
-			http.Error(w, "Identifiant de ville invalide", http.StatusBadRequest)
-			return
+			return badRequest("Identifiant de quartier invalide", fmt.Errorf("invalid neighbourhood ID: %w", err))
+		}
+		neighbourhood, err := s.cityService.GetNeighbourhood(r.Context(), neighbourhoodID)
+		if err != nil {
+			return fmt.Errorf("failed to get neighbourhood: %w", err)
+		}
+		if neighbourhood.CityID != id {
+			return notFound("Quartier introuvable", nil)
+		}
+	}
+
+	switch action {
+	case "create":
+		err = s.cityService.CreateNeighbourhood(r.Context(), id, name)
+	case "update":
+		err = s.cityService.RenameNeighbourhood(r.Context(), neighbourhoodID, name)
+	case "delete":
+		err = s.cityService.DeleteNeighbourhood(r.Context(), neighbourhoodID)
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	switch {
+	case errors.Is(err, city.ErrNeighbourhoodExists):
+		return badRequest("Un quartier de la ville porte déjà ce nom", nil)
+	case errors.Is(err, city.ErrNeighbourhoodInUse):
+		return badRequest("Le quartier est utilisé par des maisons et ne peut pas être supprimé", nil)
+	case err != nil:
+		return internalError("Erreur lors de la modification des quartiers", fmt.Errorf("failed to modify neighbourhood: %w", err))
+	}
+
+	http.Redirect(w, r, "/ville/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+	return nil
+}
+
+// mergeCitiesPage renders the confirmation to merge a city into another one,
+// listing the houses which will be moved
+func (s *Server) mergeCitiesPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	id, otherID, err := cityPairIDs(r)
+	if err != nil {
+		return err
+	}
+	if id == otherID {
+		return badRequest("Une ville ne peut pas être fusionnée avec elle-même", nil)
+	}
+
+	target, err := s.cityService.GetCity(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get city: %w", err)
+	}
+
+	source, err := s.cityService.GetCity(r.Context(), otherID)
+	if err != nil {
+		return fmt.Errorf("failed to get city: %w", err)
+	}
+
+	var moved []models.House
+	for _, house := range houses {
+		if house.CityID == source.ID {
+			moved = append(moved, house)
 		}
+	}
 
-		// Delete city
-		err = s.cityService.DeleteCity(r.Context(), cityID)
+	// Render template
+	component := web.MergeCitiesPage(target, source, moved, houses)
+	return component.Render(r.Context(), w)
+}
+
+// mergeCities moves the houses of the other city to the city, then deletes
+// the other city
+func (s *Server) mergeCities(w http.ResponseWriter, r *http.Request) error {
+	id, otherID, err := cityPairIDs(r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.cityService.MergeCities(r.Context(), id, otherID); err != nil {
+		return fmt.Errorf("failed to merge cities: %w", err)
+	}
+
+	http.Redirect(w, r, "/ville/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+	return nil
+}
+
+// cityPairIDs parses the IDs of the two cities from the URL
+func cityPairIDs(r *http.Request) (int64, int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, 0, badRequest("Identifiant de ville invalide", fmt.Errorf("invalid city ID: %w", err))
+	}
+
+	otherID, err := strconv.ParseInt(r.PathValue("otherID"), 10, 64)
+	if err != nil {
+		return 0, 0, badRequest("Identifiant de ville invalide", fmt.Errorf("invalid city ID: %w", err))
+	}
+
+	return id, otherID, nil
+}
+
+// searchCities answers the city picker with the display names of the cities
+// matching the search, as JSON
+func (s *Server) searchCities(w http.ResponseWriter, r *http.Request) {
+	search := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	names := []string{}
+	if search != "" {
+		cities, err := s.cityService.SearchCities(r.Context(), search)
 		if err != nil {
-			if errors.Is(err, city.ErrCityInUse) {
-				slog.Error("Cannot delete city that is used by houses", "id", cityID)
-				http.Error(w, "La ville est utilisée par des maisons et ne peut pas être supprimée", http.StatusBadRequest)
-				return
-			}
-			slog.Error("Failed to delete city", "id", cityID, "error", err)
-			http.Error(w, "Erreur lors de la suppression de la ville", http.StatusInternalServerError)
+			writeAPIError(w, fmt.Errorf("failed to search cities: %w", err))
 			return
 		}
+		for _, c := range cities {
+			names = append(names, c.DisplayName())
+		}
+	}
 
-	default:
-		slog.Error("Invalid action", "action", action)
-		http.Error(w, "Action invalide", http.StatusBadRequest)
-		return
+	writeJSON(w, http.StatusOK, names)
+}
+
+// importCommunes creates the selected communes of an official list of
+// communes as cities
+func (s *Server) importCommunes(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseMultipartForm(s.config.MaxUploadSize); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
 	}
 
-	// Redirect back to city management page
-	http.Redirect(w, r, "/villes", http.StatusSeeOther)
+	selection := models.CommuneSelection{
+		Departments: models.ParseDepartments(r.FormValue("departments")),
+	}
+	if radius := strings.TrimSpace(r.FormValue("radius")); radius != "" {
+		var err error
+		selection.Radius, err = strconv.ParseFloat(strings.ReplaceAll(radius, ",", "."), 64)
+		if err != nil || selection.Radius <= 0 {
+			return badRequest("Rayon invalide", nil)
+		}
+		selection.Center, err = models.ParseCoordinates(r.FormValue("center"))
+		if err != nil || selection.Center.IsZero() {
+			return badRequest("Coordonnées du centre invalides", nil)
+		}
+	}
+
+	file, _, err := r.FormFile("communes_file")
+	if err != nil {
+		return badRequest("Aucun fichier n'a été envoyé", nil)
+	}
+	defer file.Close()
+
+	created, completed, err := s.cityService.ImportCommunes(r.Context(), file, selection)
+	if errors.Is(err, city.ErrInvalidCommunesFile) {
+		return badRequest("Le fichier n'est pas une liste de communes", fmt.Errorf("invalid communes file: %w", err))
+	}
+	if err != nil {
+		return internalError("Erreur lors de l'import des communes", fmt.Errorf("failed to import communes: %w", err))
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/villes?creees=%d&completees=%d", created, completed), http.StatusSeeOther)
+	return nil
 }
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// customFieldInputPrefix prefixes the name of custom field inputs in the house form
+const customFieldInputPrefix = "custom_"
+
+// modifyCustomFieldsPage renders the page for managing custom fields
+func (s *Server) modifyCustomFieldsPage(w http.ResponseWriter, r *http.Request) error {
+	return s.renderCustomFieldManagementPage(w, r, http.StatusOK, models.CustomFieldFormError{})
+}
+
+// renderCustomFieldManagementPage renders the page for managing custom fields
+// with the given status, showing the error of a form if any
+func (s *Server) renderCustomFieldManagementPage(w http.ResponseWriter, r *http.Request, status int, formError models.CustomFieldFormError) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	// Get all custom fields
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.CustomFieldManagementPage(fields, formError, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) modifyCustomFields(w http.ResponseWriter, r *http.Request) error {
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	// Get action type
+	action := r.FormValue("action")
+	formError := models.CustomFieldFormError{Action: action}
+
+	switch action {
+	case "create", "update":
+		// Handle custom field creation and modification
+		if action == "update" {
+			fieldID, err := parseCustomFieldID(r)
+			if err != nil {
+				return err
+			}
+			formError.FieldID = fieldID
+		}
+
+		formError.Field, formError.Message = parseCustomFieldForm(r, action)
+		formError.Field.ID = formError.FieldID
+		if formError.Message != "" {
+			return s.renderCustomFieldManagementPage(w, r, http.StatusBadRequest, formError)
+		}
+
+		var err error
+		if action == "create" {
+			err = s.customFieldService.CreateField(r.Context(), formError.Field)
+		} else {
+			err = s.customFieldService.UpdateField(r.Context(), formError.Field)
+		}
+		var inUseErr customfield.ChoicesInUseError
+		switch {
+		case errors.Is(err, customfield.ErrFieldExists):
+			formError.Message = "Un critère porte déjà ce nom"
+			return s.renderCustomFieldManagementPage(w, r, http.StatusConflict, formError)
+		case errors.Is(err, customfield.ErrChoicesRequired):
+			formError.Message = "Les choix possibles sont obligatoires pour un critère de type choix"
+			return s.renderCustomFieldManagementPage(w, r, http.StatusBadRequest, formError)
+		case errors.As(err, &inUseErr):
+			formError.Message = "Des maisons utilisent encore ces choix, modifiez-les d'abord : " + strings.Join(inUseErr.Choices, ", ")
+			return s.renderCustomFieldManagementPage(w, r, http.StatusConflict, formError)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s custom field: %w", action, err)
+		}
+
+	case "delete":
+		// Handle custom field deletion
+		fieldID, err := parseCustomFieldID(r)
+		if err != nil {
+			return err
+		}
+
+		if err := s.customFieldService.DeleteField(r.Context(), fieldID); err != nil {
+			return internalError("Erreur lors de la suppression du critère", fmt.Errorf("failed to delete custom field: %w", err))
+		}
+
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	// Redirect back to custom field management page
+	http.Redirect(w, r, "/criteres", http.StatusSeeOther)
+	return nil
+}
+
+// parseCustomFieldForm parses the custom field definition of the form,
+// returning a message if it is invalid. The type is only sent on creation,
+// it cannot be changed afterwards.
+func parseCustomFieldForm(r *http.Request, action string) (models.CustomField, string) {
+	field := models.CustomField{
+		Name:    strings.TrimSpace(r.FormValue("field_name")),
+		Type:    r.FormValue("field_type"),
+		Unit:    strings.TrimSpace(r.FormValue("field_unit")),
+		Choices: models.ParseCustomFieldChoices(r.FormValue("field_choices")),
+	}
+
+	if field.Name == "" {
+		return field, "Le nom du critère est obligatoire"
+	}
+	if action == "create" {
+		if !models.IsValidCustomFieldType(field.Type) {
+			return field, "Type de critère invalide"
+		}
+	}
+
+	return field, ""
+}
+
+// parseCustomFieldID parses the custom field ID from the form, returning an error if it is missing or invalid
+func parseCustomFieldID(r *http.Request) (int64, error) {
+	fieldIDStr := r.FormValue("field_id")
+	if fieldIDStr == "" {
+		return 0, badRequest("L'identifiant du critère est obligatoire", nil)
+	}
+
+	fieldID, err := strconv.ParseInt(fieldIDStr, 10, 64)
+	if err != nil {
+		return 0, badRequest("Identifiant de critère invalide", fmt.Errorf("invalid custom field ID: %w", err))
+	}
+
+	return fieldID, nil
+}
+
+// parseCustomValues collects the custom field values sent with the house form,
+// their validation against field definitions is done by the house service
+func parseCustomValues(r *http.Request) map[int64]string {
+	values := map[int64]string{}
+	for name := range r.Form {
+		idStr, ok := strings.CutPrefix(name, customFieldInputPrefix)
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		values[id] = r.FormValue(name)
+	}
+	return values
+}
+
+// parseCustomFieldConditions parses the conditions on custom fields from the query string
+func parseCustomFieldConditions(r *http.Request, fields []models.CustomField) []models.CustomFieldCondition {
+	query := r.URL.Query()
+
+	var conditions []models.CustomFieldCondition
+	for _, field := range fields {
+		prefix := "critere_" + strconv.FormatInt(field.ID, 10)
+		condition := models.CustomFieldCondition{
+			Field: field,
+			Value: strings.TrimSpace(query.Get(prefix)),
+			Min:   strings.TrimSpace(query.Get(prefix + "_min")),
+			Max:   strings.TrimSpace(query.Get(prefix + "_max")),
+		}
+		if !condition.IsEmpty() {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/web"
+)
+
+// mergeHousesPage renders the confirmation to merge a house into another one
+func (s *Server) mergeHousesPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	id, otherID, err := housePairIDs(r)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.houseService.GetHouse(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	source, err := s.houseService.GetHouse(r.Context(), otherID)
+	if err != nil {
+		return err
+	}
+
+	// Render template
+	component := web.MergeHousesPage(target, source, houses)
+	return component.Render(r.Context(), w)
+}
+
+// mergeHouses merges the other house into the house, then deletes the other house
+func (s *Server) mergeHouses(w http.ResponseWriter, r *http.Request) error {
+	id, otherID, err := housePairIDs(r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.houseService.MergeHouses(r.Context(), id, otherID); err != nil {
+		return fmt.Errorf("failed to merge houses: %w", err)
+	}
+
+	http.Redirect(w, r, "/maison/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+	return nil
+}
+
+// dismissDuplicate records that two houses are not duplicates
+func (s *Server) dismissDuplicate(w http.ResponseWriter, r *http.Request) error {
+	id, otherID, err := housePairIDs(r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.duplicateService.Dismiss(r.Context(), id, otherID); err != nil {
+		return internalError("Erreur lors de l'enregistrement", fmt.Errorf("failed to dismiss duplicate: %w", err))
+	}
+
+	http.Redirect(w, r, "/maison/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+	return nil
+}
+
+// housePairIDs returns the IDs of the two houses in the URL path
+func housePairIDs(r *http.Request) (int64, int64, error) {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, 0, badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	otherIDStr := r.PathValue("otherID")
+	otherID, err := strconv.ParseInt(otherIDStr, 10, 64)
+	if err != nil {
+		return 0, 0, badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	return id, otherID, nil
+}
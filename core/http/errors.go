@@ -0,0 +1,172 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/geo"
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/core/importer"
+	"github.com/willoma/recherche-maison/core/journal"
+	"github.com/willoma/recherche-maison/core/tag"
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// pageHandler is an HTTP handler of the web interface, whose error is
+// answered with an error page
+type pageHandler func(w http.ResponseWriter, r *http.Request) error
+
+// pageError is an error answered with an error page showing its french
+// message, with its HTTP status
+type pageError struct {
+	status  int
+	message string
+	err     error // Cause of the error, logged instead of the message if set
+}
+
+func (e pageError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return e.message
+}
+
+func (e pageError) Unwrap() error {
+	return e.err
+}
+
+// badRequest returns an error answered with a 400 status and the given
+// message, caused by err if not nil
+func badRequest(message string, err error) error {
+	return pageError{status: http.StatusBadRequest, message: message, err: err}
+}
+
+// notFound returns an error answered with a 404 status and the given
+// message, caused by err if not nil
+func notFound(message string, err error) error {
+	return pageError{status: http.StatusNotFound, message: message, err: err}
+}
+
+// internalError returns an error answered with a 500 status and the given
+// message, caused by err
+func internalError(message string, err error) error {
+	return pageError{status: http.StatusInternalServerError, message: message, err: err}
+}
+
+// errorStatus returns the HTTP status and the french message matching an
+// error returned by a page handler
+func errorStatus(err error) (int, string) {
+	var pageErr pageError
+	switch {
+	case errors.As(err, &pageErr):
+		return pageErr.status, pageErr.message
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, "Cet élément n'existe pas, il a peut-être été supprimé"
+	case errors.Is(err, city.ErrCityNotFound):
+		return http.StatusNotFound, "Ville introuvable"
+	case errors.Is(err, journal.ErrEntryNotFound):
+		return http.StatusNotFound, "Entrée du journal introuvable"
+	case errors.Is(err, city.ErrCityExists):
+		return http.StatusConflict, "Une ville porte déjà ce nom avec ce code postal"
+	case errors.Is(err, city.ErrCityInUse):
+		return http.StatusConflict, "La ville est utilisée par des maisons et ne peut pas être supprimée"
+	case errors.Is(err, tag.ErrTagExists):
+		return http.StatusConflict, "Une étiquette porte déjà ce nom"
+	case errors.Is(err, customfield.ErrFieldExists):
+		return http.StatusConflict, "Un critère porte déjà ce nom"
+	case errors.Is(err, city.ErrNeighbourhoodExists):
+		return http.StatusConflict, "Un quartier de la ville porte déjà ce nom"
+	case errors.Is(err, city.ErrNeighbourhoodInUse):
+		return http.StatusConflict, "Le quartier est utilisé par des maisons et ne peut pas être supprimé"
+	case errors.Is(err, city.ErrMergeSameCity):
+		return http.StatusBadRequest, "Une ville ne peut pas être fusionnée avec elle-même"
+	case errors.Is(err, city.ErrInvalidCommunesFile):
+		return http.StatusBadRequest, "Le fichier n'est pas une liste de communes"
+	case errors.Is(err, house.ErrMergeSameHouse):
+		return http.StatusBadRequest, "Une maison ne peut pas être fusionnée avec elle-même"
+	case errors.Is(err, importer.ErrInvalidCSV):
+		return http.StatusBadRequest, "Le fichier n'est pas un fichier CSV valide"
+	case errors.Is(err, geo.ErrInvalidBANFile):
+		return http.StatusBadRequest, "Le fichier n'est pas un fichier d'adresses de la Base Adresse Nationale"
+	case errors.Is(err, geo.ErrInvalidGeoJSONFile):
+		return http.StatusBadRequest, "Le fichier n'est pas un fichier GeoJSON de contours"
+	case errors.Is(err, models.ErrInvalidNeighbourhood):
+		return http.StatusBadRequest, "Le quartier ne fait pas partie de la ville de la maison"
+	case errors.Is(err, models.ErrInvalidCustomValue):
+		return http.StatusBadRequest, "Valeur invalide pour un critère personnalisé"
+	case errors.Is(err, models.ErrInvalidCoordinates):
+		return http.StatusBadRequest, "Coordonnées invalides"
+	default:
+		return http.StatusInternalServerError, "Erreur interne du serveur"
+	}
+}
+
+// handle adapts a page handler to the mux: its error is logged, then
+// answered with an error page unless the response was already started
+func (s *Server) handle(h pageHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		err := h(rw, r)
+		if err == nil {
+			return
+		}
+
+		status, message := errorStatus(err)
+		if status >= http.StatusInternalServerError {
+			slog.Error("Request failed", "method", r.Method, "path", r.URL.Path, "status", status, "error", err)
+		} else {
+			slog.Warn("Invalid request", "method", r.Method, "path", r.URL.Path, "status", status, "error", err)
+		}
+
+		if rw.started {
+			return
+		}
+		s.renderErrorPage(w, r, status, message)
+	}
+}
+
+// renderErrorPage renders an error page with the given status, keeping the
+// navigation menu
+func (s *Server) renderErrorPage(w http.ResponseWriter, r *http.Request, status int, message string) {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		slog.Error("Failed to get houses", "error", err)
+	}
+
+	w.WriteHeader(status)
+	if err := web.ErrorPage(status, message, houses).Render(r.Context(), w); err != nil {
+		slog.Error("Failed to render error page", "error", err)
+	}
+}
+
+// notFoundPage answers the requests matching no route
+func (s *Server) notFoundPage(w http.ResponseWriter, r *http.Request) error {
+	return notFound("Cette page n'existe pas", nil)
+}
+
+// responseWriter records whether the response was started, so that an error
+// page is not written after a partial response
+type responseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap gives access to the underlying response writer, for http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// exportCSV exports the houses matching the filter of the main page as CSV
+func (s *Server) exportCSV(w http.ResponseWriter, r *http.Request) error {
+	return s.exportHouses(w, r, "text/csv; charset=utf-8", "maisons.csv", s.exportService.WriteCSV)
+}
+
+// exportODS exports the houses matching the filter of the main page as an
+// OpenDocument spreadsheet
+func (s *Server) exportODS(w http.ResponseWriter, r *http.Request) error {
+	return s.exportHouses(w, r, "application/vnd.oasis.opendocument.spreadsheet", "maisons.ods", s.exportService.WriteODS)
+}
+
+func (s *Server) exportHouses(w http.ResponseWriter, r *http.Request, contentType, filename string, write func(context.Context, io.Writer, models.HouseFilter) error) error {
+	// Get custom fields for the filter
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	// Get filter from query string
+	filter, err := parseHouseFilter(r, fields)
+	if err != nil {
+		return badRequest("Filtre invalide", fmt.Errorf("invalid house filter: %w", err))
+	}
+
+	// The export is streamed, an error can only be logged once it has started
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if err := write(r.Context(), w, filter); err != nil {
+		return fmt.Errorf("failed to export houses to %s: %w", filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/geo"
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// pointsOfInterestPage renders the page for managing the points of interest
+func (s *Server) pointsOfInterestPage(w http.ResponseWriter, r *http.Request) error {
+	return s.renderPointsOfInterestPage(w, r, http.StatusOK, models.PointOfInterestFormError{})
+}
+
+// renderPointsOfInterestPage renders the page for managing the points of
+// interest with the given status, showing the error of a form if any
+func (s *Server) renderPointsOfInterestPage(w http.ResponseWriter, r *http.Request, status int, formError models.PointOfInterestFormError) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	points, err := s.geoService.ListPointsOfInterest(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get points of interest: %w", err)
+	}
+
+	addressCount, err := s.geoService.CountAddresses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to count addresses: %w", err)
+	}
+
+	// Result of an addresses import
+	var notice string
+	if imported := r.URL.Query().Get("importees"); imported != "" {
+		notice = imported + " adresses ont été importées."
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.PointsOfInterestPage(points, formError, addressCount, s.config.Geocoder == config.GeocoderBAN, notice, houses)
+	return component.Render(r.Context(), w)
+}
+
+// modifyPointsOfInterest creates, updates or deletes a point of interest
+func (s *Server) modifyPointsOfInterest(w http.ResponseWriter, r *http.Request) error {
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	// Get action type
+	action := r.FormValue("action")
+	formError := models.PointOfInterestFormError{
+		Action:      action,
+		Coordinates: strings.TrimSpace(r.FormValue("poi_coordinates")),
+		Address:     r.FormValue("poi_address"),
+		City:        r.FormValue("poi_city"),
+	}
+
+	switch action {
+	case "create", "update":
+		if action == "update" {
+			id, err := parsePointOfInterestID(r)
+			if err != nil {
+				return err
+			}
+			formError.PointID = id
+		}
+
+		var err error
+		formError.Point, formError.Message, err = s.parsePointOfInterestForm(r)
+		if err != nil {
+			return err
+		}
+		if formError.Message != "" {
+			return s.renderPointsOfInterestPage(w, r, http.StatusBadRequest, formError)
+		}
+
+		point := formError.Point
+		point.ID = formError.PointID
+		if action == "create" {
+			err = s.geoService.CreatePointOfInterest(r.Context(), point)
+		} else {
+			err = s.geoService.UpdatePointOfInterest(r.Context(), point)
+		}
+		if errors.Is(err, geo.ErrPointOfInterestExists) {
+			formError.Message = "Un lieu porte déjà ce nom"
+			return s.renderPointsOfInterestPage(w, r, http.StatusConflict, formError)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s point of interest: %w", action, err)
+		}
+
+	case "delete":
+		id, err := parsePointOfInterestID(r)
+		if err != nil {
+			return err
+		}
+
+		if err := s.geoService.DeletePointOfInterest(r.Context(), id); err != nil {
+			return internalError("Erreur lors de la suppression du lieu", fmt.Errorf("failed to delete point of interest: %w", err))
+		}
+
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	// Redirect back to points of interest page
+	http.Redirect(w, r, "/lieux", http.StatusSeeOther)
+	return nil
+}
+
+// parsePointOfInterestForm parses the point of interest fields of the form,
+// locating it from its address when its coordinates are empty. It returns a
+// message if the fields are invalid.
+func (s *Server) parsePointOfInterestForm(r *http.Request) (models.PointOfInterest, string, error) {
+	point := models.PointOfInterest{
+		Name:       strings.TrimSpace(r.FormValue("poi_name")),
+		Category:   r.FormValue("poi_category"),
+		TravelMode: r.FormValue("poi_travel_mode"),
+	}
+	if point.Category == "" {
+		point.Category = models.PointOfInterestOther
+	}
+	if point.TravelMode == "" {
+		point.TravelMode = models.TravelDriving
+	}
+
+	if point.Name == "" {
+		return point, "Le nom du lieu est obligatoire", nil
+	}
+	if !models.IsValidPointOfInterestCategory(point.Category) {
+		point.Category = models.PointOfInterestOther
+		return point, "Catégorie de lieu invalide", nil
+	}
+	if !models.IsValidTravelMode(point.TravelMode) {
+		point.TravelMode = models.TravelDriving
+		return point, "Moyen de transport invalide", nil
+	}
+	if maxTravelTime := r.FormValue("poi_max_travel_time"); maxTravelTime != "" {
+		var err error
+		point.MaxTravelTime, err = strconv.ParseInt(maxTravelTime, 10, 64)
+		if err != nil || point.MaxTravelTime < 0 {
+			point.MaxTravelTime = 0
+			return point, "Temps de trajet maximum invalide", nil
+		}
+	}
+
+	coordinates, err := models.ParseCoordinates(r.FormValue("poi_coordinates"))
+	if err != nil {
+		return point, "Coordonnées invalides", nil
+	}
+
+	// Without coordinates, the point of interest is located from its address
+	if coordinates.IsZero() {
+		coordinates, err = s.geoService.GeocodeAddress(r.Context(), r.FormValue("poi_address"), r.FormValue("poi_city"))
+		if errors.Is(err, geo.ErrNotFound) {
+			return point, "Adresse introuvable, saisissez les coordonnées du lieu", nil
+		}
+		if err != nil {
+			return point, "", internalError("Erreur lors de la localisation de l'adresse", fmt.Errorf("failed to geocode point of interest: %w", err))
+		}
+	}
+	point.Latitude, point.Longitude = coordinates.Latitude, coordinates.Longitude
+
+	return point, "", nil
+}
+
+// parsePointOfInterestID parses the point of interest ID from the form,
+// returning an error if it is missing or invalid
+func parsePointOfInterestID(r *http.Request) (int64, error) {
+	idStr := r.FormValue("poi_id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, badRequest("Identifiant de lieu invalide", fmt.Errorf("invalid point of interest ID: %w", err))
+	}
+	return id, nil
+}
+
+// importAddresses imports a BAN addresses file, used by the offline geocoder
+func (s *Server) importAddresses(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseMultipartForm(s.config.MaxUploadSize); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	file, _, err := r.FormFile("addresses_file")
+	if err != nil {
+		return badRequest("Aucun fichier n'a été envoyé", nil)
+	}
+	defer file.Close()
+
+	count, err := s.geoService.ImportAddresses(r.Context(), file)
+	if errors.Is(err, geo.ErrInvalidBANFile) {
+		return badRequest("Le fichier n'est pas un fichier d'adresses de la Base Adresse Nationale", fmt.Errorf("invalid addresses file: %w", err))
+	}
+	if err != nil {
+		return internalError("Erreur lors de l'import des adresses", fmt.Errorf("failed to import addresses: %w", err))
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/lieux?importees=%d", count), http.StatusSeeOther)
+	return nil
+}
+
+// locateHouse sets the coordinates of a house from its address
+func (s *Server) locateHouse(w http.ResponseWriter, r *http.Request) error {
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	_, err = s.geoService.LocateHouse(r.Context(), id)
+	if errors.Is(err, geo.ErrNotFound) {
+		return notFound("L'adresse de la maison est introuvable, saisissez ses coordonnées dans le formulaire de modification", nil)
+	}
+	if err != nil {
+		return internalError("Erreur lors de la localisation de la maison", fmt.Errorf("failed to locate house: %w", err))
+	}
+
+	http.Redirect(w, r, "/maison/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+	return nil
+}
+
+// locateHouseForm sets the coordinates of a submitted house from its address
+// when they are not given. Failures are only logged: the house can still be saved.
+func (s *Server) locateHouseForm(r *http.Request, houseForm *models.House) {
+	if !houseForm.Coordinates().IsZero() {
+		return
+	}
+
+	c, err := s.geoService.Geocode(r.Context(), *houseForm)
+	if err != nil {
+		if !errors.Is(err, geo.ErrNotFound) {
+			slog.Error("Failed to geocode house", "address", houseForm.Address, "error", err)
+		}
+		return
+	}
+
+	houseForm.Latitude = c.Latitude
+	houseForm.Longitude = c.Longitude
+}
@@ -1,418 +1,593 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"path/filepath"
 	"strconv"
+	"strings"
 
-	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/city"
 	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/core/scraper"
 	"github.com/willoma/recherche-maison/models"
 	"github.com/willoma/recherche-maison/web"
 )
 
-func (s *Server) createHousePage(w http.ResponseWriter, r *http.Request) {
+func (s *Server) createHousePage(w http.ResponseWriter, r *http.Request) error {
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
 	}
 
 	// Get cities for the dropdown
 	cities, err := s.cityService.ListCities(r.Context())
 	if err != nil {
-		slog.Error("Failed to get cities", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get cities: %w", err)
 	}
 
-	// Render template
-	component := web.CreateHousePage(cities, houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render create house page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
+	neighbourhoods, err := s.cityService.ListNeighbourhoods(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhoods: %w", err)
 	}
-}
 
-func (s *Server) createHouse(w http.ResponseWriter, r *http.Request) {
-	// Parse form data
-	houseForm, err, errMsg := parseHouseForm(r)
+	// Get tags for the checkboxes
+	tags, err := s.tagService.ListTags(r.Context())
 	if err != nil {
-		slog.Error("Failed to parse house form", "error", err)
-		http.Error(w, errMsg, http.StatusBadRequest)
-		return
+		return fmt.Errorf("failed to get tags: %w", err)
 	}
 
-	// Create the house and get its ID
-	houseID, err := s.houseService.CreateHouse(r.Context(), houseForm)
+	// Get custom fields
+	fields, err := s.customFieldService.ListFields(r.Context())
 	if err != nil {
-		slog.Error("Failed to create house", "error", err)
-		http.Error(w, "Erreur lors de la création de la maison", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get custom fields: %w", err)
 	}
 
-	// Handle publication URLs
-	urls := r.Form["pub_url[]"]
-	dates := r.Form["pub_date[]"]
-
-	// Add publication URLs
-	for i, url := range urls {
-		if url == "" {
-			continue
+	// Pre-fill the form from a listing, if requested
+	var houseForm models.House
+	var publicationURLs []models.PublicationURL
+	var listingPhotos []string
+	var notice string
+	listingURL := r.URL.Query().Get("annonce")
+	if listingURL != "" {
+		listing, err := s.scraperService.Scrape(r.Context(), listingURL)
+		switch {
+		case errors.Is(err, scraper.ErrInvalidURL):
+			notice = "L'adresse de l'annonce est invalide"
+		case err != nil:
+			slog.Error("Failed to scrape listing", "url", listingURL, "error", err)
+			notice = "Impossible de lire l'annonce, vous pouvez saisir la maison vous-même"
+		default:
+			houseForm = listing.House()
+			publicationURLs = []models.PublicationURL{listing.PublicationURL()}
+			listingPhotos = listing.PhotoURLs
+			if listing.CityName != "" {
+				houseForm.CityID = matchCity(cities, listing.CityName)
+				if houseForm.CityID == 0 {
+					notice = fmt.Sprintf("La ville « %s » de l'annonce ne fait pas partie de la liste des villes", listing.CityName)
+				}
+			}
 		}
+	}
 
-		// Skip if we don't have a matching date
-		if i >= len(dates) || dates[i] == "" {
-			continue
-		}
+	// Render template
+	component := web.CreateHousePage(houseForm, publicationURLs, listingURL, listingPhotos, notice, nil, nil, cities, neighbourhoods, tags, fields, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) createHouse(w http.ResponseWriter, r *http.Request) error {
+	// Parse and check form data
+	houseForm, publicationURLs, formErrors, err := s.readHouseForm(r)
+	if err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to read house form: %w", err))
+	}
+	if !formErrors.IsEmpty() {
+		return s.renderCreateHouseForm(w, r, http.StatusUnprocessableEntity, houseForm, publicationURLs, nil, formErrors)
+	}
+
+	s.locateHouseForm(r, &houseForm)
 
-		// Parse the date
-		date, err := house.ParsePublicationDate(dates[i])
+	// Warn about possible duplicates, unless the user already confirmed the creation
+	if r.FormValue("confirm_duplicate") == "" {
+		duplicates, err := s.duplicateService.FindDuplicates(r.Context(), houseForm)
 		if err != nil {
-			slog.Error("Invalid publication date", "date", dates[i], "error", err)
-			continue
+			return fmt.Errorf("failed to find duplicates: %w", err)
+		}
+		if len(duplicates) > 0 {
+			return s.renderCreateHouseForm(w, r, http.StatusOK, houseForm, publicationURLs, duplicates, nil)
 		}
+	}
 
-		// Create publication URL
-		err = s.houseService.AddPublicationURL(r.Context(), houseID, url, date)
+	// Create the house and get its ID
+	houseID, err := s.houseService.CreateHouse(r.Context(), houseForm)
+	if formErrors := houseServiceFormErrors(err); formErrors != nil {
+		return s.renderCreateHouseForm(w, r, http.StatusUnprocessableEntity, houseForm, publicationURLs, nil, formErrors)
+	}
+	if err != nil {
+		return internalError("Erreur lors de la création de la maison", fmt.Errorf("failed to create house: %w", err))
+	}
+
+	// Add publication URLs
+	for _, publicationURL := range publicationURLs {
+		_, err = s.houseService.AddPublicationURL(r.Context(), houseID, publicationURL.URL, publicationURL.PublicationDate)
 		if err != nil {
-			slog.Error("Failed to add publication URL", "house_id", houseID, "url", url, "error", err)
+			slog.Error("Failed to add publication URL", "house_id", houseID, "url", publicationURL.URL, "error", err)
 		}
 	}
 
 	// Handle photo uploads
 	// TODO: Implement photo uploads
 
+	// Download the photos selected from the listing
+	if listingPhotos := r.Form["listing_photos[]"]; len(listingPhotos) > 0 {
+		filenames, err := s.scraperService.DownloadPhotos(r.Context(), houseID, listingPhotos)
+		if err != nil {
+			slog.Error("Failed to download listing photos", "house_id", houseID, "error", err)
+		}
+		if houseForm.MainPhoto == "" && len(filenames) > 0 {
+			houseForm.MainPhoto = filenames[0]
+			if err := s.houseService.UpdateHouse(r.Context(), houseID, houseForm); err != nil {
+				slog.Error("Failed to set main photo", "house_id", houseID, "error", err)
+			}
+		}
+	}
+
 	// Redirect to house details page
 	http.Redirect(w, r, "/maison/"+strconv.FormatInt(houseID, 10), http.StatusSeeOther)
+	return nil
+}
+
+// houseServiceFormErrors returns the errors of the house form matching an
+// error of the house service about the submitted values, nil for other errors
+func houseServiceFormErrors(err error) models.FormErrors {
+	switch {
+	case errors.Is(err, models.ErrInvalidNeighbourhood):
+		return models.FormErrors{"neighbourhood_id": "Le quartier ne fait pas partie de la ville de la maison"}
+	case errors.Is(err, models.ErrInvalidCustomValue):
+		return models.FormErrors{"custom_fields": "Valeur invalide pour un critère personnalisé"}
+	default:
+		return nil
+	}
 }
 
-func (s *Server) modifyHousePage(w http.ResponseWriter, r *http.Request) {
+// renderCreateHouseForm renders the house creation form again with the given
+// status, with the submitted values, warning about the possible duplicates
+// and showing the errors of the fields
+func (s *Server) renderCreateHouseForm(w http.ResponseWriter, r *http.Request, status int, houseForm models.House, publicationURLs []models.PublicationURL, duplicates []models.DuplicateCandidate, formErrors models.FormErrors) error {
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	cities, err := s.cityService.ListCities(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get cities: %w", err)
+	}
+
+	neighbourhoods, err := s.cityService.ListNeighbourhoods(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhoods: %w", err)
+	}
+
+	tags, err := s.tagService.ListTags(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
 	}
 
+	w.WriteHeader(status)
+	component := web.CreateHousePage(houseForm, publicationURLs, "", r.Form["listing_photos[]"], "", duplicates, formErrors, cities, neighbourhoods, tags, fields, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) modifyHousePage(w http.ResponseWriter, r *http.Request) error {
 	// Get house ID from URL
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		slog.Error("Invalid house ID", "id", idStr, "error", err)
-		http.Error(w, "Identifiant de maison invalide", http.StatusBadRequest)
-		return
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
 	}
 
 	// Get house from database
 	house, err := s.houseService.GetHouse(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get house", "id", id, "error", err)
-		http.Error(w, "Maison introuvable", http.StatusNotFound)
-		return
+		return err
 	}
 
 	// Get publication URLs
 	publicationURLs, err := s.houseService.GetPublicationURLs(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get publication URLs", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get publication URLs: %w", err)
+	}
+
+	return s.renderModifyHouseForm(w, r, http.StatusOK, house, publicationURLs, nil)
+}
+
+// renderModifyHouseForm renders the house modification form with the given
+// status, showing the errors of the fields
+func (s *Server) renderModifyHouseForm(w http.ResponseWriter, r *http.Request, status int, house models.House, publicationURLs []models.PublicationURL, formErrors models.FormErrors) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
 	}
 
 	// Get photos
-	photos, err := s.houseService.GetPhotos(r.Context(), id)
+	photos, err := s.houseService.GetPhotos(r.Context(), house.ID)
 	if err != nil {
-		slog.Error("Failed to get photos", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get photos: %w", err)
 	}
 
 	// Get attachments
-	attachments, err := s.houseService.GetAttachments(r.Context(), id)
+	attachments, err := s.houseService.GetAttachments(r.Context(), house.ID)
 	if err != nil {
-		slog.Error("Failed to get attachments", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get attachments: %w", err)
 	}
 
 	// Get cities for the dropdown
 	cities, err := s.cityService.ListCities(r.Context())
 	if err != nil {
-		slog.Error("Failed to get cities", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get cities: %w", err)
 	}
 
-	// Render template
-	component := web.ModifyHousePage(house, publicationURLs, photos, attachments, cities, houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render modify house page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
+	neighbourhoods, err := s.cityService.ListNeighbourhoods(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhoods: %w", err)
 	}
+
+	// Get tags for the checkboxes
+	tags, err := s.tagService.ListTags(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	// Get custom fields
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.ModifyHousePage(house, publicationURLs, photos, attachments, formErrors, cities, neighbourhoods, tags, fields, houses)
+	return component.Render(r.Context(), w)
 }
 
-func (s *Server) modifyHouse(w http.ResponseWriter, r *http.Request) {
+func (s *Server) modifyHouse(w http.ResponseWriter, r *http.Request) error {
 	// Get house ID from URL path
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		slog.Error("Invalid house ID", "error", err)
-		http.Error(w, "Identifiant de maison invalide", http.StatusBadRequest)
-		return
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
 	}
 
-	// Parse form data
-	houseForm, err, errMsg := parseHouseForm(r)
+	// Parse and check form data
+	houseForm, publicationURLs, formErrors, err := s.readHouseForm(r)
 	if err != nil {
-		slog.Error("Failed to parse house form", "error", err)
-		http.Error(w, errMsg, http.StatusBadRequest)
-		return
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to read house form: %w", err))
+	}
+	houseForm.ID = id
+	if !formErrors.IsEmpty() {
+		return s.renderModifyHouseForm(w, r, http.StatusUnprocessableEntity, houseForm, publicationURLs, formErrors)
 	}
 
+	s.locateHouseForm(r, &houseForm)
+
 	// Update the house in the database
 	err = s.houseService.UpdateHouse(r.Context(), id, houseForm)
+	if formErrors := houseServiceFormErrors(err); formErrors != nil {
+		return s.renderModifyHouseForm(w, r, http.StatusUnprocessableEntity, houseForm, publicationURLs, formErrors)
+	}
 	if err != nil {
-		slog.Error("Failed to update house", "error", err)
-		http.Error(w, "Erreur lors de la mise à jour de la maison", http.StatusInternalServerError)
-		return
+		return internalError("Erreur lors de la mise à jour de la maison", fmt.Errorf("failed to update house: %w", err))
 	}
 
 	// Redirect to house page
 	http.Redirect(w, r, fmt.Sprintf("/maison/%d", id), http.StatusSeeOther)
-	return
+	return nil
 }
 
-func (s *Server) deleteHousePage(w http.ResponseWriter, r *http.Request) {
+func (s *Server) deleteHousePage(w http.ResponseWriter, r *http.Request) error {
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
 	}
 
 	// Get house ID from URL
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		slog.Error("Invalid house ID", "id", idStr, "error", err)
-		http.Error(w, "Identifiant de maison invalide", http.StatusBadRequest)
-		return
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
 	}
 
 	// Get house from database
 	house, err := s.houseService.GetHouse(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get house", "id", id, "error", err)
-		http.Error(w, "Maison introuvable", http.StatusNotFound)
-		return
+		return err
 	}
 
 	// Render template
 	component := web.DeleteHousePage(house, houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render delete house page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-	}
+	return component.Render(r.Context(), w)
 }
 
-func (s *Server) deleteHouse(w http.ResponseWriter, r *http.Request) {
+func (s *Server) deleteHouse(w http.ResponseWriter, r *http.Request) error {
 	// Delete house submission handler will be implemented later
+	return nil
 }
 
-func (s *Server) housePage(w http.ResponseWriter, r *http.Request) {
+func (s *Server) housePage(w http.ResponseWriter, r *http.Request) error {
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
 	}
 
 	// Get house ID from URL
 	idStr := r.PathValue("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		slog.Error("Invalid house ID", "id", idStr, "error", err)
-		http.Error(w, "Identifiant de maison invalide", http.StatusBadRequest)
-		return
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
 	}
 
 	// Get house from database
 	house, err := s.houseService.GetHouse(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get house", "id", id, "error", err)
-		http.Error(w, "Maison introuvable", http.StatusNotFound)
-		return
+		return err
 	}
 
 	// Get publication URLs
 	publicationURLs, err := s.houseService.GetPublicationURLs(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get publication URLs", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get publication URLs: %w", err)
+	}
+
+	// Get the latest check of each publication
+	checks, err := s.watcherService.ListHouseChecks(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get publication checks: %w", err)
 	}
 
 	// Get photos
 	photos, err := s.houseService.GetPhotos(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get photos", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get photos: %w", err)
 	}
 
 	// Get attachments
 	attachments, err := s.houseService.GetAttachments(r.Context(), id)
 	if err != nil {
-		slog.Error("Failed to get attachments", "house_id", id, "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get attachments: %w", err)
 	}
 
-	// Render template
-	component := web.HousePage(house, publicationURLs, photos, attachments, houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render house page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
+	// Get timeline, merging the journal with the other events
+	timeline, err := s.journalService.GetTimeline(r.Context(), house, publicationURLs)
+	if err != nil {
+		return fmt.Errorf("failed to get timeline: %w", err)
+	}
+
+	// Get tasks
+	tasks, err := s.taskService.ListHouseTasks(r.Context(), id)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Get custom fields
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	// Get the houses which may describe the same property
+	duplicates, err := s.duplicateService.FindDuplicates(r.Context(), house)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	// Get the distances to the points of interest
+	proximity, err := s.geoService.Proximity(r.Context(), house)
+	if err != nil {
+		return fmt.Errorf("failed to compute distances: %w", err)
 	}
+
+	// Render template
+	component := web.HousePage(house, publicationURLs, checks, photos, attachments, fields, timeline, tasks, duplicates, proximity, houses)
+	return component.Render(r.Context(), w)
 }
 
-func (s *Server) housePhoto(w http.ResponseWriter, r *http.Request) {
+func (s *Server) housePhoto(w http.ResponseWriter, r *http.Request) error {
 	houseID := r.PathValue("id")
 	filename := r.PathValue("filename")
 
-	filePath := filepath.Join(config.UploadsDir, houseID, "photos", filename)
+	filePath := filepath.Join(s.config.UploadsDir, houseID, "photos", filename)
 	http.ServeFile(w, r, filePath)
+	return nil
 }
 
-func (s *Server) houseAttachment(w http.ResponseWriter, r *http.Request) {
+func (s *Server) houseAttachment(w http.ResponseWriter, r *http.Request) error {
 	houseID := r.PathValue("id")
 	filename := r.PathValue("filename")
 
-	filePath := filepath.Join(config.UploadsDir, houseID, "attachments", filename)
+	filePath := filepath.Join(s.config.UploadsDir, houseID, "attachments", filename)
 	http.ServeFile(w, r, filePath)
+	return nil
 }
 
-// parseHouseForm parses the form data for house creation and modification
-// Returns the parsed house data, an error if parsing fails, and a translated error message
-func parseHouseForm(r *http.Request) (models.House, error, string) {
-	var houseForm models.House
-	var err error
+func (s *Server) houseDossier(w http.ResponseWriter, r *http.Request) error {
+	// Get house ID from URL
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
 
-	// Parse multipart form data (for file uploads)
-	if err := r.ParseMultipartForm(config.MaxUploadSize); err != nil {
-		return houseForm, err, "Erreur lors de la soumission du formulaire"
+	// Get house from database
+	house, err := s.houseService.GetHouse(r.Context(), id)
+	if err != nil {
+		return err
 	}
 
-	// Parse title
-	houseForm.Title = r.FormValue("title")
-	if houseForm.Title == "" {
-		return houseForm, fmt.Errorf("title is required"), "Le titre est obligatoire"
+	// The archive is streamed, an error can only be logged once it has started
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="maison-%d.zip"`, id))
+	if err := s.dossierService.WriteZIP(r.Context(), w, house); err != nil {
+		return fmt.Errorf("failed to write dossier of house %d: %w", id, err)
 	}
+	return nil
+}
 
-	// Parse city ID
-	cityIDStr := r.FormValue("city_id")
-	if cityIDStr == "" {
-		return houseForm, fmt.Errorf("city ID is required"), "L'identifiant de ville est obligatoire"
+// matchCity returns the ID of the city with the given name, ignoring case and
+// hyphens, or 0 if there is none
+func matchCity(cities []models.City, name string) int64 {
+	normalize := func(name string) string {
+		return strings.Join(strings.Fields(strings.ReplaceAll(name, "-", " ")), " ")
 	}
+	name = normalize(name)
+	for _, city := range cities {
+		if strings.EqualFold(normalize(city.Name), name) {
+			return city.ID
+		}
+	}
+	return 0
+}
 
-	houseForm.CityID, err = strconv.ParseInt(cityIDStr, 10, 64)
-	if err != nil {
-		return houseForm, fmt.Errorf("invalid city ID: %w", err), "Identifiant de ville invalide"
+// lookupHouseFormCity sets the ID of the city chosen by its name in the
+// house form. Returns a translated error message if no city or several
+// cities match the name, and an error if the lookup failed.
+func (s *Server) lookupHouseFormCity(r *http.Request, houseForm *models.House) (string, error) {
+	if houseForm.CityID != 0 {
+		return "", nil
 	}
 
-	// Parse address (optional)
-	houseForm.Address = r.FormValue("address")
+	c, err := s.cityService.LookupCity(r.Context(), houseForm.CityName)
+	switch {
+	case errors.Is(err, city.ErrCityNotFound):
+		return fmt.Sprintf("La ville « %s » ne fait pas partie de la liste des villes", houseForm.CityName), nil
+	case errors.Is(err, city.ErrCityAmbiguous):
+		return fmt.Sprintf("Plusieurs villes portent le nom « %s », choisissez-la avec son code postal", houseForm.CityName), nil
+	case err != nil:
+		return "", err
+	}
 
-	// Parse price
-	priceStr := r.FormValue("price")
-	if priceStr != "" {
-		houseForm.Price, err = strconv.ParseInt(priceStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid price: %w", err), "Prix invalide"
-		}
+	houseForm.CityID = c.ID
+	houseForm.CityName = c.Name
+	houseForm.CityPostalCode = c.PostalCode
+	return "", nil
+}
+
+// readHouseForm parses and validates the form data for house creation and
+// modification. Returns the house, its publication URLs and the errors of
+// the fields, and an error if the form cannot be read or checked at all.
+func (s *Server) readHouseForm(r *http.Request) (models.House, []models.PublicationURL, models.FormErrors, error) {
+	houseForm, publicationURLs, formErrors, err := parseHouseForm(r, s.config.MaxUploadSize)
+	if err != nil {
+		return houseForm, nil, nil, err
+	}
+	for field, message := range houseForm.Validate() {
+		formErrors.Add(field, message)
 	}
 
-	// Parse surface
-	surfaceStr := r.FormValue("surface")
-	if surfaceStr != "" {
-		houseForm.Surface, err = strconv.ParseInt(surfaceStr, 10, 64)
+	// The city must exist, and the neighbourhood must be one of its own
+	if formErrors.Get("city") == "" {
+		message, err := s.lookupHouseFormCity(r, &houseForm)
 		if err != nil {
-			return houseForm, fmt.Errorf("invalid surface: %w", err), "Surface invalide"
+			return houseForm, nil, nil, err
+		}
+		if message != "" {
+			formErrors.Add("city", message)
 		}
 	}
-
-	// Parse rooms
-	roomsStr := r.FormValue("rooms")
-	if roomsStr != "" {
-		houseForm.Rooms, err = strconv.ParseInt(roomsStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid rooms: %w", err), "Nombre de pièces invalide"
+	if houseForm.NeighbourhoodID != 0 && houseForm.CityID != 0 {
+		neighbourhood, err := s.cityService.GetNeighbourhood(r.Context(), houseForm.NeighbourhoodID)
+		if err != nil || neighbourhood.CityID != houseForm.CityID {
+			formErrors.Add("neighbourhood_id", "Le quartier ne fait pas partie de la ville de la maison")
 		}
 	}
 
-	// Parse bedrooms
-	bedroomsStr := r.FormValue("bedrooms")
-	if bedroomsStr != "" {
-		houseForm.Bedrooms, err = strconv.ParseInt(bedroomsStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid bedrooms: %w", err), "Nombre de chambres invalide"
+	// Custom values must match the type of their field
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return houseForm, nil, nil, err
+	}
+	for _, field := range fields {
+		if _, err := field.ParseValue(houseForm.CustomValues[field.ID]); err != nil {
+			formErrors.Add(customFieldInputPrefix+strconv.FormatInt(field.ID, 10), "Valeur invalide pour ce critère")
 		}
 	}
 
-	// Parse bathrooms
-	bathroomsStr := r.FormValue("bathrooms")
-	if bathroomsStr != "" {
-		houseForm.Bathrooms, err = strconv.ParseInt(bathroomsStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid bathrooms: %w", err), "Nombre de salles de bain invalide"
-		}
+	return houseForm, publicationURLs, formErrors, nil
+}
+
+// parseHouseForm parses the form data for house creation and modification.
+// Returns the parsed house data and publication URLs, with the errors of the
+// fields which cannot be parsed or are missing, and an error if the form
+// cannot be read at all.
+func parseHouseForm(r *http.Request, maxUploadSize int64) (models.House, []models.PublicationURL, models.FormErrors, error) {
+	var houseForm models.House
+	formErrors := models.FormErrors{}
+
+	// Parse multipart form data (for file uploads)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		return houseForm, nil, nil, err
 	}
 
-	// Parse floors
-	floorsStr := r.FormValue("floors")
-	if floorsStr != "" {
-		houseForm.Floors, err = strconv.ParseInt(floorsStr, 10, 64)
+	// Parse title
+	houseForm.Title = strings.TrimSpace(r.FormValue("title"))
+
+	// Parse city, chosen by its name in the city picker, or given by its ID.
+	// The name is looked up by lookupHouseFormCity.
+	houseForm.CityName = strings.TrimSpace(r.FormValue("city"))
+	if cityIDStr := r.FormValue("city_id"); houseForm.CityName == "" && cityIDStr != "" {
+		cityID, err := strconv.ParseInt(cityIDStr, 10, 64)
 		if err != nil {
-			return houseForm, fmt.Errorf("invalid floors: %w", err), "Nombre d'étages invalide"
+			formErrors.Add("city", "Identifiant de ville invalide")
 		}
+		houseForm.CityID = cityID
 	}
 
-	// Parse construction year (optional)
-	if yearStr := r.FormValue("construction_year"); yearStr != "" {
-		houseForm.ConstructionYear, err = strconv.ParseInt(yearStr, 10, 64)
+	// Parse neighbourhood (optional)
+	if neighbourhoodIDStr := r.FormValue("neighbourhood_id"); neighbourhoodIDStr != "" {
+		neighbourhoodID, err := strconv.ParseInt(neighbourhoodIDStr, 10, 64)
 		if err != nil {
-			return houseForm, fmt.Errorf("invalid construction year: %w", err), "Année de construction invalide"
+			formErrors.Add("neighbourhood_id", "Identifiant de quartier invalide")
 		}
+		houseForm.NeighbourhoodID = neighbourhoodID
 	}
 
+	// Parse address (optional)
+	houseForm.Address = r.FormValue("address")
+
+	// Parse coordinates (optional)
+	coordinates, err := models.ParseCoordinates(r.FormValue("coordinates"))
+	if err != nil {
+		formErrors.Add("coordinates", "Coordonnées invalides, saisissez la latitude puis la longitude")
+	}
+	houseForm.Latitude = coordinates.Latitude
+	houseForm.Longitude = coordinates.Longitude
+
+	// Parse numbers, the mandatory ones must be filled in
+	parseIntegerField(r, formErrors, "price", true, &houseForm.Price)
+	parseIntegerField(r, formErrors, "surface", true, &houseForm.Surface)
+	parseIntegerField(r, formErrors, "rooms", true, &houseForm.Rooms)
+	parseIntegerField(r, formErrors, "bedrooms", true, &houseForm.Bedrooms)
+	parseIntegerField(r, formErrors, "bathrooms", true, &houseForm.Bathrooms)
+	parseIntegerField(r, formErrors, "floors", true, &houseForm.Floors)
+	parseIntegerField(r, formErrors, "construction_year", false, &houseForm.ConstructionYear)
+	parseIntegerField(r, formErrors, "land_surface", false, &houseForm.LandSurface)
+	parseIntegerField(r, formErrors, "outdoor_parking_spaces", false, &houseForm.OutdoorParkingSpaces)
+
 	// Parse house type
 	houseForm.HouseType = r.FormValue("house_type")
 
-	// Parse land surface (optional)
-	if landSurfaceStr := r.FormValue("land_surface"); landSurfaceStr != "" {
-		houseForm.LandSurface, err = strconv.ParseInt(landSurfaceStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid land surface: %w", err), "Surface du terrain invalide"
-		}
-	}
-
 	// Parse has garage (optional)
 	if hasGarageStr := r.FormValue("has_garage"); hasGarageStr != "" {
 		houseForm.HasGarage, err = strconv.ParseBool(hasGarageStr)
 		if err != nil {
-			return houseForm, fmt.Errorf("invalid has garage: %w", err), "Valeur invalide pour le garage"
-		}
-	}
-
-	// Parse outdoor parking spaces (optional)
-	if outdoorParkingStr := r.FormValue("outdoor_parking_spaces"); outdoorParkingStr != "" {
-		houseForm.OutdoorParkingSpaces, err = strconv.ParseInt(outdoorParkingStr, 10, 64)
-		if err != nil {
-			return houseForm, fmt.Errorf("invalid outdoor parking spaces: %w", err), "Nombre de places de parking extérieures invalide"
+			formErrors.Add("has_garage", "Valeur invalide pour le garage")
 		}
 	}
 
@@ -422,5 +597,83 @@ func parseHouseForm(r *http.Request) (models.House, error, string) {
 	// Get main photo
 	houseForm.MainPhoto = r.FormValue("main_photo")
 
-	return houseForm, nil, ""
+	// Parse tags (optional)
+	tagIDs, err := parseTagIDs(r.Form["tag_ids[]"])
+	if err != nil {
+		formErrors.Add("tags", "Identifiant d'étiquette invalide")
+	}
+	for _, tagID := range tagIDs {
+		houseForm.Tags = append(houseForm.Tags, models.Tag{ID: tagID})
+	}
+
+	// Parse custom field values (optional)
+	houseForm.CustomValues = parseCustomValues(r)
+
+	return houseForm, parsePublicationForm(r, formErrors), formErrors, nil
+}
+
+// parseIntegerField parses an integer field of the form into value,
+// recording an error if it is not an integer, or if it is mandatory and empty
+func parseIntegerField(r *http.Request, formErrors models.FormErrors, name string, mandatory bool, value *int64) {
+	valueStr := strings.TrimSpace(r.FormValue(name))
+	if valueStr == "" {
+		if mandatory {
+			formErrors.Add(name, "Ce champ est obligatoire")
+		}
+		return
+	}
+
+	parsed, err := strconv.ParseInt(valueStr, 10, 64)
+	if err != nil {
+		formErrors.Add(name, "Saisissez un nombre entier")
+		return
+	}
+	*value = parsed
+}
+
+// parsePublicationForm parses the publication URLs of the house form,
+// recording the errors of their URLs and dates. Rows without URL nor date
+// are ignored.
+func parsePublicationForm(r *http.Request, formErrors models.FormErrors) []models.PublicationURL {
+	urls := r.Form["pub_url[]"]
+	dates := r.Form["pub_date[]"]
+	ids := r.Form["pub_id[]"]
+
+	var publicationURLs []models.PublicationURL
+	for i, url := range urls {
+		publicationURL := models.PublicationURL{URL: strings.TrimSpace(url)}
+		var dateStr string
+		if i < len(dates) {
+			dateStr = strings.TrimSpace(dates[i])
+		}
+		if i < len(ids) {
+			publicationURL.ID, _ = strconv.ParseInt(ids[i], 10, 64)
+		}
+		if publicationURL.URL == "" && dateStr == "" {
+			continue
+		}
+
+		// Errors are named after the fields of the row once rendered again
+		index := strconv.Itoa(len(publicationURLs))
+		switch {
+		case publicationURL.URL == "":
+			formErrors.Add("pub_url_"+index, "L'adresse de l'annonce est obligatoire")
+		case !models.IsValidPublicationURL(publicationURL.URL):
+			formErrors.Add("pub_url_"+index, "L'adresse de l'annonce doit commencer par http:// ou https://")
+		}
+
+		date, err := house.ParsePublicationDate(dateStr)
+		switch {
+		case dateStr == "":
+			formErrors.Add("pub_date_"+index, "La date de publication est obligatoire")
+		case err != nil:
+			formErrors.Add("pub_date_"+index, "Date de publication invalide")
+		default:
+			publicationURL.PublicationDate = date
+		}
+
+		publicationURLs = append(publicationURLs, publicationURL)
+	}
+
+	return publicationURLs
 }
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/willoma/recherche-maison/core/importer"
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// importPage renders the form to upload a CSV file of houses
+func (s *Server) importPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	// Render template
+	component := web.ImportPage(houses)
+	return component.Render(r.Context(), w)
+}
+
+// importPreview renders the houses found in an uploaded CSV file, or in the
+// CSV data of a previous preview with a new column mapping
+func (s *Server) importPreview(w http.ResponseWriter, r *http.Request) error {
+	data, mapping, err := s.parseImportForm(r)
+	if err != nil {
+		return err
+	}
+
+	preview, err := importer.PreviewCSV(data, mapping)
+	if err != nil {
+		return badRequest("Le fichier n'est pas un fichier CSV valide", fmt.Errorf("failed to read CSV file: %w", err))
+	}
+
+	return s.renderImportPreview(w, r, preview, data, http.StatusOK)
+}
+
+// importHouses imports the houses of a previewed CSV file
+func (s *Server) importHouses(w http.ResponseWriter, r *http.Request) error {
+	data, mapping, err := s.parseImportForm(r)
+	if err != nil {
+		return err
+	}
+
+	preview, err := s.importService.ImportCSV(r.Context(), data, mapping)
+	if errors.Is(err, importer.ErrInvalidCSV) && preview.HasErrors() {
+		return s.renderImportPreview(w, r, preview, data, http.StatusUnprocessableEntity)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to import houses: %w", err)
+	}
+
+	slog.Info("Houses imported", "count", len(preview.Rows))
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) renderImportPreview(w http.ResponseWriter, r *http.Request, preview models.CSVImportPreview, data []byte, status int) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.ImportPreviewPage(preview, string(data), houses)
+	return component.Render(r.Context(), w)
+}
+
+// parseImportForm returns the CSV data, either uploaded or sent back by the
+// preview page, and the column mapping, nil when it must be guessed
+func (s *Server) parseImportForm(r *http.Request) ([]byte, []string, error) {
+	if err := r.ParseMultipartForm(s.config.MaxUploadSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, nil, badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	file, _, err := r.FormFile("csv_file")
+	if err == nil {
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, nil, badRequest("Erreur lors de la lecture du fichier", fmt.Errorf("failed to read uploaded CSV file: %w", err))
+		}
+		return data, nil, nil
+	}
+
+	data := r.FormValue("csv_data")
+	if data == "" {
+		return nil, nil, badRequest("Aucun fichier n'a été envoyé", nil)
+	}
+
+	return []byte(data), r.Form["mapping"], nil
+}
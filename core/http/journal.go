@@ -0,0 +1,134 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+func (s *Server) addJournalEntry(w http.ResponseWriter, r *http.Request) error {
+	// Get house ID from URL path
+	idStr := r.PathValue("id")
+	houseID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	// Parse form data
+	entry, err, errMsg := parseJournalEntryForm(r)
+	if err != nil {
+		return badRequest(errMsg, fmt.Errorf("failed to parse journal entry form: %w", err))
+	}
+	entry.HouseID = houseID
+
+	if err := s.journalService.AddEntry(r.Context(), entry); err != nil {
+		return internalError("Erreur lors de l'ajout au journal", fmt.Errorf("failed to add journal entry: %w", err))
+	}
+
+	// Redirect to house page
+	http.Redirect(w, r, fmt.Sprintf("/maison/%d#journal", houseID), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) completeFollowUp(w http.ResponseWriter, r *http.Request) error {
+	// Get house and entry IDs from URL path
+	houseID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	entryIDStr := r.PathValue("entryID")
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant d'entrée de journal invalide", fmt.Errorf("invalid journal entry ID: %w", err))
+	}
+
+	if err := s.journalService.CompleteFollowUp(r.Context(), houseID, entryID); err != nil {
+		return fmt.Errorf("failed to complete follow-up: %w", err)
+	}
+
+	// Redirect back to the page the follow-up was completed from
+	http.Redirect(w, r, redirectTarget(r, "/maison/"+r.PathValue("id")+"#journal"), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) deleteJournalEntry(w http.ResponseWriter, r *http.Request) error {
+	// Get house and entry IDs from URL path
+	houseID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	entryIDStr := r.PathValue("entryID")
+	entryID, err := strconv.ParseInt(entryIDStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant d'entrée de journal invalide", fmt.Errorf("invalid journal entry ID: %w", err))
+	}
+
+	if err := s.journalService.DeleteEntry(r.Context(), houseID, entryID); err != nil {
+		return fmt.Errorf("failed to delete journal entry: %w", err)
+	}
+
+	// Redirect to house page
+	http.Redirect(w, r, "/maison/"+r.PathValue("id")+"#journal", http.StatusSeeOther)
+	return nil
+}
+
+// redirectTarget returns the local path given in the "redirect" form value,
+// or fallback if it is missing or does not point inside the application
+func redirectTarget(r *http.Request, fallback string) string {
+	target := r.FormValue("redirect")
+	if len(target) < 1 || target[0] != '/' || (len(target) > 1 && (target[1] == '/' || target[1] == '\\')) {
+		return fallback
+	}
+	return target
+}
+
+// parseJournalEntryForm parses the form data for journal entry creation
+// Returns the parsed entry, an error if parsing fails, and a translated error message
+func parseJournalEntryForm(r *http.Request) (models.JournalEntry, error, string) {
+	var entry models.JournalEntry
+	var err error
+
+	if err := r.ParseForm(); err != nil {
+		return entry, err, "Erreur lors de la soumission du formulaire"
+	}
+
+	// Parse entry type
+	entry.EntryType = r.FormValue("entry_type")
+	if !models.IsValidJournalEntryType(entry.EntryType) {
+		return entry, fmt.Errorf("invalid entry type %q", entry.EntryType), "Type d'échange invalide"
+	}
+
+	// Parse entry date
+	entryDateStr := r.FormValue("entry_date")
+	if entryDateStr == "" {
+		return entry, fmt.Errorf("entry date is required"), "La date est obligatoire"
+	}
+	entry.EntryDate, err = time.Parse("2006-01-02", entryDateStr)
+	if err != nil {
+		return entry, fmt.Errorf("invalid entry date: %w", err), "Date invalide"
+	}
+
+	// Parse contact (optional)
+	entry.Contact = r.FormValue("contact")
+
+	// Parse summary
+	entry.Summary = r.FormValue("summary")
+	if entry.Summary == "" {
+		return entry, fmt.Errorf("summary is required"), "Le résumé est obligatoire"
+	}
+
+	// Parse follow-up date (optional)
+	if followUpStr := r.FormValue("follow_up_date"); followUpStr != "" {
+		entry.FollowUpDate, err = time.Parse("2006-01-02", followUpStr)
+		if err != nil {
+			return entry, fmt.Errorf("invalid follow-up date: %w", err), "Date de relance invalide"
+		}
+	}
+
+	return entry, nil, ""
+}
@@ -1,26 +1,103 @@
 package http
 
 import (
-	"log/slog"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 
+	"github.com/willoma/recherche-maison/models"
 	"github.com/willoma/recherche-maison/web"
 )
 
 // mainPage renders the main page with the list of houses
-func (s *Server) mainPage(w http.ResponseWriter, r *http.Request) {
+func (s *Server) mainPage(w http.ResponseWriter, r *http.Request) error {
 	// Get houses from database
 	houses, err := s.houseService.ListHouses(r.Context())
 	if err != nil {
-		slog.Error("Failed to get houses", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
-		return
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	// Get custom fields for the filter
+	fields, err := s.customFieldService.ListFields(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get custom fields: %w", err)
+	}
+
+	// Get filter from query string
+	filter, err := parseHouseFilter(r, fields)
+	if err != nil {
+		return badRequest("Filtre invalide", fmt.Errorf("invalid house filter: %w", err))
+	}
+
+	// Get houses matching the filter
+	filteredHouses := houses
+	if !filter.IsEmpty() {
+		filteredHouses, err = s.houseService.ListFilteredHouses(r.Context(), filter)
+		if err != nil {
+			return fmt.Errorf("failed to get filtered houses: %w", err)
+		}
+	}
+
+	// Get sort from query string
+	sort, err := models.ParseHouseSort(r.URL.Query().Get("tri"))
+	if err != nil {
+		return badRequest("Tri invalide", fmt.Errorf("invalid house sort: %w", err))
+	}
+
+	// Get the distances to the points of interest, shown and used for sorting
+	proximities, points, err := s.geoService.Proximities(r.Context(), filteredHouses)
+	if err != nil {
+		return fmt.Errorf("failed to compute proximities: %w", err)
+	}
+
+	// The houses list is shared with the menu, which must keep its order
+	filteredHouses = slices.Clone(filteredHouses)
+	if err := models.SortHouses(filteredHouses, sort, proximities, fields); err != nil {
+		return badRequest("Tri invalide", fmt.Errorf("invalid house sort: %w", err))
+	}
+
+	// Get tags for the filter
+	tags, err := s.tagService.ListTags(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	// Get neighbourhoods for the filter
+	neighbourhoods, err := s.cityService.ListNeighbourhoods(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get neighbourhoods: %w", err)
+	}
+
+	// Get follow-ups that are due
+	followUps, err := s.journalService.ListDueFollowUps(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get due follow-ups: %w", err)
 	}
 
 	// Render template
-	component := web.MainPage(houses)
-	if err := component.Render(r.Context(), w); err != nil {
-		slog.Error("Failed to render main page", "error", err)
-		http.Error(w, "Erreur interne du serveur", http.StatusInternalServerError)
+	component := web.MainPage(filteredHouses, filter, sort, r.URL.Query(), points, proximities, neighbourhoods, tags, fields, followUps, houses)
+	return component.Render(r.Context(), w)
+}
+
+// parseHouseFilter parses the house filter from the query string
+func parseHouseFilter(r *http.Request, fields []models.CustomField) (models.HouseFilter, error) {
+	var filter models.HouseFilter
+	var err error
+
+	if neighbourhood := r.URL.Query().Get("quartier"); neighbourhood != "" {
+		filter.NeighbourhoodID, err = strconv.ParseInt(neighbourhood, 10, 64)
+		if err != nil {
+			return filter, err
+		}
+	}
+
+	filter.TagIDs, err = parseTagIDs(r.URL.Query()["etiquette"])
+	if err != nil {
+		return filter, err
 	}
+
+	filter.CustomFields = parseCustomFieldConditions(r, fields)
+
+	return filter, nil
 }
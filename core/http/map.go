@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/willoma/recherche-maison/core/geo"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// mapPage renders the map of the houses
+func (s *Server) mapPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	statuses, err := s.watcherService.PublicationStatuses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get publication statuses: %w", err)
+	}
+
+	houseMap, err := s.geoService.Map(r.Context(), houses, statuses)
+	if err != nil {
+		return fmt.Errorf("failed to build map: %w", err)
+	}
+
+	// Result of a boundaries import
+	var notice string
+	if imported := r.URL.Query().Get("importes"); imported != "" {
+		notice = imported + " contours de villes ont été importés."
+	}
+
+	// Render template
+	component := web.MapPage(houseMap, notice, houses)
+	return component.Render(r.Context(), w)
+}
+
+// modifyBoundaries imports the boundaries of the cities from a GeoJSON file,
+// or deletes all of them
+func (s *Server) modifyBoundaries(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseMultipartForm(s.config.MaxUploadSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	// Get action type
+	action := r.FormValue("action")
+
+	switch action {
+	case "import":
+		file, _, err := r.FormFile("boundaries_file")
+		if err != nil {
+			return badRequest("Aucun fichier n'a été envoyé", nil)
+		}
+		defer file.Close()
+
+		count, err := s.geoService.ImportBoundaries(r.Context(), file)
+		if errors.Is(err, geo.ErrInvalidGeoJSONFile) {
+			return badRequest("Le fichier n'est pas un fichier GeoJSON de contours de villes", fmt.Errorf("invalid boundaries file: %w", err))
+		}
+		if err != nil {
+			return internalError("Erreur lors de l'import des contours", fmt.Errorf("failed to import boundaries: %w", err))
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/carte?importes=%d", count), http.StatusSeeOther)
+		return nil
+
+	case "delete":
+		if err := s.geoService.DeleteBoundaries(r.Context()); err != nil {
+			return internalError("Erreur lors de la suppression des contours", fmt.Errorf("failed to delete boundaries: %w", err))
+		}
+
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	// Redirect back to map page
+	http.Redirect(w, r, "/carte", http.StatusSeeOther)
+	return nil
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/web"
+)
+
+// notificationsPage renders the page listing the changes detected on the publications
+func (s *Server) notificationsPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	notifications, err := s.watcherService.ListNotifications(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	// Render template
+	component := web.NotificationsPage(notifications, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) markNotificationRead(w http.ResponseWriter, r *http.Request) error {
+	// Get notification ID from URL path
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de notification invalide", fmt.Errorf("invalid notification ID: %w", err))
+	}
+
+	if err := s.watcherService.MarkRead(r.Context(), id); err != nil {
+		return internalError("Erreur lors de la mise à jour de la notification", fmt.Errorf("failed to mark notification as read: %w", err))
+	}
+
+	// Redirect back to the page the notification was marked from
+	http.Redirect(w, r, redirectTarget(r, "/notifications"), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) markAllNotificationsRead(w http.ResponseWriter, r *http.Request) error {
+	if err := s.watcherService.MarkAllRead(r.Context()); err != nil {
+		return internalError("Erreur lors de la mise à jour des notifications", fmt.Errorf("failed to mark notifications as read: %w", err))
+	}
+
+	http.Redirect(w, r, "/notifications", http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) checkHousePublications(w http.ResponseWriter, r *http.Request) error {
+	// Get house ID from URL path
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de maison invalide", fmt.Errorf("invalid house ID: %w", err))
+	}
+
+	if err := s.watcherService.CheckHouse(r.Context(), id); err != nil {
+		return internalError("Erreur lors de la vérification des annonces", fmt.Errorf("failed to check house publications: %w", err))
+	}
+
+	http.Redirect(w, r, "/maison/"+idStr, http.StatusSeeOther)
+	return nil
+}
+
+// withUnreadNotifications makes the unread notifications count available to
+// the layout of the pages
+func (s *Server) withUnreadNotifications(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only pages display the count
+		if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		count, err := s.watcherService.UnreadCount(r.Context())
+		if err != nil {
+			slog.Error("Failed to count unread notifications", "error", err)
+		}
+		ctx := web.WithUnreadNotifications(r.Context(), count)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -9,30 +9,65 @@ import (
 
 	"github.com/willoma/recherche-maison/config"
 	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/dossier"
+	"github.com/willoma/recherche-maison/core/duplicate"
+	"github.com/willoma/recherche-maison/core/export"
 	"github.com/willoma/recherche-maison/core/file"
+	"github.com/willoma/recherche-maison/core/geo"
 	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/core/importer"
+	"github.com/willoma/recherche-maison/core/journal"
+	"github.com/willoma/recherche-maison/core/scraper"
+	"github.com/willoma/recherche-maison/core/tag"
+	"github.com/willoma/recherche-maison/core/task"
+	"github.com/willoma/recherche-maison/core/watcher"
 	"github.com/willoma/recherche-maison/static"
 )
 
 // Server handles HTTP requests for the application
 type Server struct {
-	fileService  *file.Service
-	houseService *house.Service
-	cityService  *city.Service
+	config             config.Config
+	fileService        *file.Service
+	houseService       *house.Service
+	cityService        *city.Service
+	journalService     *journal.Service
+	taskService        *task.Service
+	tagService         *tag.Service
+	customFieldService *customfield.Service
+	dossierService     *dossier.Service
+	exportService      *export.Service
+	importService      *importer.Service
+	scraperService     *scraper.Service
+	watcherService     *watcher.Service
+	duplicateService   *duplicate.Service
+	geoService         *geo.Service
 }
 
 // NewServer creates a new HTTP server
-func NewServer(fileService *file.Service, houseService *house.Service, cityService *city.Service) *Server {
+func NewServer(cfg config.Config, fileService *file.Service, houseService *house.Service, cityService *city.Service, journalService *journal.Service, taskService *task.Service, tagService *tag.Service, customFieldService *customfield.Service, dossierService *dossier.Service, exportService *export.Service, importService *importer.Service, scraperService *scraper.Service, watcherService *watcher.Service, duplicateService *duplicate.Service, geoService *geo.Service) *Server {
 	return &Server{
-		fileService:  fileService,
-		houseService: houseService,
-		cityService:  cityService,
+		config:             cfg,
+		fileService:        fileService,
+		houseService:       houseService,
+		cityService:        cityService,
+		journalService:     journalService,
+		taskService:        taskService,
+		tagService:         tagService,
+		customFieldService: customFieldService,
+		dossierService:     dossierService,
+		exportService:      exportService,
+		importService:      importService,
+		scraperService:     scraperService,
+		watcherService:     watcherService,
+		duplicateService:   duplicateService,
+		geoService:         geoService,
 	}
 }
 
 // Run starts the HTTP server
-func Run(fileService *file.Service, houseService *house.Service, cityService *city.Service) {
-	server := NewServer(fileService, houseService, cityService)
+func Run(cfg config.Config, fileService *file.Service, houseService *house.Service, cityService *city.Service, journalService *journal.Service, taskService *task.Service, tagService *tag.Service, customFieldService *customfield.Service, dossierService *dossier.Service, exportService *export.Service, importService *importer.Service, scraperService *scraper.Service, watcherService *watcher.Service, duplicateService *duplicate.Service, geoService *geo.Service) {
+	server := NewServer(cfg, fileService, houseService, cityService, journalService, taskService, tagService, customFieldService, dossierService, exportService, importService, scraperService, watcherService, duplicateService, geoService)
 	server.Start()
 }
 
@@ -40,7 +75,7 @@ func Run(fileService *file.Service, houseService *house.Service, cityService *ci
 func (s *Server) Start() {
 	mux := http.NewServeMux()
 	s.registerRoutes(mux)
-	s.startServer(mux)
+	s.startServer(s.withOverdueTasks(s.withUnreadNotifications(mux)))
 }
 
 // registerRoutes registers all HTTP routes
@@ -50,27 +85,90 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /style.css", static.ServeStyle)
 
 	// Main page
-	mux.HandleFunc("GET /{$}", s.mainPage)
+	mux.HandleFunc("GET /{$}", s.handle(s.mainPage))
+
+	// Export routes
+	mux.HandleFunc("GET /export.csv", s.handle(s.exportCSV))
+	mux.HandleFunc("GET /export.ods", s.handle(s.exportODS))
+
+	// JSON API
+	s.registerAPIRoutes(mux)
+
+	// Import routes
+	mux.HandleFunc("GET /importer", s.handle(s.importPage))
+	mux.HandleFunc("POST /importer/apercu", s.handle(s.importPreview))
+	mux.HandleFunc("POST /importer", s.handle(s.importHouses))
 
 	// House routes
-	mux.HandleFunc("GET /maison/creer", s.createHousePage)
-	mux.HandleFunc("POST /maison/creer", s.createHouse)
-	mux.HandleFunc("GET /maison/{id}", s.housePage)
-	mux.HandleFunc("GET /maison/{id}/modifier", s.modifyHousePage)
-	mux.HandleFunc("POST /maison/{id}/modifier", s.modifyHouse)
-	mux.HandleFunc("POST /maison/{id}/supprimer", s.deleteHouse)
-	mux.HandleFunc("GET /maison/{id}/photos/{filename}", s.housePhoto)
-	mux.HandleFunc("GET /maison/{id}/piecesjointes/{filename}", s.houseAttachment)
+	mux.HandleFunc("GET /maison/creer", s.handle(s.createHousePage))
+	mux.HandleFunc("POST /maison/creer", s.handle(s.createHouse))
+	mux.HandleFunc("GET /maison/{id}", s.handle(s.housePage))
+	mux.HandleFunc("GET /maison/{id}/modifier", s.handle(s.modifyHousePage))
+	mux.HandleFunc("POST /maison/{id}/modifier", s.handle(s.modifyHouse))
+	mux.HandleFunc("POST /maison/{id}/supprimer", s.handle(s.deleteHouse))
+	mux.HandleFunc("GET /maison/{id}/photos/{filename}", s.handle(s.housePhoto))
+	mux.HandleFunc("GET /maison/{id}/piecesjointes/{filename}", s.handle(s.houseAttachment))
+	mux.HandleFunc("GET /maison/{id}/dossier.zip", s.handle(s.houseDossier))
+	mux.HandleFunc("POST /maison/{id}/annonces/verifier", s.handle(s.checkHousePublications))
+	mux.HandleFunc("GET /maison/{id}/fusionner/{otherID}", s.handle(s.mergeHousesPage))
+	mux.HandleFunc("POST /maison/{id}/fusionner/{otherID}", s.handle(s.mergeHouses))
+	mux.HandleFunc("POST /maison/{id}/doublons/{otherID}/ignorer", s.handle(s.dismissDuplicate))
+	mux.HandleFunc("POST /maison/{id}/localiser", s.handle(s.locateHouse))
+
+	// Journal routes
+	mux.HandleFunc("POST /maison/{id}/journal", s.handle(s.addJournalEntry))
+	mux.HandleFunc("POST /maison/{id}/journal/{entryID}/relance-faite", s.handle(s.completeFollowUp))
+	mux.HandleFunc("POST /maison/{id}/journal/{entryID}/supprimer", s.handle(s.deleteJournalEntry))
+
+	// Task routes
+	mux.HandleFunc("GET /taches", s.handle(s.tasksPage))
+	mux.HandleFunc("POST /taches", s.handle(s.createTask))
+	mux.HandleFunc("POST /taches/{id}/terminer", s.handle(s.completeTask))
+	mux.HandleFunc("POST /taches/{id}/rouvrir", s.handle(s.reopenTask))
+	mux.HandleFunc("POST /taches/{id}/supprimer", s.handle(s.deleteTask))
+
+	// Notification routes
+	mux.HandleFunc("GET /notifications", s.handle(s.notificationsPage))
+	mux.HandleFunc("POST /notifications/lues", s.handle(s.markAllNotificationsRead))
+	mux.HandleFunc("POST /notifications/{id}/lue", s.handle(s.markNotificationRead))
 
 	// City routes
-	mux.HandleFunc("GET /villes", s.modifyCitiesPage)
-	mux.HandleFunc("POST /villes", s.modifyCities)
+	mux.HandleFunc("GET /villes", s.handle(s.modifyCitiesPage))
+	mux.HandleFunc("POST /villes", s.handle(s.modifyCities))
+	mux.HandleFunc("GET /villes/recherche", s.searchCities)
+	mux.HandleFunc("POST /villes/communes", s.handle(s.importCommunes))
+	mux.HandleFunc("GET /ville/{id}", s.handle(s.cityPage))
+	mux.HandleFunc("POST /ville/{id}/quartiers", s.handle(s.modifyNeighbourhoods))
+	mux.HandleFunc("GET /ville/{id}/fusionner/{otherID}", s.handle(s.mergeCitiesPage))
+	mux.HandleFunc("POST /ville/{id}/fusionner/{otherID}", s.handle(s.mergeCities))
+
+	// Points of interest routes
+	mux.HandleFunc("GET /lieux", s.handle(s.pointsOfInterestPage))
+	mux.HandleFunc("POST /lieux", s.handle(s.modifyPointsOfInterest))
+	mux.HandleFunc("POST /lieux/adresses", s.handle(s.importAddresses))
+
+	// Map routes
+	mux.HandleFunc("GET /carte", s.handle(s.mapPage))
+	mux.HandleFunc("POST /carte/contours", s.handle(s.modifyBoundaries))
+
+	// Tag routes
+	mux.HandleFunc("GET /etiquettes", s.handle(s.modifyTagsPage))
+	mux.HandleFunc("POST /etiquettes", s.handle(s.modifyTags))
+
+	// Custom field routes
+	mux.HandleFunc("GET /criteres", s.handle(s.modifyCustomFieldsPage))
+	mux.HandleFunc("POST /criteres", s.handle(s.modifyCustomFields))
+
+	// Pages matching no route, other methods on known pages being answered
+	// with "method not allowed" by the mux
+	mux.HandleFunc("GET /", s.handle(s.notFoundPage))
 }
 
 // startServer starts the HTTP server
-func (s *Server) startServer(mux *http.ServeMux) {
-	slog.Info("Starting server", "addr", fmt.Sprintf(":%d", config.Port))
-	if err := http.ListenAndServe(":"+fmt.Sprintf("%d", config.Port), mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+func (s *Server) startServer(handler http.Handler) {
+	addr := fmt.Sprintf(":%d", s.config.Port)
+	slog.Info("Starting server", "addr", addr)
+	if err := http.ListenAndServe(addr, handler); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		slog.Error("Server error", "error", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/core/tag"
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// tagColorPattern matches the colors sent by the color picker
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// modifyTagsPage renders the page for managing tags
+func (s *Server) modifyTagsPage(w http.ResponseWriter, r *http.Request) error {
+	return s.renderTagManagementPage(w, r, http.StatusOK, models.TagFormError{})
+}
+
+// renderTagManagementPage renders the page for managing tags with the given
+// status, showing the error of a form if any
+func (s *Server) renderTagManagementPage(w http.ResponseWriter, r *http.Request, status int, formError models.TagFormError) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	// Get all tags
+	tags, err := s.tagService.ListTags(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	// Render template
+	w.WriteHeader(status)
+	component := web.TagManagementPage(tags, formError, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) modifyTags(w http.ResponseWriter, r *http.Request) error {
+	// Parse form data
+	if err := r.ParseForm(); err != nil {
+		return badRequest("Erreur lors de la soumission du formulaire", fmt.Errorf("failed to parse form: %w", err))
+	}
+
+	// Get action type
+	action := r.FormValue("action")
+	formError := models.TagFormError{Action: action}
+
+	switch action {
+	case "create", "update":
+		// Handle tag creation and modification
+		if action == "update" {
+			tagID, err := parseTagID(r)
+			if err != nil {
+				return err
+			}
+			formError.TagID = tagID
+		}
+
+		formError.Tag, formError.Message = parseTagForm(r)
+		if formError.Message != "" {
+			return s.renderTagManagementPage(w, r, http.StatusBadRequest, formError)
+		}
+
+		var err error
+		if action == "create" {
+			err = s.tagService.CreateTag(r.Context(), formError.Tag.Name, formError.Tag.Color)
+		} else {
+			err = s.tagService.UpdateTag(r.Context(), formError.TagID, formError.Tag.Name, formError.Tag.Color)
+		}
+		if errors.Is(err, tag.ErrTagExists) {
+			formError.Message = "Une étiquette porte déjà ce nom"
+			return s.renderTagManagementPage(w, r, http.StatusConflict, formError)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s tag: %w", action, err)
+		}
+
+	case "delete":
+		// Handle tag deletion
+		tagID, err := parseTagID(r)
+		if err != nil {
+			return err
+		}
+
+		if err := s.tagService.DeleteTag(r.Context(), tagID); err != nil {
+			return internalError("Erreur lors de la suppression de l'étiquette", fmt.Errorf("failed to delete tag: %w", err))
+		}
+
+	default:
+		return badRequest("Action invalide", nil)
+	}
+
+	// Redirect back to tag management page
+	http.Redirect(w, r, "/etiquettes", http.StatusSeeOther)
+	return nil
+}
+
+// parseTagForm parses the tag fields of the form, returning a message if
+// they are invalid
+func parseTagForm(r *http.Request) (models.Tag, string) {
+	tagForm := models.Tag{
+		Name:  strings.TrimSpace(r.FormValue("tag_name")),
+		Color: r.FormValue("tag_color"),
+	}
+	if tagForm.Color == "" {
+		tagForm.Color = models.DefaultTagColor
+	}
+
+	if tagForm.Name == "" {
+		return tagForm, "Le nom de l'étiquette est obligatoire"
+	}
+	if !tagColorPattern.MatchString(tagForm.Color) {
+		tagForm.Color = models.DefaultTagColor
+		return tagForm, "Couleur d'étiquette invalide"
+	}
+
+	return tagForm, ""
+}
+
+// parseTagID parses the tag ID from the form, returning an error if it is missing or invalid
+func parseTagID(r *http.Request) (int64, error) {
+	tagIDStr := r.FormValue("tag_id")
+	if tagIDStr == "" {
+		return 0, badRequest("L'identifiant de l'étiquette est obligatoire", nil)
+	}
+
+	tagID, err := strconv.ParseInt(tagIDStr, 10, 64)
+	if err != nil {
+		return 0, badRequest("Identifiant d'étiquette invalide", fmt.Errorf("invalid tag ID: %w", err))
+	}
+
+	return tagID, nil
+}
+
+// parseTagIDs parses a list of tag IDs, ignoring empty values
+func parseTagIDs(values []string) ([]int64, error) {
+	var ids []int64
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
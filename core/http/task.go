@@ -0,0 +1,147 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+	"github.com/willoma/recherche-maison/web"
+)
+
+// tasksPage renders the page listing all tasks
+func (s *Server) tasksPage(w http.ResponseWriter, r *http.Request) error {
+	houses, err := s.houseService.ListHouses(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get houses: %w", err)
+	}
+
+	tasks, err := s.taskService.ListTasks(r.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Render template
+	component := web.TasksPage(tasks, houses)
+	return component.Render(r.Context(), w)
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) error {
+	// Parse form data
+	task, err, errMsg := parseTaskForm(r)
+	if err != nil {
+		return badRequest(errMsg, fmt.Errorf("failed to parse task form: %w", err))
+	}
+
+	if err := s.taskService.CreateTask(r.Context(), task); err != nil {
+		return internalError("Erreur lors de la création de la tâche", fmt.Errorf("failed to create task: %w", err))
+	}
+
+	// Redirect back to the page the task was created from
+	http.Redirect(w, r, redirectTarget(r, "/taches"), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) completeTask(w http.ResponseWriter, r *http.Request) error {
+	return s.setTaskDone(w, r, true)
+}
+
+func (s *Server) reopenTask(w http.ResponseWriter, r *http.Request) error {
+	return s.setTaskDone(w, r, false)
+}
+
+func (s *Server) setTaskDone(w http.ResponseWriter, r *http.Request, done bool) error {
+	// Get task ID from URL path
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de tâche invalide", fmt.Errorf("invalid task ID: %w", err))
+	}
+
+	if err := s.taskService.SetTaskDone(r.Context(), id, done); err != nil {
+		return internalError("Erreur lors de la mise à jour de la tâche", fmt.Errorf("failed to update task: %w", err))
+	}
+
+	// Redirect back to the page the task was updated from
+	http.Redirect(w, r, redirectTarget(r, "/taches"), http.StatusSeeOther)
+	return nil
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) error {
+	// Get task ID from URL path
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return badRequest("Identifiant de tâche invalide", fmt.Errorf("invalid task ID: %w", err))
+	}
+
+	if err := s.taskService.DeleteTask(r.Context(), id); err != nil {
+		return internalError("Erreur lors de la suppression de la tâche", fmt.Errorf("failed to delete task: %w", err))
+	}
+
+	// Redirect back to the page the task was deleted from
+	http.Redirect(w, r, redirectTarget(r, "/taches"), http.StatusSeeOther)
+	return nil
+}
+
+// withOverdueTasks makes the overdue tasks count available to the layout,
+// counted on each page so that it follows the deleted and merged houses
+func (s *Server) withOverdueTasks(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only pages display the count
+		if r.Method != http.MethodGet || strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		count, err := s.taskService.OverdueCount(r.Context())
+		if err != nil {
+			slog.Error("Failed to count overdue tasks", "error", err)
+		}
+		ctx := web.WithOverdueTasks(r.Context(), count)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseTaskForm parses the form data for task creation
+// Returns the parsed task, an error if parsing fails, and a translated error message
+func parseTaskForm(r *http.Request) (models.Task, error, string) {
+	var task models.Task
+	var err error
+
+	if err := r.ParseForm(); err != nil {
+		return task, err, "Erreur lors de la soumission du formulaire"
+	}
+
+	// Parse title
+	task.Title = r.FormValue("title")
+	if task.Title == "" {
+		return task, fmt.Errorf("title is required"), "Le titre est obligatoire"
+	}
+
+	// Parse house ID
+	houseIDStr := r.FormValue("house_id")
+	if houseIDStr == "" {
+		return task, fmt.Errorf("house ID is required"), "La maison est obligatoire"
+	}
+	task.HouseID, err = strconv.ParseInt(houseIDStr, 10, 64)
+	if err != nil {
+		return task, fmt.Errorf("invalid house ID: %w", err), "Identifiant de maison invalide"
+	}
+
+	// Parse due date (optional)
+	if dueDateStr := r.FormValue("due_date"); dueDateStr != "" {
+		task.DueDate, err = time.Parse("2006-01-02", dueDateStr)
+		if err != nil {
+			return task, fmt.Errorf("invalid due date: %w", err), "Date d'échéance invalide"
+		}
+	}
+
+	// Parse assignee (optional)
+	task.Assignee = r.FormValue("assignee")
+
+	return task, nil, ""
+}
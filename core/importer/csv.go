@@ -0,0 +1,378 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrInvalidCSV is returned when a CSV file contains errors and cannot be imported
+var ErrInvalidCSV = errors.New("invalid CSV file")
+
+// ReadCSV reads the headers and the records of a CSV file. The separator is
+// detected among semicolons, commas and tabs, and a UTF-8 BOM is ignored.
+func ReadCSV(data []byte) ([]string, [][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\uFEFF"))
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	separator := ';'
+	count := bytes.Count(firstLine, []byte(";"))
+	for _, candidate := range []rune{',', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(candidate))); n > count {
+			separator, count = candidate, n
+		}
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = separator
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%w: empty file", ErrInvalidCSV)
+	}
+
+	return records[0], records[1:], nil
+}
+
+// GuessCSVMapping maps the headers of a CSV file to the house fields with a
+// matching name. Each field is mapped to one column at most.
+func GuessCSVMapping(headers []string) []string {
+	mapping := make([]string, len(headers))
+	used := map[string]bool{}
+
+	for i, header := range headers {
+		normalized := normalizeHeader(header)
+		for _, column := range models.CSVImportColumns {
+			if used[column.Name] {
+				continue
+			}
+			for _, alias := range column.Aliases {
+				if normalized == alias {
+					mapping[i] = column.Name
+					used[column.Name] = true
+					break
+				}
+			}
+			if mapping[i] != "" {
+				break
+			}
+		}
+	}
+
+	return mapping
+}
+
+// normalizeHeader lowercases a header, removes its accents, the parts
+// between parentheses and everything that is not a letter or a digit
+func normalizeHeader(header string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(header) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth > 0:
+		default:
+			r = removeAccent(r)
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+func removeAccent(r rune) rune {
+	switch r {
+	case 'à', 'â', 'ä':
+		return 'a'
+	case 'é', 'è', 'ê', 'ë':
+		return 'e'
+	case 'î', 'ï':
+		return 'i'
+	case 'ô', 'ö':
+		return 'o'
+	case 'ù', 'û', 'ü':
+		return 'u'
+	case 'ç':
+		return 'c'
+	default:
+		return r
+	}
+}
+
+// PreviewCSV analyses a CSV file with the given column mapping, or with the
+// guessed one if mapping is nil, and returns the houses it contains with
+// the validation errors of each row
+func PreviewCSV(data []byte, mapping []string) (models.CSVImportPreview, error) {
+	headers, records, err := ReadCSV(data)
+	if err != nil {
+		return models.CSVImportPreview{}, err
+	}
+
+	if mapping == nil {
+		mapping = GuessCSVMapping(headers)
+	}
+	// Columns without a mapping are ignored
+	mapping = append(mapping, make([]string, max(0, len(headers)-len(mapping)))...)[:len(headers)]
+
+	preview := models.CSVImportPreview{
+		Headers: headers,
+		Mapping: mapping,
+	}
+
+	mapped := map[string]bool{}
+	for _, name := range mapping {
+		if name == "" {
+			continue
+		}
+		if mapped[name] {
+			preview.Errors = append(preview.Errors, fmt.Sprintf("Le champ « %s » est associé à plusieurs colonnes.", csvColumnLabel(name)))
+		}
+		mapped[name] = true
+	}
+	for _, column := range models.CSVImportColumns {
+		if column.Required && !mapped[column.Name] {
+			preview.Errors = append(preview.Errors, fmt.Sprintf("Aucune colonne n'est associée au champ obligatoire « %s ».", column.Label))
+		}
+	}
+
+	for i, record := range records {
+		if isEmptyRecord(record) {
+			continue
+		}
+		preview.Rows = append(preview.Rows, parseCSVRow(i+2, record, mapping))
+	}
+
+	if len(preview.Rows) == 0 {
+		preview.Errors = append(preview.Errors, "Le fichier ne contient aucune maison.")
+	}
+
+	return preview, nil
+}
+
+// ImportCSV imports the houses of a CSV file in a single transaction, the
+// missing cities being created. If the file contains errors, nothing is
+// imported and the preview showing them is returned with ErrInvalidCSV.
+func (s *Service) ImportCSV(ctx context.Context, data []byte, mapping []string) (models.CSVImportPreview, error) {
+	preview, err := PreviewCSV(data, mapping)
+	if err != nil {
+		return preview, err
+	}
+	if preview.HasErrors() {
+		return preview, ErrInvalidCSV
+	}
+
+	houses := make([]models.House, len(preview.Rows))
+	publicationURLs := make([][]models.PublicationURL, len(preview.Rows))
+	for i, row := range preview.Rows {
+		houses[i] = row.House
+		publicationURLs[i] = row.PublicationURLs
+	}
+
+	if _, err := s.houseService.ImportHouses(ctx, s.cityService, houses, publicationURLs); err != nil {
+		return preview, err
+	}
+
+	return preview, nil
+}
+
+func csvColumnLabel(name string) string {
+	for _, column := range models.CSVImportColumns {
+		if column.Name == name {
+			return column.Label
+		}
+	}
+	return name
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCSVRow builds the house described by a CSV record
+func parseCSVRow(line int, record []string, mapping []string) models.CSVImportRow {
+	row := models.CSVImportRow{
+		Line:   line,
+		Values: make([]string, len(mapping)),
+		Errors: map[int]string{},
+	}
+	copy(row.Values, record)
+
+	h := &row.House
+	var urls []string
+	urlsColumn := -1
+	publicationDate := today()
+
+	for i, name := range mapping {
+		value := strings.TrimSpace(row.Values[i])
+
+		var err error
+		switch name {
+		case models.CSVImportTitle:
+			h.Title = value
+		case models.CSVImportCity:
+			h.CityName = value
+		case models.CSVImportAddress:
+			h.Address = value
+		case models.CSVImportPrice:
+			h.Price, err = parseInteger(value)
+		case models.CSVImportSurface:
+			h.Surface, err = parseInteger(value)
+		case models.CSVImportRooms:
+			h.Rooms, err = parseInteger(value)
+		case models.CSVImportBedrooms:
+			h.Bedrooms, err = parseInteger(value)
+		case models.CSVImportBathrooms:
+			h.Bathrooms, err = parseInteger(value)
+		case models.CSVImportFloors:
+			h.Floors, err = parseInteger(value)
+		case models.CSVImportConstructionYear:
+			h.ConstructionYear, err = parseInteger(value)
+		case models.CSVImportHouseType:
+			h.HouseType = strings.ToLower(value)
+			if h.HouseType != "" && !models.IsValidHouseType(h.HouseType) {
+				err = fmt.Errorf("Type de maison inconnu : %s (maison ou appartement)", value)
+			}
+		case models.CSVImportLandSurface:
+			h.LandSurface, err = parseInteger(value)
+		case models.CSVImportHasGarage:
+			h.HasGarage, err = parseBoolean(value)
+		case models.CSVImportOutdoorParkingSpaces:
+			h.OutdoorParkingSpaces, err = parseInteger(value)
+		case models.CSVImportNotes:
+			h.Notes = row.Values[i]
+		case models.CSVImportPublicationURLs:
+			urls, urlsColumn = strings.Fields(value), i
+			for _, url := range urls {
+				if !models.IsValidPublicationURL(url) {
+					err = fmt.Errorf("URL invalide : %s", url)
+					break
+				}
+			}
+		case models.CSVImportPublicationDate:
+			if value != "" {
+				publicationDate, err = parseDate(value)
+			}
+		}
+
+		if err != nil {
+			row.Errors[i] = err.Error()
+		}
+	}
+
+	// The house form rules apply to imported houses too, their errors being
+	// shown against the mapped columns. Fields checked by these rules and left
+	// unmapped are required columns, reported for the whole file.
+	for field, message := range h.Validate() {
+		for i, name := range mapping {
+			if name == field && row.Errors[i] == "" {
+				row.Errors[i] = message
+			}
+		}
+	}
+
+	if urlsColumn >= 0 && row.Errors[urlsColumn] == "" {
+		for _, url := range urls {
+			row.PublicationURLs = append(row.PublicationURLs, models.PublicationURL{
+				URL:             url,
+				PublicationDate: publicationDate,
+			})
+		}
+	}
+
+	return row
+}
+
+// thousandsPattern matches integers whose groups of three digits are
+// separated by dots or spaces
+var thousandsPattern = regexp.MustCompile(`^-?\d{1,3}([. ]\d{3})+$`)
+
+// parseInteger parses an integer written in the french way, possibly with
+// decimals after a comma, a unit, or thousands separators: a dot or a space
+// followed by groups of exactly three digits. Other dots are decimal points,
+// and values that fit neither reading are rejected as ambiguous.
+func parseInteger(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	cleaned := strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r == '-', r == ',', r == '.':
+			return r
+		case unicode.IsSpace(r):
+			return ' '
+		default:
+			return -1
+		}
+	}, strings.TrimSuffix(strings.TrimSuffix(value, "m²"), "m2")))
+
+	integer, decimals, hasComma := strings.Cut(cleaned, ",")
+	switch {
+	case thousandsPattern.MatchString(integer):
+		integer = strings.NewReplacer(".", "", " ", "").Replace(integer)
+	case hasComma && strings.ContainsAny(integer, ". "),
+		!hasComma && strings.Contains(integer, " "):
+		return 0, fmt.Errorf("Nombre invalide : %s", value)
+	}
+
+	digits := integer
+	if hasComma {
+		digits += "." + decimals
+	}
+	number, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Nombre invalide : %s", value)
+	}
+	return int64(math.Round(number)), nil
+}
+
+// parseBoolean parses yes/no values, in french or in english
+func parseBoolean(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "non", "n", "no", "false", "faux", "0":
+		return false, nil
+	case "oui", "o", "yes", "y", "true", "vrai", "1", "x":
+		return true, nil
+	default:
+		return false, fmt.Errorf("Valeur invalide, « oui » ou « non » attendu : %s", value)
+	}
+}
+
+// parseDate parses a date in the french format or in the ISO format
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{"02/01/2006", "2/1/2006", "2006-01-02", "02/01/2006 15:04"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Date invalide : %s", value)
+}
+
+// today returns the current date, at midnight UTC
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
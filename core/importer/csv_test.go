@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+func TestPreviewCSVValidatesRows(t *testing.T) {
+	data := []byte("Titre;Ville;Prix;Surface;Pièces;Chambres;Niveaux;Type;URL\n" +
+		"Maison de ville;Brest;250000;90;4;2;1;maison;https://example.com/annonce\n" +
+		"Sans type;Brest;250000;90;4;2;1;;\n" +
+		"Trop de chambres;Brest;250000;90;3;5;0;maison;ftp://example.com/annonce\n")
+
+	preview, err := PreviewCSV(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Errors) != 0 {
+		t.Fatalf("unexpected file errors: %v", preview.Errors)
+	}
+	if len(preview.Rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(preview.Rows))
+	}
+
+	if row := preview.Rows[0]; row.HasErrors() {
+		t.Errorf("valid row refused: %v", row.Errors)
+	}
+
+	wantErrors := []map[int]string{
+		1: {7: "Choisissez le type de maison"},
+		2: {
+			5: "Le nombre de chambres ne peut pas dépasser le nombre de pièces",
+			6: "La maison doit avoir au moins un niveau",
+			8: "URL invalide : ftp://example.com/annonce",
+		},
+	}
+	for i := 1; i < len(preview.Rows); i++ {
+		row := preview.Rows[i]
+		if len(row.Errors) != len(wantErrors[i]) {
+			t.Errorf("line %d: got errors %v, want %v", row.Line, row.Errors, wantErrors[i])
+			continue
+		}
+		for column, message := range wantErrors[i] {
+			if row.Errors[column] != message {
+				t.Errorf("line %d, column %d: got error %q, want %q", row.Line, column, row.Errors[column], message)
+			}
+		}
+	}
+}
+
+func TestPreviewCSVRequiresHouseFormColumns(t *testing.T) {
+	preview, err := PreviewCSV([]byte("Titre;Ville;Prix;Surface\nCSV house;Brest;250000;90\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.HasErrors() {
+		t.Fatal("file without rooms, floors and type columns accepted")
+	}
+
+	missing := map[string]bool{}
+	for _, column := range models.CSVImportColumns {
+		if column.Required {
+			missing[column.Label] = true
+		}
+	}
+	for _, name := range []string{"Titre", "Ville", "Prix (€)", "Surface (m²)"} {
+		delete(missing, name)
+	}
+	if len(preview.Errors) != len(missing) {
+		t.Errorf("got file errors %v, want one per missing column among %v", preview.Errors, missing)
+	}
+}
+
+func TestParseInteger(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		want  int64
+	}{
+		{"", 0},
+		{"250000", 250000},
+		{"250.000", 250000},
+		{"250 000", 250000},
+		{"250 000 €", 250000},
+		{"1.250.000", 1250000},
+		{"250000,50", 250001},
+		{"1 250,4", 1250},
+		{"90 m²", 90},
+		{"90,5m2", 91},
+		{"90.4", 90},
+		{"-3", -3},
+	} {
+		got, err := parseInteger(tc.value)
+		if err != nil {
+			t.Errorf("parseInteger(%q): %v", tc.value, err)
+		} else if got != tc.want {
+			t.Errorf("parseInteger(%q) = %d, want %d", tc.value, got, tc.want)
+		}
+	}
+
+	for _, value := range []string{"abc", "25 00", "2.50.000", "1.250,000.5", "250.00 0"} {
+		if got, err := parseInteger(value); err == nil {
+			t.Errorf("parseInteger(%q) = %d, want an error", value, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/willoma/recherche-maison/core/city"
+	"github.com/willoma/recherche-maison/core/customfield"
+	"github.com/willoma/recherche-maison/core/export"
+	"github.com/willoma/recherche-maison/core/house"
+	"github.com/willoma/recherche-maison/core/tag"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Service provides methods for importing houses
+type Service struct {
+	houseService       *house.Service
+	cityService        *city.Service
+	tagService         *tag.Service
+	customFieldService *customfield.Service
+}
+
+// NewService creates a new import service
+func NewService(houseService *house.Service, cityService *city.Service, tagService *tag.Service, customFieldService *customfield.Service) *Service {
+	return &Service{
+		houseService:       houseService,
+		cityService:        cityService,
+		tagService:         tagService,
+		customFieldService: customFieldService,
+	}
+}
+
+// ImportJSON creates the houses of a JSON dump written by the export service,
+// creating the missing cities and tags. Values of unknown custom fields are
+// ignored. It returns the number of houses created.
+func (s *Service) ImportJSON(ctx context.Context, r io.Reader) (int, error) {
+	var dump export.Dump
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return 0, fmt.Errorf("failed to read JSON dump: %w", err)
+	}
+	if dump.Version != export.DumpVersion {
+		return 0, fmt.Errorf("unsupported JSON dump version %d", dump.Version)
+	}
+
+	fields, err := s.customFieldService.ListFields(ctx)
+	if err != nil {
+		return 0, err
+	}
+	fieldIDs := make(map[string]int64, len(fields))
+	for _, field := range fields {
+		fieldIDs[field.Name] = field.ID
+	}
+
+	for i, dumpHouse := range dump.Houses {
+		cityID, err := s.cityService.FindOrCreateCity(ctx, dumpHouse.City, dumpHouse.CityPostalCode)
+		if err != nil {
+			return i, err
+		}
+
+		var neighbourhoodID int64
+		if dumpHouse.Neighbourhood != "" {
+			neighbourhoodID, err = s.cityService.FindOrCreateNeighbourhood(ctx, cityID, dumpHouse.Neighbourhood)
+			if err != nil {
+				return i, err
+			}
+		}
+
+		h := models.House{
+			Title:                dumpHouse.Title,
+			CityID:               cityID,
+			NeighbourhoodID:      neighbourhoodID,
+			Address:              dumpHouse.Address,
+			Latitude:             dumpHouse.Latitude,
+			Longitude:            dumpHouse.Longitude,
+			Price:                dumpHouse.Price,
+			Surface:              dumpHouse.Surface,
+			Rooms:                dumpHouse.Rooms,
+			Bedrooms:             dumpHouse.Bedrooms,
+			Bathrooms:            dumpHouse.Bathrooms,
+			Floors:               dumpHouse.Floors,
+			ConstructionYear:     dumpHouse.ConstructionYear,
+			HouseType:            dumpHouse.HouseType,
+			LandSurface:          dumpHouse.LandSurface,
+			HasGarage:            dumpHouse.HasGarage,
+			OutdoorParkingSpaces: dumpHouse.OutdoorParkingSpaces,
+			Notes:                dumpHouse.Notes,
+			CustomValues:         map[int64]string{},
+		}
+
+		for _, tagName := range dumpHouse.Tags {
+			tag, err := s.tag(ctx, tagName)
+			if err != nil {
+				return i, err
+			}
+			h.Tags = append(h.Tags, tag)
+		}
+
+		for name, value := range dumpHouse.CustomFields {
+			if fieldID, ok := fieldIDs[name]; ok {
+				h.CustomValues[fieldID] = value
+			}
+		}
+
+		houseID, err := s.houseService.CreateHouse(ctx, h)
+		if err != nil {
+			return i, fmt.Errorf("failed to import house %q: %w", dumpHouse.Title, err)
+		}
+
+		for _, pub := range dumpHouse.Publications {
+			date, err := house.ParsePublicationDate(pub.PublicationDate)
+			if err != nil {
+				return i + 1, fmt.Errorf("invalid publication date %q for house %q: %w", pub.PublicationDate, dumpHouse.Title, err)
+			}
+			if _, err := s.houseService.AddPublicationURL(ctx, houseID, pub.URL, date); err != nil {
+				return i + 1, err
+			}
+		}
+	}
+
+	return len(dump.Houses), nil
+}
+
+// tag returns the tag with the given name, creating it if needed
+func (s *Service) tag(ctx context.Context, name string) (models.Tag, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		tags, err := s.tagService.ListTags(ctx)
+		if err != nil {
+			return models.Tag{}, err
+		}
+		for _, t := range tags {
+			if t.Name == name {
+				return t, nil
+			}
+		}
+		if attempt == 0 {
+			if err := s.tagService.CreateTag(ctx, name, models.DefaultTagColor); err != nil {
+				return models.Tag{}, err
+			}
+		}
+	}
+	return models.Tag{}, fmt.Errorf("tag %q not found after its creation", name)
+}
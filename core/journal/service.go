@@ -0,0 +1,151 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrEntryNotFound is returned when a journal entry does not exist for the house
+var ErrEntryNotFound = errors.New("journal entry not found")
+
+// Service provides methods for managing the communication log of houses
+type Service struct {
+	queries *db.Queries
+}
+
+// NewService creates a new journal service
+func NewService(queries *db.Queries) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
+
+// ListEntries retrieves all journal entries for a house, most recent first
+func (s *Service) ListEntries(ctx context.Context, houseID int64) ([]models.JournalEntry, error) {
+	entries, err := s.queries.ListJournalEntries(ctx, houseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list journal entries: %w", err)
+	}
+	return models.FromDBJournalEntries(entries), nil
+}
+
+// ListDueFollowUps retrieves all pending follow-ups planned for today or earlier
+func (s *Service) ListDueFollowUps(ctx context.Context) ([]models.JournalEntry, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	entries, err := s.queries.ListDueFollowUps(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due follow-ups: %w", err)
+	}
+	return models.FromDBJournalEntries(entries), nil
+}
+
+// AddEntry adds a new journal entry for a house
+func (s *Service) AddEntry(ctx context.Context, entry models.JournalEntry) error {
+	if err := s.queries.CreateJournalEntry(ctx, db.CreateJournalEntryParams{
+		HouseID:      entry.HouseID,
+		EntryType:    entry.EntryType,
+		EntryDate:    entry.EntryDate,
+		Contact:      entry.Contact,
+		Summary:      entry.Summary,
+		FollowUpDate: entry.FollowUpDate,
+	}); err != nil {
+		return fmt.Errorf("failed to add journal entry: %w", err)
+	}
+	return nil
+}
+
+// CompleteFollowUp marks the follow-up of a journal entry of a house as done
+func (s *Service) CompleteFollowUp(ctx context.Context, houseID, id int64) error {
+	rows, err := s.queries.CompleteFollowUp(ctx, id, houseID)
+	if err != nil {
+		return fmt.Errorf("failed to complete follow-up: %w", err)
+	}
+	if rows == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// DeleteEntry deletes a journal entry of a house
+func (s *Service) DeleteEntry(ctx context.Context, houseID, id int64) error {
+	rows, err := s.queries.DeleteJournalEntry(ctx, id, houseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete journal entry: %w", err)
+	}
+	if rows == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// GetTimeline builds the history of a house, merging its journal entries
+// with the other dated events known about it, including the changes detected
+// on its publications, most recent first
+func (s *Service) GetTimeline(ctx context.Context, house models.House, publicationURLs []models.PublicationURL) ([]models.TimelineEvent, error) {
+	entries, err := s.ListEntries(ctx, house.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbNotifications, err := s.queries.ListHouseNotifications(ctx, house.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list house notifications: %w", err)
+	}
+	notifications := models.FromDBNotifications(dbNotifications)
+
+	events := make([]models.TimelineEvent, 0, len(entries)+len(publicationURLs)+len(notifications)+1)
+
+	events = append(events, models.TimelineEvent{
+		Date:  house.CreatedAt,
+		Kind:  models.TimelineEventCreated,
+		Title: "Maison ajoutée",
+	})
+
+	for _, pub := range publicationURLs {
+		events = append(events, models.TimelineEvent{
+			Date:  pub.PublicationDate,
+			Kind:  models.TimelineEventPublication,
+			Title: "Annonce publiée",
+			URL:   pub.URL,
+		})
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		title := models.JournalEntryTypeLabel(entry.EntryType)
+		if entry.Contact != "" {
+			title += " - " + entry.Contact
+		}
+		events = append(events, models.TimelineEvent{
+			Date:    entry.EntryDate,
+			Kind:    models.TimelineEventJournal,
+			Title:   title,
+			Details: entry.Summary,
+			Entry:   entry,
+		})
+	}
+
+	for i := range notifications {
+		notification := &notifications[i]
+		events = append(events, models.TimelineEvent{
+			Date:         notification.CreatedAt,
+			Kind:         models.TimelineEventChange,
+			URL:          notification.URL,
+			Notification: notification,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.After(events[j].Date)
+	})
+
+	return events, nil
+}
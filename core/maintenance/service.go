@@ -0,0 +1,116 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Service provides methods for checking and optimizing the stored data
+type Service struct {
+	queries    *db.Queries
+	db         *sql.DB
+	uploadsDir string
+}
+
+// NewService creates a new maintenance service
+func NewService(queries *db.Queries, dbConn *sql.DB, uploadsDir string) *Service {
+	return &Service{
+		queries:    queries,
+		db:         dbConn,
+		uploadsDir: uploadsDir,
+	}
+}
+
+// Check verifies the integrity of the database and its consistency with the
+// uploads directory, and returns the list of problems found
+func (s *Service) Check(ctx context.Context) ([]string, error) {
+	var problems []string
+
+	// Database integrity
+	rows, err := s.db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check database integrity: %w", err)
+	}
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to read database integrity: %w", err)
+		}
+		if result != "ok" {
+			problems = append(problems, "base de données : "+result)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to check database integrity: %w", err)
+	}
+
+	// Foreign keys
+	rows, err = s.db.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	for rows.Next() {
+		var table, parent string
+		var rowID sql.NullInt64
+		var fkID int64
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to read foreign key check: %w", err)
+		}
+		problems = append(problems, fmt.Sprintf("base de données : la ligne %d de %s référence une entrée absente de %s", rowID.Int64, table, parent))
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+
+	// Consistency between houses and uploads
+	houses, err := s.queries.ListHouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list houses: %w", err)
+	}
+
+	known := make(map[string]bool, len(houses))
+	for _, house := range houses {
+		dir := strconv.FormatInt(house.ID, 10)
+		known[dir] = true
+
+		for _, sub := range []string{"photos", "attachments"} {
+			if info, err := os.Stat(filepath.Join(s.uploadsDir, dir, sub)); err != nil || !info.IsDir() {
+				problems = append(problems, fmt.Sprintf("maison %d (%s) : le répertoire %s est absent", house.ID, house.Title, filepath.Join(dir, sub)))
+			}
+		}
+
+		if house.MainPhoto != "" {
+			if _, err := os.Stat(filepath.Join(s.uploadsDir, dir, "photos", house.MainPhoto)); err != nil {
+				problems = append(problems, fmt.Sprintf("maison %d (%s) : la photo principale %s est absente", house.ID, house.Title, house.MainPhoto))
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(s.uploadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploads directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !known[entry.Name()] {
+			problems = append(problems, fmt.Sprintf("uploads : %s ne correspond à aucune maison", entry.Name()))
+		}
+	}
+
+	return problems, nil
+}
+
+// Vacuum rebuilds the database file, reclaiming unused space
+func (s *Service) Vacuum(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
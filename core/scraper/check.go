@@ -0,0 +1,75 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// searchWords are found in the query strings of the search pages portals
+// redirect to when an ad has been removed, or as the last part of their paths
+var searchWords = []string{"recherche", "search", "liste", "resultats", "annonces"}
+
+// Check fetches the page of a publication and tells whether the ad is still
+// online and, when possible, its current price. The returned check carries
+// an error message when the page could not be fetched.
+func (s *Service) Check(ctx context.Context, rawURL string) models.PublicationCheck {
+	check := models.PublicationCheck{URL: rawURL}
+
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		check.Error = ErrInvalidURL.Error()
+		return check
+	}
+
+	resp, err := s.get(ctx, u.String(), maxPageSize)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.StatusCode = int64(resp.statusCode)
+	check.FinalURL = resp.url.String()
+
+	switch {
+	case resp.statusCode == http.StatusNotFound || resp.statusCode == http.StatusGone:
+		check.Removed = true
+	case resp.statusCode != http.StatusOK:
+		check.Error = "unexpected status " + resp.status
+	case isSearchRedirect(u, resp.url):
+		check.Removed = true
+	default:
+		check.Price = Parse(resp.url, resp.body).Price
+	}
+
+	return check
+}
+
+// isSearchRedirect reports whether a publication URL has been redirected to
+// the home page, a parent page or a search page, instead of the ad. Other
+// redirections, for instance to the canonical URL of the ad, whose path may
+// well contain one of the search words, keep the ad online.
+func isSearchRedirect(original, final *url.URL) bool {
+	originalPath := strings.TrimSuffix(original.Path, "/")
+	finalPath := strings.TrimSuffix(final.Path, "/")
+	if strings.EqualFold(finalPath, originalPath) {
+		return false
+	}
+
+	if finalPath == "" || strings.HasPrefix(originalPath, finalPath+"/") {
+		return true
+	}
+
+	query := strings.ToLower(final.RawQuery)
+	last := strings.ToLower(path.Base(finalPath))
+	last = strings.TrimSuffix(last, path.Ext(last))
+	for _, word := range searchWords {
+		if strings.Contains(query, word) || last == word {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// adPage is the page of an ad whose price is announced in its meta elements
+const adPage = `<html><head><title>Maison</title><meta property="product:price:amount" content="250000"></head></html>`
+
+func TestIsSearchRedirect(t *testing.T) {
+	tests := []struct {
+		original string
+		final    string
+		want     bool
+	}{
+		{"https://portail.example/annonce/123", "https://portail.example/annonce/123", false},
+		{"https://portail.example/annonce/123", "https://portail.example/annonce/123/", false},
+		{"https://portail.example/annonce/123", "https://portail.example/", true},
+		{"https://portail.example/annonce/123", "https://portail.example", true},
+		{"https://portail.example/vente/maison/123", "https://portail.example/vente/maison", true},
+		{"https://portail.example/annonce/123", "https://portail.example/recherche", true},
+		{"https://portail.example/annonce/123", "https://portail.example/annonces/", true},
+		{"https://portail.example/annonce/123", "https://portail.example/vente/resultats.html", true},
+		{"https://portail.example/annonce/123", "https://portail.example/list.htm?search=maison", true},
+		{"https://portail.example/annonce/123", "https://portail.example/vente?type=liste", true},
+		// Canonical URLs of the ad, whose paths contain search words
+		{"https://portail.example/annonce/123", "https://portail.example/annonces/achat/maison-123.htm", false},
+		{"https://portail.example/a/123", "https://portail.example/liste-des-biens/maison-castres/123", false},
+		{"https://portail.example/annonce/123", "https://www.portail.example/recherche-maison/123", false},
+	}
+
+	for _, tt := range tests {
+		original, _ := url.Parse(tt.original)
+		final, _ := url.Parse(tt.final)
+		if got := isSearchRedirect(original, final); got != tt.want {
+			t.Errorf("isSearchRedirect(%q, %q) = %v, want %v", tt.original, tt.final, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><title>Accueil</title></html>"))
+	})
+	mux.HandleFunc("GET /recherche", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><title>Résultats</title></html>"))
+	})
+	mux.HandleFunc("GET /annonces/achat/maison-123.htm", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(adPage))
+	})
+	mux.HandleFunc("GET /annonce/online", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(adPage))
+	})
+	mux.HandleFunc("GET /annonce/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	mux.HandleFunc("GET /annonce/to-search", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/recherche?ville=castres", http.StatusFound)
+	})
+	mux.HandleFunc("GET /annonce/to-home", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+	mux.HandleFunc("GET /annonce/123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/annonces/achat/maison-123.htm", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("GET /annonce/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "error", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := newTestService(t, server)
+
+	tests := []struct {
+		path       string
+		statusCode int64
+		finalPath  string
+		removed    bool
+		price      int64
+		failed     bool
+	}{
+		{path: "/annonce/online", statusCode: 200, finalPath: "/annonce/online", price: 250000},
+		{path: "/annonce/missing", statusCode: 404, finalPath: "/annonce/missing", removed: true},
+		{path: "/annonce/gone", statusCode: 410, finalPath: "/annonce/gone", removed: true},
+		{path: "/annonce/to-search", statusCode: 200, finalPath: "/recherche", removed: true},
+		{path: "/annonce/to-home", statusCode: 200, finalPath: "/", removed: true},
+		{path: "/annonce/123", statusCode: 200, finalPath: "/annonces/achat/maison-123.htm", price: 250000},
+		{path: "/annonce/error", statusCode: 500, finalPath: "/annonce/error", failed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			check := s.Check(context.Background(), server.URL+tt.path)
+
+			if check.URL != server.URL+tt.path {
+				t.Errorf("URL = %q, want %q", check.URL, server.URL+tt.path)
+			}
+			if check.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %d, want %d", check.StatusCode, tt.statusCode)
+			}
+			final, err := url.Parse(check.FinalURL)
+			if err != nil || final.Path != tt.finalPath {
+				t.Errorf("FinalURL = %q, want path %q", check.FinalURL, tt.finalPath)
+			}
+			if check.Removed != tt.removed {
+				t.Errorf("Removed = %v, want %v", check.Removed, tt.removed)
+			}
+			if check.Price != tt.price {
+				t.Errorf("Price = %d, want %d", check.Price, tt.price)
+			}
+			if check.Failed() != tt.failed {
+				t.Errorf("Failed() = %v, want %v (error %q)", check.Failed(), tt.failed, check.Error)
+			}
+		})
+	}
+
+	t.Run("invalid URL", func(t *testing.T) {
+		check := s.Check(context.Background(), "mailto:agence@portail.example")
+		if check.Error != ErrInvalidURL.Error() {
+			t.Errorf("Error = %q, want %q", check.Error, ErrInvalidURL.Error())
+		}
+	})
+}
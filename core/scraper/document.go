@@ -0,0 +1,211 @@
+package scraper
+
+import (
+	"html"
+	"strings"
+)
+
+// document holds the parts of an HTML page that carry structured data. The
+// page is scanned for the few elements we need rather than fully parsed.
+type document struct {
+	title   string
+	metas   map[string][]string // Contents of the meta elements, by lowercased property or name
+	scripts []script
+}
+
+// script is a script element of a page
+type script struct {
+	typ     string
+	id      string
+	content string
+}
+
+// meta returns the first content of the meta elements with the given property or name
+func (d document) meta(key string) string {
+	if values := d.metas[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// scriptsOfType returns the contents of the script elements with the given type
+func (d document) scriptsOfType(typ string) []string {
+	var contents []string
+	for _, s := range d.scripts {
+		if s.typ == typ {
+			contents = append(contents, s.content)
+		}
+	}
+	return contents
+}
+
+// scriptByID returns the content of the script element with the given id
+func (d document) scriptByID(id string) string {
+	for _, s := range d.scripts {
+		if s.id == id {
+			return s.content
+		}
+	}
+	return ""
+}
+
+// parseDocument scans an HTML page for its title, meta and script elements
+func parseDocument(page string) document {
+	doc := document{metas: map[string][]string{}}
+	lower := asciiLower(page)
+
+	i := 0
+	for {
+		start := strings.IndexByte(page[i:], '<')
+		if start < 0 {
+			break
+		}
+		pos := i + start
+
+		// Comments may contain anything, including tags
+		if strings.HasPrefix(page[pos:], "<!--") {
+			end := strings.Index(page[pos+4:], "-->")
+			if end < 0 {
+				break
+			}
+			i = pos + 4 + end + 3
+			continue
+		}
+
+		name, attrs, next := parseTag(page, pos+1)
+		if name == "" {
+			i = pos + 1
+			continue
+		}
+
+		switch name {
+		case "meta":
+			key := attrs["property"]
+			if key == "" {
+				key = attrs["name"]
+			}
+			if key == "" {
+				key = attrs["itemprop"]
+			}
+			if key != "" {
+				key = strings.ToLower(key)
+				doc.metas[key] = append(doc.metas[key], attrs["content"])
+			}
+		case "script", "title":
+			// The content of these elements is raw text, up to their closing tag
+			content := page[next:]
+			end := strings.Index(lower[next:], "</"+name)
+			if end >= 0 {
+				content = page[next : next+end]
+				next += end
+			} else {
+				next = len(page)
+			}
+
+			if name == "title" {
+				if doc.title == "" {
+					doc.title = strings.TrimSpace(html.UnescapeString(content))
+				}
+			} else {
+				doc.scripts = append(doc.scripts, script{
+					typ:     strings.ToLower(strings.TrimSpace(attrs["type"])),
+					id:      attrs["id"],
+					content: content,
+				})
+			}
+		}
+
+		i = next
+	}
+
+	return doc
+}
+
+// parseTag parses the opening tag starting at position i (just after the
+// '<'), and returns its lowercased name, its attributes and the position
+// following it. The name is empty if there is no opening tag at i.
+func parseTag(page string, i int) (string, map[string]string, int) {
+	start := i
+	for i < len(page) && isNameChar(page[i]) {
+		i++
+	}
+	if i == start {
+		return "", nil, i
+	}
+	name := asciiLower(page[start:i])
+
+	attrs := map[string]string{}
+	for i < len(page) {
+		// Skip whitespace and self-closing slashes
+		for i < len(page) && (isSpace(page[i]) || page[i] == '/') {
+			i++
+		}
+		if i >= len(page) {
+			break
+		}
+		if page[i] == '>' {
+			return name, attrs, i + 1
+		}
+
+		// Attribute name
+		nameStart := i
+		for i < len(page) && !isSpace(page[i]) && page[i] != '=' && page[i] != '>' && page[i] != '/' {
+			i++
+		}
+		attrName := asciiLower(page[nameStart:i])
+
+		for i < len(page) && isSpace(page[i]) {
+			i++
+		}
+		if i >= len(page) || page[i] != '=' {
+			attrs[attrName] = ""
+			continue
+		}
+		i++
+		for i < len(page) && isSpace(page[i]) {
+			i++
+		}
+		if i >= len(page) {
+			break
+		}
+
+		// Attribute value, quoted or not
+		var value string
+		if quote := page[i]; quote == '"' || quote == '\'' {
+			end := strings.IndexByte(page[i+1:], quote)
+			if end < 0 {
+				return name, attrs, len(page)
+			}
+			value = page[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			valueStart := i
+			for i < len(page) && !isSpace(page[i]) && page[i] != '>' {
+				i++
+			}
+			value = page[valueStart:i]
+		}
+		attrs[attrName] = html.UnescapeString(value)
+	}
+
+	return name, attrs, len(page)
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// asciiLower lowercases the ASCII letters of s, keeping its byte positions
+func asciiLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,199 @@
+package scraper
+
+import (
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// maxPhotos is the maximum number of photos kept from a listing
+const maxPhotos = 30
+
+// Parse extracts the data of the listing published on the page at the given
+// URL. Each source only fills the values the previous ones did not find:
+// the parser of the site first, then schema.org JSON-LD, OpenGraph and
+// finally the french phrasing of the title and description.
+func Parse(pageURL *url.URL, page []byte) models.Listing {
+	e := &extraction{base: pageURL}
+	doc := parseDocument(string(page))
+
+	if site, ok := siteFor(pageURL.Hostname()); ok {
+		site.parse(e, doc)
+	}
+	applyJSONLD(e, doc)
+	applyOpenGraph(e, doc)
+	applyText(e, e.listing.Title)
+	applyText(e, doc.title)
+	applyText(e, e.listing.Description)
+	e.setString(&e.listing.Title, doc.title)
+
+	return e.listing
+}
+
+// extraction accumulates the data found on a page
+type extraction struct {
+	base    *url.URL
+	listing models.Listing
+}
+
+// setString sets a string value if it has not been found yet
+func (e *extraction) setString(dst *string, value string) {
+	value = strings.TrimSpace(value)
+	if *dst == "" && value != "" {
+		*dst = value
+	}
+}
+
+// setInt sets an integer value if it has not been found yet
+func (e *extraction) setInt(dst *int64, value int64) {
+	if *dst == 0 && value > 0 {
+		*dst = value
+	}
+}
+
+// setDate sets the publication date if it has not been found yet
+func (e *extraction) setDate(value string) {
+	if !e.listing.PublicationDate.IsZero() {
+		return
+	}
+	if date, ok := parseDate(value); ok {
+		e.listing.PublicationDate = date
+	}
+}
+
+// addPhoto adds the URL of a photo, resolved against the page URL
+func (e *extraction) addPhoto(ref string) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || len(e.listing.PhotoURLs) >= maxPhotos {
+		return
+	}
+	u, err := e.base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return
+	}
+	photo := u.String()
+	for _, known := range e.listing.PhotoURLs {
+		if known == photo {
+			return
+		}
+	}
+	e.listing.PhotoURLs = append(e.listing.PhotoURLs, photo)
+}
+
+// applyOpenGraph extracts the data of the OpenGraph and description meta elements
+func applyOpenGraph(e *extraction, doc document) {
+	e.setString(&e.listing.Title, doc.meta("og:title"))
+	e.setString(&e.listing.Description, doc.meta("og:description"))
+	e.setString(&e.listing.Description, doc.meta("description"))
+	e.setInt(&e.listing.Price, parseNumber(doc.meta("product:price:amount")))
+	e.setInt(&e.listing.Price, parseNumber(doc.meta("og:price:amount")))
+	e.setString(&e.listing.CityName, doc.meta("og:locality"))
+	for _, image := range doc.metas["og:image"] {
+		e.addPhoto(image)
+	}
+}
+
+var (
+	landRe     = regexp.MustCompile(`(?i)terrain[^\d]{0,30}?(\d[\d\s\x{00a0}\x{202f}.,]*?)\s*m(?:²|2)(?:\W|$)`)
+	surfaceRe  = regexp.MustCompile(`(?i)(\d[\d\s\x{00a0}\x{202f}.,]*?)\s*m(?:²|2)(?:\W|$)`)
+	roomsRe    = regexp.MustCompile(`(?i)(\d+)\s*pi[eè]ces?`)
+	typeRe     = regexp.MustCompile(`\b[TF](\d)\b`)
+	bedroomsRe = regexp.MustCompile(`(?i)(\d+)\s*chambres?`)
+	priceRe    = regexp.MustCompile(`(?i)(\d[\d\s\x{00a0}\x{202f}.]*?)\s*(?:€|euros?)`)
+	cityRe     = regexp.MustCompile(`(\p{Lu}[\p{L}' -]*?)\s*\((\d{5}|\d{2})\)`)
+)
+
+// applyText extracts the data written in a text, the way french ads phrase it
+func applyText(e *extraction, text string) {
+	if text == "" {
+		return
+	}
+
+	// The first surface which is not the one of the land is the living area
+	land := landRe.FindAllStringSubmatchIndex(text, -1)
+	for _, match := range surfaceRe.FindAllStringSubmatchIndex(text, -1) {
+		if !inMatches(match[2], land) {
+			e.setInt(&e.listing.Surface, parseNumber(text[match[2]:match[3]]))
+			break
+		}
+	}
+
+	if match := roomsRe.FindStringSubmatch(text); match != nil {
+		e.setInt(&e.listing.Rooms, parseNumber(match[1]))
+	}
+	if match := typeRe.FindStringSubmatch(text); match != nil {
+		e.setInt(&e.listing.Rooms, parseNumber(match[1]))
+	}
+	if match := bedroomsRe.FindStringSubmatch(text); match != nil {
+		e.setInt(&e.listing.Bedrooms, parseNumber(match[1]))
+	}
+	if match := priceRe.FindStringSubmatch(text); match != nil {
+		e.setInt(&e.listing.Price, parseNumber(match[1]))
+	}
+	if match := cityRe.FindStringSubmatch(text); match != nil {
+		e.setString(&e.listing.CityName, match[1])
+	}
+}
+
+// inMatches reports whether a position is inside the first group of one of the matches
+func inMatches(pos int, matches [][]int) bool {
+	for _, match := range matches {
+		if pos >= match[2] && pos < match[3] {
+			return true
+		}
+	}
+	return false
+}
+
+var numberRe = regexp.MustCompile(`\d(?:[\d\s\x{00a0}\x{202f}.,]*\d)?`)
+
+// parseNumber returns the first number written in a text, in french or
+// english format, rounded to an integer, or 0 if there is none
+func parseNumber(text string) int64 {
+	number := numberRe.FindString(text)
+	if number == "" {
+		return 0
+	}
+
+	number = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\u00a0', '\u202f':
+			return -1
+		}
+		return r
+	}, number)
+
+	switch {
+	case strings.Contains(number, ","):
+		// Decimal comma, dots can only be thousands separators
+		number = strings.ReplaceAll(number, ".", "")
+		number = strings.Replace(number, ",", ".", 1)
+		number = strings.ReplaceAll(number, ",", "")
+	case strings.Count(number, ".") > 1 || thousandsRe.MatchString(number):
+		number = strings.ReplaceAll(number, ".", "")
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(math.Round(value))
+}
+
+var thousandsRe = regexp.MustCompile(`^\d{1,3}\.\d{3}$`)
+
+// parseDate parses the dates found in ads, in the most common formats
+func parseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,180 @@
+package scraper
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		page    string
+		listing models.Listing
+	}{
+		{
+			name: "JSON-LD",
+			url:  "https://www.agence.example/annonce/42",
+			page: "jsonld.html",
+			listing: models.Listing{
+				Title:           "Maison familiale avec jardin",
+				Description:     "Belle maison au calme, proche des écoles.",
+				CityName:        "Montauban",
+				Price:           285000,
+				Surface:         125,
+				Rooms:           6,
+				Bedrooms:        4,
+				PublicationDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+				PhotoURLs: []string{
+					"https://www.agence.example/photos/1.jpg",
+					"https://cdn.example.com/photos/2.jpg",
+				},
+			},
+		},
+		{
+			name: "OpenGraph",
+			url:  "https://immo.example/vente/123",
+			page: "opengraph.html",
+			listing: models.Listing{
+				Title:       "Appartement T3 lumineux de 68 m²",
+				Description: "Appartement de 3 pièces avec 2 chambres à Albi (81), 189 000 €.",
+				CityName:    "Albi",
+				Price:       189000,
+				Surface:     68,
+				Rooms:       3,
+				Bedrooms:    2,
+				PhotoURLs: []string{
+					"https://images.example.com/a.jpg",
+					"https://images.example.com/b.jpg",
+				},
+			},
+		},
+		{
+			name: "leboncoin",
+			url:  "https://www.leboncoin.fr/ad/ventes_immobilieres/2900000000",
+			page: "leboncoin.html",
+			listing: models.Listing{
+				Title:           "Maison de village rénovée",
+				Description:     "Maison rénovée avec terrain de 800 m², garage.",
+				CityName:        "Gaillac",
+				Price:           215000,
+				Surface:         110,
+				Rooms:           5,
+				Bedrooms:        3,
+				PublicationDate: time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC),
+				PhotoURLs: []string{
+					"https://img.leboncoin.fr/large/1.jpg",
+					"https://img.leboncoin.fr/large/2.jpg",
+				},
+			},
+		},
+		{
+			name: "embedded state",
+			url:  "https://www.seloger.com/annonces/achat/maison/castres-81/123.htm",
+			page: "seloger.html",
+			listing: models.Listing{
+				Title:    "Maison à vendre",
+				CityName: "Castres",
+				Price:    342000,
+				Surface:  135,
+				Rooms:    7,
+				Bedrooms: 4,
+			},
+		},
+		{
+			name: "text only",
+			url:  "https://petites-annonces.example/maison-lavaur",
+			page: "text.html",
+			listing: models.Listing{
+				Title:       "Vente maison 4 pièces 95 m² Lavaur (81500) - 198 500 €",
+				Description: "Maison de plain-pied, terrain de 1 200 m², 3 chambres.",
+				CityName:    "Lavaur",
+				Price:       198500,
+				Surface:     95,
+				Rooms:       4,
+				Bedrooms:    3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", tt.page))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pageURL, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Parse(pageURL, page)
+			assertListing(t, got, tt.listing)
+		})
+	}
+}
+
+// assertListing reports the fields of a listing which differ from the expected ones
+func assertListing(t *testing.T, got, want models.Listing) {
+	t.Helper()
+	if got.URL != want.URL {
+		t.Errorf("URL = %q, want %q", got.URL, want.URL)
+	}
+	if got.Title != want.Title {
+		t.Errorf("Title = %q, want %q", got.Title, want.Title)
+	}
+	if got.Description != want.Description {
+		t.Errorf("Description = %q, want %q", got.Description, want.Description)
+	}
+	if got.CityName != want.CityName {
+		t.Errorf("CityName = %q, want %q", got.CityName, want.CityName)
+	}
+	if got.Price != want.Price {
+		t.Errorf("Price = %d, want %d", got.Price, want.Price)
+	}
+	if got.Surface != want.Surface {
+		t.Errorf("Surface = %d, want %d", got.Surface, want.Surface)
+	}
+	if got.Rooms != want.Rooms {
+		t.Errorf("Rooms = %d, want %d", got.Rooms, want.Rooms)
+	}
+	if got.Bedrooms != want.Bedrooms {
+		t.Errorf("Bedrooms = %d, want %d", got.Bedrooms, want.Bedrooms)
+	}
+	if !got.PublicationDate.Equal(want.PublicationDate) {
+		t.Errorf("PublicationDate = %v, want %v", got.PublicationDate, want.PublicationDate)
+	}
+	if !slices.Equal(got.PhotoURLs, want.PhotoURLs) {
+		t.Errorf("PhotoURLs = %q, want %q", got.PhotoURLs, want.PhotoURLs)
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		text string
+		want int64
+	}{
+		{"", 0},
+		{"sans prix", 0},
+		{"285000", 285000},
+		{"285 000 €", 285000},
+		{"285\u202f000\u00a0€", 285000},
+		{"285.000", 285000},
+		{"1.285.000", 1285000},
+		{"124,6 m²", 125},
+		{"124.4", 124},
+		{"1 200,50", 1201},
+	}
+
+	for _, tt := range tests {
+		if got := parseNumber(tt.text); got != tt.want {
+			t.Errorf("parseNumber(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
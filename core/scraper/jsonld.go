@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// listingTypes are the schema.org types describing a listing or the housing it offers
+var listingTypes = map[string]bool{
+	"Accommodation":         true,
+	"Apartment":             true,
+	"House":                 true,
+	"Offer":                 true,
+	"Product":               true,
+	"RealEstateListing":     true,
+	"Residence":             true,
+	"SingleFamilyResidence": true,
+}
+
+// applyJSONLD extracts the data of the schema.org JSON-LD scripts
+func applyJSONLD(e *extraction, doc document) {
+	for _, content := range doc.scriptsOfType("application/ld+json") {
+		var data any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &data); err != nil {
+			continue
+		}
+		for _, entity := range jsonLDEntities(data) {
+			applyJSONLDEntity(e, entity, 0)
+		}
+	}
+}
+
+// jsonLDEntities returns the top-level entities of a JSON-LD document
+func jsonLDEntities(data any) []map[string]any {
+	var entities []map[string]any
+	for _, entity := range jsonMaps(data) {
+		if graph, ok := entity["@graph"]; ok {
+			entities = append(entities, jsonMaps(graph)...)
+		} else {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+// applyJSONLDEntity extracts the data of an entity and of the entities it describes
+func applyJSONLDEntity(e *extraction, entity map[string]any, depth int) {
+	if depth > 4 || !hasListingType(entity) {
+		return
+	}
+
+	e.setString(&e.listing.Title, jsonString(entity["name"]))
+	e.setString(&e.listing.Description, jsonString(entity["description"]))
+	e.setInt(&e.listing.Price, jsonNumber(entity["price"]))
+	e.setInt(&e.listing.Surface, jsonNumber(entity["floorSize"]))
+	e.setInt(&e.listing.Rooms, jsonNumber(entity["numberOfRooms"]))
+	e.setInt(&e.listing.Bedrooms, jsonNumber(entity["numberOfBedrooms"]))
+	e.setDate(jsonString(entity["datePosted"]))
+	e.setDate(jsonString(entity["datePublished"]))
+
+	for _, address := range jsonMaps(entity["address"]) {
+		e.setString(&e.listing.CityName, jsonString(address["addressLocality"]))
+	}
+
+	for _, key := range []string{"image", "photo"} {
+		for _, image := range jsonImages(entity[key]) {
+			e.addPhoto(image)
+		}
+	}
+
+	for _, key := range []string{"offers", "itemOffered", "mainEntity", "about"} {
+		for _, nested := range jsonMaps(entity[key]) {
+			applyJSONLDEntity(e, nested, depth+1)
+		}
+	}
+}
+
+// hasListingType reports whether an entity has one of the listing types
+func hasListingType(entity map[string]any) bool {
+	switch typ := entity["@type"].(type) {
+	case string:
+		return listingTypes[typ]
+	case []any:
+		for _, t := range typ {
+			if s, ok := t.(string); ok && listingTypes[s] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonMaps returns the objects of a JSON value, which may be an object or an array
+func jsonMaps(value any) []map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		var maps []map[string]any
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				maps = append(maps, m)
+			}
+		}
+		return maps
+	}
+	return nil
+}
+
+// jsonString returns a JSON value as a string, if it is a string or a number
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// jsonNumber returns a JSON value as an integer, if it is a number, a string
+// containing a number, a quantitative value or an array of those
+func jsonNumber(value any) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(math.Round(v))
+	case string:
+		return parseNumber(v)
+	case map[string]any:
+		return jsonNumber(v["value"])
+	case []any:
+		if len(v) > 0 {
+			return jsonNumber(v[0])
+		}
+	}
+	return 0
+}
+
+// jsonImages returns the URLs of a schema.org image value, which may be a
+// URL, an image object or an array of those
+func jsonImages(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case map[string]any:
+		if url := jsonString(v["contentUrl"]); url != "" {
+			return []string{url}
+		}
+		return []string{jsonString(v["url"])}
+	case []any:
+		var urls []string
+		for _, item := range v {
+			urls = append(urls, jsonImages(item)...)
+		}
+		return urls
+	}
+	return nil
+}
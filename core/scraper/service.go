@@ -0,0 +1,189 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// maxPageSize is the maximum size of a listing page
+const maxPageSize = 10 << 20
+
+// userAgent is sent with the requests, some portals refusing unknown clients
+const userAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+
+// ErrInvalidURL is returned when the URL of a listing is not an http or https URL
+var ErrInvalidURL = errors.New("invalid listing URL")
+
+// photoExtensions are the extensions of the downloaded photos, by content type
+var photoExtensions = map[string]string{
+	"image/avif": ".avif",
+	"image/gif":  ".gif",
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Service provides methods for extracting the data of listings from their pages
+type Service struct {
+	client       *http.Client
+	uploadsDir   string
+	maxPhotoSize int64
+}
+
+// NewService creates a new scraper service, fetching the pages and photos
+// with the given client
+func NewService(client *http.Client, uploadsDir string, maxPhotoSize int64) *Service {
+	return &Service{
+		client:       client,
+		uploadsDir:   uploadsDir,
+		maxPhotoSize: maxPhotoSize,
+	}
+}
+
+// Scrape fetches the page of a listing and extracts its data
+func (s *Service) Scrape(ctx context.Context, rawURL string) (models.Listing, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.Listing{}, ErrInvalidURL
+	}
+
+	page, finalURL, _, err := s.fetch(ctx, u.String(), maxPageSize)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	// The page is parsed as the site it was redirected to
+	listing := Parse(finalURL, page)
+	listing.URL = rawURL
+	return listing, nil
+}
+
+// DownloadPhotos downloads the photos of a listing into the photos directory
+// of a house, and returns the names of the files. Photos that cannot be
+// downloaded are skipped, their errors being returned together.
+func (s *Service) DownloadPhotos(ctx context.Context, houseID int64, photoURLs []string) ([]string, error) {
+	dir := filepath.Join(s.uploadsDir, strconv.FormatInt(houseID, 10), "photos")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create photos directory: %w", err)
+	}
+
+	var filenames []string
+	var errs []error
+	for i, photoURL := range photoURLs {
+		if i >= maxPhotos {
+			break
+		}
+		filename, err := s.downloadPhoto(ctx, dir, i+1, photoURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("photo %s: %w", photoURL, err))
+			continue
+		}
+		filenames = append(filenames, filename)
+	}
+
+	return filenames, errors.Join(errs...)
+}
+
+// downloadPhoto downloads a photo into a directory, as the given number
+func (s *Service) downloadPhoto(ctx context.Context, dir string, number int, photoURL string) (string, error) {
+	u, err := url.Parse(photoURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ErrInvalidURL
+	}
+
+	data, _, contentType, err := s.fetch(ctx, u.String(), s.maxPhotoSize)
+	if err != nil {
+		return "", err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	ext, ok := photoExtensions[mediaType]
+	if !ok {
+		// Some servers send a generic content type
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+		if ext, ok = photoExtensions[mediaType]; !ok {
+			return "", fmt.Errorf("unsupported content type %q", contentType)
+		}
+	}
+
+	filename := fmt.Sprintf("annonce-%02d%s", number, ext)
+	f, err := os.OpenFile(filepath.Join(dir, filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// fetch downloads a resource of at most maxSize bytes, and returns its
+// content, its final URL after redirections and its content type
+func (s *Service) fetch(ctx context.Context, rawURL string, maxSize int64) ([]byte, *url.URL, string, error) {
+	resp, err := s.get(ctx, rawURL, maxSize)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, nil, "", fmt.Errorf("failed to fetch %s: unexpected status %s", rawURL, resp.status)
+	}
+	return resp.body, resp.url, resp.contentType, nil
+}
+
+// response is a downloaded resource, whatever its status
+type response struct {
+	statusCode  int
+	status      string
+	url         *url.URL // Final URL, after redirections
+	contentType string
+	body        []byte
+}
+
+// get downloads a resource of at most maxSize bytes
+func (s *Service) get(ctx context.Context, rawURL string, maxSize int64) (response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return response{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return response{}, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return response{}, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if int64(len(data)) > maxSize {
+		return response{}, fmt.Errorf("failed to read %s: larger than %d bytes", rawURL, maxSize)
+	}
+
+	return response{
+		statusCode:  resp.StatusCode,
+		status:      resp.Status,
+		url:         resp.Request.URL,
+		contentType: resp.Header.Get("Content-Type"),
+		body:        data,
+	}, nil
+}
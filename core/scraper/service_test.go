@@ -0,0 +1,150 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/willoma/recherche-maison/models"
+)
+
+// pngHeader is enough for the content of a photo to be detected as PNG
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// newTestService returns a service using the client of a test server
+func newTestService(t *testing.T, server *httptest.Server) *Service {
+	t.Helper()
+	return NewService(server.Client(), t.TempDir(), 1<<20)
+}
+
+func TestScrape(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "jsonld.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /annonce/42", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != userAgent {
+			http.Error(w, "unknown client", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+	mux.HandleFunc("GET /a/42", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/annonce/42", http.StatusMovedPermanently)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := newTestService(t, server)
+
+	t.Run("page", func(t *testing.T) {
+		got, err := s.Scrape(context.Background(), server.URL+"/a/42")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertListing(t, got, models.Listing{
+			URL:             server.URL + "/a/42",
+			Title:           "Maison familiale avec jardin",
+			Description:     "Belle maison au calme, proche des écoles.",
+			CityName:        "Montauban",
+			Price:           285000,
+			Surface:         125,
+			Rooms:           6,
+			Bedrooms:        4,
+			PublicationDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+			PhotoURLs: []string{
+				// Relative photos are resolved against the page after redirection
+				server.URL + "/photos/1.jpg",
+				"https://cdn.example.com/photos/2.jpg",
+			},
+		})
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := s.Scrape(context.Background(), server.URL+"/annonce/43"); err == nil {
+			t.Error("expected an error for a missing page")
+		}
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		for _, rawURL := range []string{"", "ftp://example.com/annonce", "/annonce/42", "https://"} {
+			if _, err := s.Scrape(context.Background(), rawURL); !errors.Is(err, ErrInvalidURL) {
+				t.Errorf("Scrape(%q) error = %v, want %v", rawURL, err, ErrInvalidURL)
+			}
+		}
+	})
+}
+
+func TestDownloadPhotos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /photos/1.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("jpeg"))
+	})
+	mux.HandleFunc("GET /photos/2", func(w http.ResponseWriter, r *http.Request) {
+		// Generic content type, the extension comes from the content
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(pngHeader)
+	})
+	mux.HandleFunc("GET /photos/page.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	})
+	mux.HandleFunc("GET /photos/large.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(bytes.Repeat([]byte{0}, 1<<20+1))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := newTestService(t, server)
+
+	filenames, err := s.DownloadPhotos(context.Background(), 7, []string{
+		server.URL + "/photos/1.jpg",
+		server.URL + "/photos/missing.jpg",
+		server.URL + "/photos/2",
+		server.URL + "/photos/page.html",
+		server.URL + "/photos/large.jpg",
+		"data:image/png;base64,AAAA",
+	})
+	if err == nil {
+		t.Error("expected the errors of the photos which could not be downloaded")
+	}
+
+	// Files are numbered after the position of their URL
+	want := []string{"annonce-01.jpg", "annonce-03.png"}
+	if !slices.Equal(filenames, want) {
+		t.Fatalf("filenames = %q, want %q", filenames, want)
+	}
+
+	dir := filepath.Join(s.uploadsDir, "7", "photos")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	if !slices.Equal(files, want) {
+		t.Errorf("files = %q, want %q", files, want)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "annonce-03.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, pngHeader) {
+		t.Errorf("annonce-03.png = %q, want %q", data, pngHeader)
+	}
+}
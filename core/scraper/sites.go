@@ -0,0 +1,158 @@
+package scraper
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// site is a parser for the pages of a real estate portal
+type site struct {
+	hosts []string
+	parse func(e *extraction, doc document)
+}
+
+// sites are the portals whose pages have a dedicated parser
+var sites = []site{
+	{
+		hosts: []string{"leboncoin.fr"},
+		parse: parseLeboncoin,
+	},
+	{
+		// These portals embed the state of their page as JSON, where the
+		// listing data can be found under the names they use
+		hosts: []string{"seloger.com", "bienici.com", "pap.fr", "logic-immo.com", "figaro-immobilier.fr", "ouestfrance-immo.com"},
+		parse: parseEmbeddedState,
+	},
+}
+
+// siteFor returns the parser for the pages of the given host, or its subdomains
+func siteFor(host string) (site, bool) {
+	host = strings.ToLower(host)
+	for _, s := range sites {
+		for _, h := range s.hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return s, true
+			}
+		}
+	}
+	return site{}, false
+}
+
+// parseLeboncoin extracts the data of the ad embedded in the Next.js state of the page
+func parseLeboncoin(e *extraction, doc document) {
+	var data struct {
+		Props struct {
+			PageProps struct {
+				Ad struct {
+					Subject              string `json:"subject"`
+					Body                 string `json:"body"`
+					Price                any    `json:"price"`
+					FirstPublicationDate string `json:"first_publication_date"`
+					Location             struct {
+						City string `json:"city"`
+					} `json:"location"`
+					Images struct {
+						URLs      []string `json:"urls"`
+						URLsLarge []string `json:"urls_large"`
+					} `json:"images"`
+					Attributes []struct {
+						Key   string `json:"key"`
+						Value string `json:"value"`
+					} `json:"attributes"`
+				} `json:"ad"`
+			} `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err := json.Unmarshal([]byte(doc.scriptByID("__NEXT_DATA__")), &data); err != nil {
+		return
+	}
+	ad := data.Props.PageProps.Ad
+
+	e.setString(&e.listing.Title, ad.Subject)
+	e.setString(&e.listing.Description, ad.Body)
+	e.setInt(&e.listing.Price, jsonNumber(ad.Price))
+	e.setString(&e.listing.CityName, ad.Location.City)
+	e.setDate(ad.FirstPublicationDate)
+
+	for _, attribute := range ad.Attributes {
+		switch attribute.Key {
+		case "square":
+			e.setInt(&e.listing.Surface, parseNumber(attribute.Value))
+		case "rooms":
+			e.setInt(&e.listing.Rooms, parseNumber(attribute.Value))
+		case "bedrooms":
+			e.setInt(&e.listing.Bedrooms, parseNumber(attribute.Value))
+		}
+	}
+
+	photos := ad.Images.URLsLarge
+	if len(photos) == 0 {
+		photos = ad.Images.URLs
+	}
+	for _, photo := range photos {
+		e.addPhoto(photo)
+	}
+}
+
+// Names under which the portals store the listing data in their embedded state
+var (
+	stateSurfaceKeys  = []string{"surface", "livingArea", "livingSurface", "surfaceArea"}
+	stateRoomsKeys    = []string{"rooms", "roomsQuantity", "nbRooms", "roomCount"}
+	stateBedroomsKeys = []string{"bedrooms", "bedroomsQuantity", "nbBedrooms", "bedroomCount"}
+	statePriceKeys    = []string{"price"}
+	stateCityKeys     = []string{"city"}
+)
+
+// parseEmbeddedState extracts the data found in the JSON scripts of the page
+func parseEmbeddedState(e *extraction, doc document) {
+	for _, content := range doc.scriptsOfType("application/json") {
+		var data any
+		if err := json.Unmarshal([]byte(content), &data); err != nil {
+			continue
+		}
+		walkState(e, data, 0)
+	}
+}
+
+// walkState looks for the listing data in a JSON value, in a stable order
+func walkState(e *extraction, value any, depth int) {
+	if depth > 12 {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			switch child := v[key].(type) {
+			case float64:
+				switch {
+				case slices.Contains(stateSurfaceKeys, key):
+					e.setInt(&e.listing.Surface, jsonNumber(child))
+				case slices.Contains(stateRoomsKeys, key):
+					e.setInt(&e.listing.Rooms, jsonNumber(child))
+				case slices.Contains(stateBedroomsKeys, key):
+					e.setInt(&e.listing.Bedrooms, jsonNumber(child))
+				case slices.Contains(statePriceKeys, key):
+					e.setInt(&e.listing.Price, jsonNumber(child))
+				}
+			case string:
+				if slices.Contains(stateCityKeys, key) {
+					e.setString(&e.listing.CityName, child)
+				}
+			default:
+				walkState(e, child, depth+1)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			walkState(e, item, depth+1)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// ErrTagExists is returned when another tag has the same name
+var ErrTagExists = errors.New("une étiquette porte déjà ce nom")
+
+// Service provides methods for managing tags
+type Service struct {
+	queries *db.Queries
+}
+
+// NewService creates a new tag service
+func NewService(queries *db.Queries) *Service {
+	return &Service{
+		queries: queries,
+	}
+}
+
+// ListTags retrieves all tags
+func (s *Service) ListTags(ctx context.Context) ([]models.Tag, error) {
+	tags, err := s.queries.ListTags(ctx)
+	if err != nil {
+		slog.Error("Failed to list tags", "error", err)
+		return nil, err
+	}
+	return models.FromDBTags(tags), nil
+}
+
+// CreateTag creates a new tag, returning ErrTagExists if another tag has the
+// same name
+func (s *Service) CreateTag(ctx context.Context, name, color string) error {
+	if err := s.checkUnique(ctx, 0, name); err != nil {
+		return err
+	}
+
+	if err := s.queries.CreateTag(ctx, name, color); err != nil {
+		slog.Error("Failed to create tag", "name", name, "color", color, "error", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateTag updates the name and color of an existing tag, returning
+// ErrTagExists if another tag has the same name
+func (s *Service) UpdateTag(ctx context.Context, id int64, name, color string) error {
+	if err := s.checkUnique(ctx, id, name); err != nil {
+		return err
+	}
+
+	if err := s.queries.UpdateTag(ctx, db.UpdateTagParams{
+		ID:    id,
+		Name:  name,
+		Color: color,
+	}); err != nil {
+		slog.Error("Failed to update tag", "id", id, "name", name, "color", color, "error", err)
+		return err
+	}
+	return nil
+}
+
+// checkUnique returns ErrTagExists if a tag other than the one with the given
+// ID has the given name
+func (s *Service) checkUnique(ctx context.Context, id int64, name string) error {
+	existing, err := s.queries.GetTagByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Error("Failed to get tag", "name", name, "error", err)
+		return err
+	}
+	if existing.ID != id {
+		return ErrTagExists
+	}
+	return nil
+}
+
+// DeleteTag deletes a tag, removing it from all houses
+func (s *Service) DeleteTag(ctx context.Context, id int64) error {
+	if err := s.queries.DeleteTag(ctx, id); err != nil {
+		slog.Error("Failed to delete tag", "id", id, "error", err)
+		return err
+	}
+	return nil
+}
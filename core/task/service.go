@@ -0,0 +1,136 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// Service provides methods for managing tasks and their reminders
+type Service struct {
+	queries *db.Queries
+
+	mu       sync.Mutex
+	reminded map[int64]bool // Tasks for which a reminder has already been issued
+}
+
+// NewService creates a new task service
+func NewService(queries *db.Queries) *Service {
+	return &Service{
+		queries:  queries,
+		reminded: map[int64]bool{},
+	}
+}
+
+// today returns the current day, as stored in the database for dates
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ListTasks retrieves all tasks, pending ones first, ordered by due date
+func (s *Service) ListTasks(ctx context.Context) ([]models.Task, error) {
+	tasks, err := s.queries.ListTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	return models.FromDBTasks(tasks), nil
+}
+
+// ListHouseTasks retrieves all tasks of a house, pending ones first, ordered by due date
+func (s *Service) ListHouseTasks(ctx context.Context, houseID int64) ([]models.Task, error) {
+	tasks, err := s.queries.ListHouseTasks(ctx, houseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list house tasks: %w", err)
+	}
+	return models.FromDBTasks(tasks), nil
+}
+
+// CreateTask creates a new task
+func (s *Service) CreateTask(ctx context.Context, task models.Task) error {
+	if err := s.queries.CreateTask(ctx, db.CreateTaskParams{
+		HouseID:  task.HouseID,
+		Title:    task.Title,
+		DueDate:  task.DueDate,
+		Assignee: task.Assignee,
+	}); err != nil {
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+	s.refreshReminders(ctx)
+	return nil
+}
+
+// SetTaskDone marks a task as done or not done
+func (s *Service) SetTaskDone(ctx context.Context, id int64, done bool) error {
+	if err := s.queries.SetTaskDone(ctx, done, id); err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	s.refreshReminders(ctx)
+	return nil
+}
+
+// DeleteTask deletes a task
+func (s *Service) DeleteTask(ctx context.Context, id int64) error {
+	if err := s.queries.DeleteTask(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	s.refreshReminders(ctx)
+	return nil
+}
+
+// OverdueCount returns the number of pending tasks whose due date has passed
+func (s *Service) OverdueCount(ctx context.Context) (int, error) {
+	count, err := s.queries.CountOverdueTasks(ctx, today())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count overdue tasks: %w", err)
+	}
+	return int(count), nil
+}
+
+// RunReminders computes due reminders immediately, then every interval,
+// until ctx is cancelled
+func (s *Service) RunReminders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.refreshReminders(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshReminders issues a reminder for each pending task that became due
+// since the previous run
+func (s *Service) refreshReminders(ctx context.Context) {
+	day := today()
+
+	dueTasks, err := s.queries.ListDueTasks(ctx, day)
+	if err != nil {
+		slog.Error("Failed to list due tasks", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reminded := make(map[int64]bool, len(dueTasks))
+	for _, dbTask := range dueTasks {
+		task := models.FromDBTask(dbTask)
+		if !s.reminded[task.ID] {
+			slog.Info("Task reminder", "task_id", task.ID, "title", task.Title, "house", task.HouseTitle, "due_date", task.DueDate.Format("2006-01-02"), "assignee", task.Assignee)
+		}
+		reminded[task.ID] = true
+	}
+
+	s.reminded = reminded
+}
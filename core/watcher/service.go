@@ -0,0 +1,251 @@
+package watcher
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/willoma/recherche-maison/core/scraper"
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// checkDelay is the pause between two checks of a run, not to overload the portals
+const checkDelay = 2 * time.Second
+
+// Service provides methods for checking the publication URLs of the houses
+// and for managing the notifications about their changes
+type Service struct {
+	queries *db.Queries
+	db      *sql.DB
+	scraper *scraper.Service
+}
+
+// NewService creates a new watcher service
+func NewService(queries *db.Queries, dbConn *sql.DB, scraperService *scraper.Service) *Service {
+	return &Service{
+		queries: queries,
+		db:      dbConn,
+		scraper: scraperService,
+	}
+}
+
+// RunChecks checks the publication URLs immediately, then every interval,
+// until ctx is cancelled. Publications checked less than an interval ago,
+// for instance before a restart, are skipped.
+func (s *Service) RunChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.checkAll(ctx, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAll checks every publication URL not checked since the given duration
+func (s *Service) checkAll(ctx context.Context, minAge time.Duration) {
+	publications, err := s.queries.ListAllPublicationURLs(ctx)
+	if err != nil {
+		slog.Error("Failed to list publication URLs", "error", err)
+		return
+	}
+
+	checked := 0
+	for _, pub := range publications {
+		lastCheck, err := s.queries.GetLastPublicationCheckTime(ctx, pub.ID, pub.URL)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			slog.Error("Failed to get last publication check", "publication_id", pub.ID, "error", err)
+			continue
+		}
+		if err == nil && time.Since(lastCheck) < minAge {
+			continue
+		}
+
+		if checked > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(checkDelay):
+			}
+		}
+		checked++
+
+		if err := s.checkPublication(ctx, models.FromDBPublicationURL(pub)); err != nil {
+			slog.Error("Failed to check publication", "publication_id", pub.ID, "url", pub.URL, "error", err)
+		}
+	}
+
+	if checked > 0 {
+		slog.Info("Publication URLs checked", "count", checked)
+	}
+}
+
+// CheckHouse checks all the publication URLs of a house now
+func (s *Service) CheckHouse(ctx context.Context, houseID int64) error {
+	publications, err := s.queries.GetPublicationURLs(ctx, houseID)
+	if err != nil {
+		return fmt.Errorf("failed to get publication URLs: %w", err)
+	}
+
+	for _, pub := range publications {
+		if err := s.checkPublication(ctx, models.FromDBPublicationURL(pub)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPublication checks a publication URL, stores the result and creates
+// the notifications for the changes since the previous successful check
+func (s *Service) checkPublication(ctx context.Context, pub models.PublicationURL) error {
+	check := s.scraper.Check(ctx, pub.URL)
+	if check.Failed() {
+		slog.Warn("Publication check failed", "publication_id", pub.ID, "url", pub.URL, "error", check.Error)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	previous, err := queries.GetLatestPublicationCheck(ctx, pub.ID, pub.URL)
+	hasPrevious := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get latest publication check: %w", err)
+	}
+
+	// The price is compared to the last one found, or to the one of the
+	// house before the first check
+	referencePrice, err := queries.GetLastPublicationPrice(ctx, pub.ID, pub.URL)
+	if errors.Is(err, sql.ErrNoRows) {
+		house, err := queries.GetHouse(ctx, pub.HouseID)
+		if err != nil {
+			return fmt.Errorf("failed to get house: %w", err)
+		}
+		referencePrice = house.Price
+	} else if err != nil {
+		return fmt.Errorf("failed to get last publication price: %w", err)
+	}
+
+	if err := queries.CreatePublicationCheck(ctx, db.CreatePublicationCheckParams{
+		PublicationURLID: pub.ID,
+		URL:              pub.URL,
+		StatusCode:       check.StatusCode,
+		FinalURL:         check.FinalURL,
+		Removed:          check.Removed,
+		Price:            check.Price,
+		Error:            check.Error,
+	}); err != nil {
+		return fmt.Errorf("failed to create publication check: %w", err)
+	}
+
+	if !check.Failed() {
+		notification := db.CreateNotificationParams{
+			HouseID: pub.HouseID,
+			URL:     pub.URL,
+		}
+		switch {
+		case check.Removed && !(hasPrevious && previous.Removed):
+			notification.NotificationType = models.NotificationListingRemoved
+		case !check.Removed && hasPrevious && previous.Removed:
+			notification.NotificationType = models.NotificationListingRestored
+		case !check.Removed && check.Price > 0 && referencePrice > 0 && check.Price != referencePrice:
+			notification.NotificationType = models.NotificationPriceChanged
+			notification.OldPrice = referencePrice
+			notification.NewPrice = check.Price
+		}
+
+		if notification.NotificationType != "" {
+			if err := queries.CreateNotification(ctx, notification); err != nil {
+				return fmt.Errorf("failed to create notification: %w", err)
+			}
+			slog.Info("Publication changed", "publication_id", pub.ID, "url", pub.URL, "type", notification.NotificationType)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListHouseChecks retrieves the latest check of each publication URL of a
+// house, by publication URL ID
+func (s *Service) ListHouseChecks(ctx context.Context, houseID int64) (map[int64]models.PublicationCheck, error) {
+	dbChecks, err := s.queries.ListHousePublicationChecks(ctx, houseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list publication checks: %w", err)
+	}
+
+	checks := make(map[int64]models.PublicationCheck, len(dbChecks))
+	for _, dbCheck := range dbChecks {
+		checks[dbCheck.PublicationURLID] = models.FromDBPublicationCheck(dbCheck)
+	}
+	return checks, nil
+}
+
+// PublicationStatuses returns the publication status of each house which
+// has checked publications, by house ID. Failed checks are ignored.
+func (s *Service) PublicationStatuses(ctx context.Context) (map[int64]string, error) {
+	checks, err := s.queries.ListLatestPublicationChecks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list publication checks: %w", err)
+	}
+
+	statuses := make(map[int64]string)
+	for _, check := range checks {
+		switch {
+		case check.Error != "":
+		case !check.Removed:
+			statuses[check.HouseID] = models.PublicationStatusOnline
+		case statuses[check.HouseID] != models.PublicationStatusOnline:
+			statuses[check.HouseID] = models.PublicationStatusRemoved
+		}
+	}
+	return statuses, nil
+}
+
+// ListNotifications retrieves all notifications, unread ones first
+func (s *Service) ListNotifications(ctx context.Context) ([]models.Notification, error) {
+	notifications, err := s.queries.ListNotifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return models.FromDBNotifications(notifications), nil
+}
+
+// UnreadCount returns the number of unread notifications
+func (s *Service) UnreadCount(ctx context.Context) (int, error) {
+	count, err := s.queries.CountUnreadNotifications(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return int(count), nil
+}
+
+// MarkRead marks a notification as read
+func (s *Service) MarkRead(ctx context.Context, id int64) error {
+	if err := s.queries.MarkNotificationRead(ctx, id); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks all notifications as read
+func (s *Service) MarkAllRead(ctx context.Context) error {
+	if err := s.queries.MarkAllNotificationsRead(ctx); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/willoma/recherche-maison/config"
+	"github.com/willoma/recherche-maison/core/scraper"
+	"github.com/willoma/recherche-maison/db"
+	"github.com/willoma/recherche-maison/models"
+)
+
+// housePrice is the price of the house whose publication is checked
+const housePrice = 260000
+
+// newTestPublication returns a watcher using the client of a test server,
+// on a new database with one house published at the given URL
+func newTestPublication(t *testing.T, server *httptest.Server, rawURL string) (*Service, models.PublicationURL) {
+	t.Helper()
+	ctx := context.Background()
+
+	cfg := config.Default()
+	cfg.DBPath = filepath.Join(t.TempDir(), "test.db")
+	dbConn, err := db.Init(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbConn.Close() })
+	queries := db.New(dbConn)
+
+	if err := queries.CreateCity(ctx, db.CreateCityParams{Name: "Castres", PostalCode: "81100"}); err != nil {
+		t.Fatal(err)
+	}
+	houseID, err := queries.CreateHouse(ctx, db.CreateHouseParams{
+		Title:     "Maison de ville",
+		CityID:    1,
+		Price:     housePrice,
+		Surface:   100,
+		Rooms:     5,
+		Floors:    1,
+		HouseType: models.HouseTypeHouse,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubID, err := queries.CreatePublicationURL(ctx, db.CreatePublicationURLParams{
+		HouseID:         houseID,
+		URL:             rawURL,
+		PublicationDate: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewService(queries, dbConn, scraper.NewService(server.Client(), t.TempDir(), 1<<20))
+	return s, models.PublicationURL{ID: pubID, HouseID: houseID, URL: rawURL}
+}
+
+func TestCheckPublication(t *testing.T) {
+	var price int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><title>Accueil</title></html>"))
+	})
+	mux.HandleFunc("GET /recherche", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><title>Résultats</title></html>"))
+	})
+	mux.HandleFunc("GET /annonces/achat/maison-123.htm", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><meta property="product:price:amount" content="` + fmt.Sprint(price) + `"></html>`))
+	})
+	mux.HandleFunc("GET /annonce/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	mux.HandleFunc("GET /annonce/to-search", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/recherche?ville=castres", http.StatusFound)
+	})
+	mux.HandleFunc("GET /annonce/to-home", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+	mux.HandleFunc("GET /annonce/123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/annonces/achat/maison-123.htm", http.StatusMovedPermanently)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		price        int64
+		notification models.Notification // Zero if no notification is expected
+	}{
+		{
+			name:         "not found",
+			path:         "/annonce/missing",
+			notification: models.Notification{NotificationType: models.NotificationListingRemoved},
+		},
+		{
+			name:         "gone",
+			path:         "/annonce/gone",
+			notification: models.Notification{NotificationType: models.NotificationListingRemoved},
+		},
+		{
+			name:         "redirect to search",
+			path:         "/annonce/to-search",
+			notification: models.Notification{NotificationType: models.NotificationListingRemoved},
+		},
+		{
+			name:         "redirect to home",
+			path:         "/annonce/to-home",
+			notification: models.Notification{NotificationType: models.NotificationListingRemoved},
+		},
+		{
+			name:  "same price",
+			path:  "/annonce/123",
+			price: housePrice,
+		},
+		{
+			name:  "price change",
+			path:  "/annonce/123",
+			price: 245000,
+			notification: models.Notification{
+				NotificationType: models.NotificationPriceChanged,
+				OldPrice:         housePrice,
+				NewPrice:         245000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			price = tt.price
+			s, pub := newTestPublication(t, server, server.URL+tt.path)
+
+			if err := s.checkPublication(ctx, pub); err != nil {
+				t.Fatal(err)
+			}
+
+			notifications, err := s.ListNotifications(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.notification.NotificationType == "" {
+				if len(notifications) > 0 {
+					t.Fatalf("notifications = %+v, want none", notifications)
+				}
+				return
+			}
+			if len(notifications) != 1 {
+				t.Fatalf("notifications = %+v, want one %q", notifications, tt.notification.NotificationType)
+			}
+			got := notifications[0]
+			if got.HouseID != pub.HouseID || got.URL != pub.URL {
+				t.Errorf("notification for house %d and URL %q, want house %d and URL %q", got.HouseID, got.URL, pub.HouseID, pub.URL)
+			}
+			if got.NotificationType != tt.notification.NotificationType || got.OldPrice != tt.notification.OldPrice || got.NewPrice != tt.notification.NewPrice {
+				t.Errorf("notification = %q from %d to %d, want %q from %d to %d",
+					got.NotificationType, got.OldPrice, got.NewPrice,
+					tt.notification.NotificationType, tt.notification.OldPrice, tt.notification.NewPrice)
+			}
+		})
+	}
+}
+
+func TestCheckPublicationSequence(t *testing.T) {
+	online := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /annonce/123", func(w http.ResponseWriter, r *http.Request) {
+		if !online {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`<html><meta property="product:price:amount" content="260000"></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	s, pub := newTestPublication(t, server, server.URL+"/annonce/123")
+
+	// The removal is only notified once, and the return of the ad is notified
+	for _, online = range []bool{false, false, true, true} {
+		if err := s.checkPublication(ctx, pub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notifications, err := s.ListNotifications(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, notification := range notifications {
+		types = append(types, notification.NotificationType)
+	}
+	if len(types) != 2 || !slices.Contains(types, models.NotificationListingRemoved) || !slices.Contains(types, models.NotificationListingRestored) {
+		t.Errorf("notifications = %q, want one %q and one %q", types, models.NotificationListingRemoved, models.NotificationListingRestored)
+	}
+}
@@ -24,71 +24,388 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addHouseTagStmt, err = db.PrepareContext(ctx, addHouseTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddHouseTag: %w", err)
+	}
+	if q.completeFollowUpStmt, err = db.PrepareContext(ctx, completeFollowUp); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteFollowUp: %w", err)
+	}
+	if q.countBANAddressesStmt, err = db.PrepareContext(ctx, countBANAddresses); err != nil {
+		return nil, fmt.Errorf("error preparing query CountBANAddresses: %w", err)
+	}
+	if q.countMapBoundariesStmt, err = db.PrepareContext(ctx, countMapBoundaries); err != nil {
+		return nil, fmt.Errorf("error preparing query CountMapBoundaries: %w", err)
+	}
+	if q.countOverdueTasksStmt, err = db.PrepareContext(ctx, countOverdueTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOverdueTasks: %w", err)
+	}
+	if q.countUnreadNotificationsStmt, err = db.PrepareContext(ctx, countUnreadNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadNotifications: %w", err)
+	}
 	if q.createCityStmt, err = db.PrepareContext(ctx, createCity); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCity: %w", err)
 	}
+	if q.createCustomFieldStmt, err = db.PrepareContext(ctx, createCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCustomField: %w", err)
+	}
+	if q.createCustomValueStmt, err = db.PrepareContext(ctx, createCustomValue); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCustomValue: %w", err)
+	}
+	if q.createDuplicateDismissalStmt, err = db.PrepareContext(ctx, createDuplicateDismissal); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateDuplicateDismissal: %w", err)
+	}
 	if q.createHouseStmt, err = db.PrepareContext(ctx, createHouse); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateHouse: %w", err)
 	}
+	if q.createJournalEntryStmt, err = db.PrepareContext(ctx, createJournalEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJournalEntry: %w", err)
+	}
+	if q.createNeighbourhoodStmt, err = db.PrepareContext(ctx, createNeighbourhood); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNeighbourhood: %w", err)
+	}
+	if q.createNotificationStmt, err = db.PrepareContext(ctx, createNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotification: %w", err)
+	}
+	if q.createPointOfInterestStmt, err = db.PrepareContext(ctx, createPointOfInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePointOfInterest: %w", err)
+	}
+	if q.createPublicationCheckStmt, err = db.PrepareContext(ctx, createPublicationCheck); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePublicationCheck: %w", err)
+	}
 	if q.createPublicationURLStmt, err = db.PrepareContext(ctx, createPublicationURL); err != nil {
 		return nil, fmt.Errorf("error preparing query CreatePublicationURL: %w", err)
 	}
+	if q.createTagStmt, err = db.PrepareContext(ctx, createTag); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTag: %w", err)
+	}
+	if q.createTaskStmt, err = db.PrepareContext(ctx, createTask); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTask: %w", err)
+	}
 	if q.deleteAllPublicationURLsStmt, err = db.PrepareContext(ctx, deleteAllPublicationURLs); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAllPublicationURLs: %w", err)
 	}
 	if q.deleteCityStmt, err = db.PrepareContext(ctx, deleteCity); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCity: %w", err)
 	}
+	if q.deleteCustomFieldStmt, err = db.PrepareContext(ctx, deleteCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCustomField: %w", err)
+	}
+	if q.deleteDuplicatePublicationURLsStmt, err = db.PrepareContext(ctx, deleteDuplicatePublicationURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDuplicatePublicationURLs: %w", err)
+	}
 	if q.deleteHouseStmt, err = db.PrepareContext(ctx, deleteHouse); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteHouse: %w", err)
 	}
+	if q.deleteHouseCustomValuesStmt, err = db.PrepareContext(ctx, deleteHouseCustomValues); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteHouseCustomValues: %w", err)
+	}
+	if q.deleteHouseTagsStmt, err = db.PrepareContext(ctx, deleteHouseTags); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteHouseTags: %w", err)
+	}
+	if q.deleteJournalEntryStmt, err = db.PrepareContext(ctx, deleteJournalEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteJournalEntry: %w", err)
+	}
+	if q.deleteMapBoundariesStmt, err = db.PrepareContext(ctx, deleteMapBoundaries); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMapBoundaries: %w", err)
+	}
+	if q.deleteNeighbourhoodStmt, err = db.PrepareContext(ctx, deleteNeighbourhood); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNeighbourhood: %w", err)
+	}
+	if q.deletePointOfInterestStmt, err = db.PrepareContext(ctx, deletePointOfInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePointOfInterest: %w", err)
+	}
 	if q.deletePublicationURLStmt, err = db.PrepareContext(ctx, deletePublicationURL); err != nil {
 		return nil, fmt.Errorf("error preparing query DeletePublicationURL: %w", err)
 	}
+	if q.deleteTagStmt, err = db.PrepareContext(ctx, deleteTag); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTag: %w", err)
+	}
+	if q.deleteTaskStmt, err = db.PrepareContext(ctx, deleteTask); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTask: %w", err)
+	}
 	if q.getCityStmt, err = db.PrepareContext(ctx, getCity); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCity: %w", err)
 	}
+	if q.getCityByNameStmt, err = db.PrepareContext(ctx, getCityByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCityByName: %w", err)
+	}
+	if q.getCityByNameAndPostalCodeStmt, err = db.PrepareContext(ctx, getCityByNameAndPostalCode); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCityByNameAndPostalCode: %w", err)
+	}
+	if q.getCustomFieldStmt, err = db.PrepareContext(ctx, getCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomField: %w", err)
+	}
+	if q.getCustomFieldByNameStmt, err = db.PrepareContext(ctx, getCustomFieldByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCustomFieldByName: %w", err)
+	}
 	if q.getHouseStmt, err = db.PrepareContext(ctx, getHouse); err != nil {
 		return nil, fmt.Errorf("error preparing query GetHouse: %w", err)
 	}
+	if q.getLastPublicationCheckTimeStmt, err = db.PrepareContext(ctx, getLastPublicationCheckTime); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastPublicationCheckTime: %w", err)
+	}
+	if q.getLastPublicationPriceStmt, err = db.PrepareContext(ctx, getLastPublicationPrice); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastPublicationPrice: %w", err)
+	}
+	if q.getLatestPublicationCheckStmt, err = db.PrepareContext(ctx, getLatestPublicationCheck); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestPublicationCheck: %w", err)
+	}
+	if q.getNeighbourhoodStmt, err = db.PrepareContext(ctx, getNeighbourhood); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNeighbourhood: %w", err)
+	}
+	if q.getNeighbourhoodByNameStmt, err = db.PrepareContext(ctx, getNeighbourhoodByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNeighbourhoodByName: %w", err)
+	}
+	if q.getPointOfInterestByNameStmt, err = db.PrepareContext(ctx, getPointOfInterestByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPointOfInterestByName: %w", err)
+	}
+	if q.getPublicationURLStmt, err = db.PrepareContext(ctx, getPublicationURL); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPublicationURL: %w", err)
+	}
 	if q.getPublicationURLsStmt, err = db.PrepareContext(ctx, getPublicationURLs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPublicationURLs: %w", err)
 	}
+	if q.getTagByNameStmt, err = db.PrepareContext(ctx, getTagByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTagByName: %w", err)
+	}
+	if q.listAllCustomValuesStmt, err = db.PrepareContext(ctx, listAllCustomValues); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllCustomValues: %w", err)
+	}
+	if q.listAllHouseTagsStmt, err = db.PrepareContext(ctx, listAllHouseTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllHouseTags: %w", err)
+	}
+	if q.listAllPublicationURLsStmt, err = db.PrepareContext(ctx, listAllPublicationURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllPublicationURLs: %w", err)
+	}
+	if q.listBANStreetAddressesStmt, err = db.PrepareContext(ctx, listBANStreetAddresses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBANStreetAddresses: %w", err)
+	}
 	if q.listCitiesStmt, err = db.PrepareContext(ctx, listCities); err != nil {
 		return nil, fmt.Errorf("error preparing query ListCities: %w", err)
 	}
+	if q.listCityNeighbourhoodsStmt, err = db.PrepareContext(ctx, listCityNeighbourhoods); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCityNeighbourhoods: %w", err)
+	}
+	if q.listCustomFieldUsedValuesStmt, err = db.PrepareContext(ctx, listCustomFieldUsedValues); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCustomFieldUsedValues: %w", err)
+	}
+	if q.listCustomFieldsStmt, err = db.PrepareContext(ctx, listCustomFields); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCustomFields: %w", err)
+	}
+	if q.listDueFollowUpsStmt, err = db.PrepareContext(ctx, listDueFollowUps); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueFollowUps: %w", err)
+	}
+	if q.listDueTasksStmt, err = db.PrepareContext(ctx, listDueTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueTasks: %w", err)
+	}
+	if q.listDuplicateDismissalsStmt, err = db.PrepareContext(ctx, listDuplicateDismissals); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDuplicateDismissals: %w", err)
+	}
+	if q.listHouseCustomValuesStmt, err = db.PrepareContext(ctx, listHouseCustomValues); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseCustomValues: %w", err)
+	}
+	if q.listHouseNotificationsStmt, err = db.PrepareContext(ctx, listHouseNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseNotifications: %w", err)
+	}
+	if q.listHousePublicationChecksStmt, err = db.PrepareContext(ctx, listHousePublicationChecks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHousePublicationChecks: %w", err)
+	}
+	if q.listHouseTagsStmt, err = db.PrepareContext(ctx, listHouseTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseTags: %w", err)
+	}
+	if q.listHouseTasksStmt, err = db.PrepareContext(ctx, listHouseTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHouseTasks: %w", err)
+	}
 	if q.listHousesStmt, err = db.PrepareContext(ctx, listHouses); err != nil {
 		return nil, fmt.Errorf("error preparing query ListHouses: %w", err)
 	}
+	if q.listJournalEntriesStmt, err = db.PrepareContext(ctx, listJournalEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListJournalEntries: %w", err)
+	}
+	if q.listLatestPublicationChecksStmt, err = db.PrepareContext(ctx, listLatestPublicationChecks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLatestPublicationChecks: %w", err)
+	}
+	if q.listMapBoundariesInBoxStmt, err = db.PrepareContext(ctx, listMapBoundariesInBox); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMapBoundariesInBox: %w", err)
+	}
+	if q.listNeighbourhoodsStmt, err = db.PrepareContext(ctx, listNeighbourhoods); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNeighbourhoods: %w", err)
+	}
+	if q.listNotificationsStmt, err = db.PrepareContext(ctx, listNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNotifications: %w", err)
+	}
+	if q.listPointsOfInterestStmt, err = db.PrepareContext(ctx, listPointsOfInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPointsOfInterest: %w", err)
+	}
+	if q.listTagsStmt, err = db.PrepareContext(ctx, listTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTags: %w", err)
+	}
+	if q.listTasksStmt, err = db.PrepareContext(ctx, listTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTasks: %w", err)
+	}
+	if q.markAllNotificationsReadStmt, err = db.PrepareContext(ctx, markAllNotificationsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllNotificationsRead: %w", err)
+	}
+	if q.markNotificationReadStmt, err = db.PrepareContext(ctx, markNotificationRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationRead: %w", err)
+	}
+	if q.moveCityHousesStmt, err = db.PrepareContext(ctx, moveCityHouses); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveCityHouses: %w", err)
+	}
+	if q.moveCityNeighbourhoodsStmt, err = db.PrepareContext(ctx, moveCityNeighbourhoods); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveCityNeighbourhoods: %w", err)
+	}
+	if q.moveJournalEntriesStmt, err = db.PrepareContext(ctx, moveJournalEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveJournalEntries: %w", err)
+	}
+	if q.moveNeighbourhoodHousesStmt, err = db.PrepareContext(ctx, moveNeighbourhoodHouses); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveNeighbourhoodHouses: %w", err)
+	}
+	if q.moveNotificationsStmt, err = db.PrepareContext(ctx, moveNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveNotifications: %w", err)
+	}
+	if q.movePublicationURLsStmt, err = db.PrepareContext(ctx, movePublicationURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query MovePublicationURLs: %w", err)
+	}
+	if q.moveTasksStmt, err = db.PrepareContext(ctx, moveTasks); err != nil {
+		return nil, fmt.Errorf("error preparing query MoveTasks: %w", err)
+	}
+	if q.renameNeighbourhoodStmt, err = db.PrepareContext(ctx, renameNeighbourhood); err != nil {
+		return nil, fmt.Errorf("error preparing query RenameNeighbourhood: %w", err)
+	}
+	if q.searchCitiesStmt, err = db.PrepareContext(ctx, searchCities); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchCities: %w", err)
+	}
+	if q.setHouseCoordinatesStmt, err = db.PrepareContext(ctx, setHouseCoordinates); err != nil {
+		return nil, fmt.Errorf("error preparing query SetHouseCoordinates: %w", err)
+	}
+	if q.setTaskDoneStmt, err = db.PrepareContext(ctx, setTaskDone); err != nil {
+		return nil, fmt.Errorf("error preparing query SetTaskDone: %w", err)
+	}
 	if q.updateCityStmt, err = db.PrepareContext(ctx, updateCity); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCity: %w", err)
 	}
+	if q.updateCustomFieldStmt, err = db.PrepareContext(ctx, updateCustomField); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCustomField: %w", err)
+	}
 	if q.updateHouseStmt, err = db.PrepareContext(ctx, updateHouse); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateHouse: %w", err)
 	}
+	if q.updatePointOfInterestStmt, err = db.PrepareContext(ctx, updatePointOfInterest); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdatePointOfInterest: %w", err)
+	}
 	if q.updatePublicationURLStmt, err = db.PrepareContext(ctx, updatePublicationURL); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdatePublicationURL: %w", err)
 	}
+	if q.updateTagStmt, err = db.PrepareContext(ctx, updateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTag: %w", err)
+	}
+	if q.upsertBANAddressStmt, err = db.PrepareContext(ctx, upsertBANAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertBANAddress: %w", err)
+	}
+	if q.upsertMapBoundaryStmt, err = db.PrepareContext(ctx, upsertMapBoundary); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertMapBoundary: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addHouseTagStmt != nil {
+		if cerr := q.addHouseTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addHouseTagStmt: %w", cerr)
+		}
+	}
+	if q.completeFollowUpStmt != nil {
+		if cerr := q.completeFollowUpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeFollowUpStmt: %w", cerr)
+		}
+	}
+	if q.countBANAddressesStmt != nil {
+		if cerr := q.countBANAddressesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countBANAddressesStmt: %w", cerr)
+		}
+	}
+	if q.countMapBoundariesStmt != nil {
+		if cerr := q.countMapBoundariesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countMapBoundariesStmt: %w", cerr)
+		}
+	}
+	if q.countOverdueTasksStmt != nil {
+		if cerr := q.countOverdueTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOverdueTasksStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadNotificationsStmt != nil {
+		if cerr := q.countUnreadNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadNotificationsStmt: %w", cerr)
+		}
+	}
 	if q.createCityStmt != nil {
 		if cerr := q.createCityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCityStmt: %w", cerr)
 		}
 	}
+	if q.createCustomFieldStmt != nil {
+		if cerr := q.createCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCustomFieldStmt: %w", cerr)
+		}
+	}
+	if q.createCustomValueStmt != nil {
+		if cerr := q.createCustomValueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCustomValueStmt: %w", cerr)
+		}
+	}
+	if q.createDuplicateDismissalStmt != nil {
+		if cerr := q.createDuplicateDismissalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createDuplicateDismissalStmt: %w", cerr)
+		}
+	}
 	if q.createHouseStmt != nil {
 		if cerr := q.createHouseStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createHouseStmt: %w", cerr)
 		}
 	}
+	if q.createJournalEntryStmt != nil {
+		if cerr := q.createJournalEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJournalEntryStmt: %w", cerr)
+		}
+	}
+	if q.createNeighbourhoodStmt != nil {
+		if cerr := q.createNeighbourhoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNeighbourhoodStmt: %w", cerr)
+		}
+	}
+	if q.createNotificationStmt != nil {
+		if cerr := q.createNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationStmt: %w", cerr)
+		}
+	}
+	if q.createPointOfInterestStmt != nil {
+		if cerr := q.createPointOfInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPointOfInterestStmt: %w", cerr)
+		}
+	}
+	if q.createPublicationCheckStmt != nil {
+		if cerr := q.createPublicationCheckStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPublicationCheckStmt: %w", cerr)
+		}
+	}
 	if q.createPublicationURLStmt != nil {
 		if cerr := q.createPublicationURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createPublicationURLStmt: %w", cerr)
 		}
 	}
+	if q.createTagStmt != nil {
+		if cerr := q.createTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTagStmt: %w", cerr)
+		}
+	}
+	if q.createTaskStmt != nil {
+		if cerr := q.createTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTaskStmt: %w", cerr)
+		}
+	}
 	if q.deleteAllPublicationURLsStmt != nil {
 		if cerr := q.deleteAllPublicationURLsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAllPublicationURLsStmt: %w", cerr)
@@ -99,56 +416,371 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteCityStmt: %w", cerr)
 		}
 	}
+	if q.deleteCustomFieldStmt != nil {
+		if cerr := q.deleteCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCustomFieldStmt: %w", cerr)
+		}
+	}
+	if q.deleteDuplicatePublicationURLsStmt != nil {
+		if cerr := q.deleteDuplicatePublicationURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDuplicatePublicationURLsStmt: %w", cerr)
+		}
+	}
 	if q.deleteHouseStmt != nil {
 		if cerr := q.deleteHouseStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteHouseStmt: %w", cerr)
 		}
 	}
+	if q.deleteHouseCustomValuesStmt != nil {
+		if cerr := q.deleteHouseCustomValuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteHouseCustomValuesStmt: %w", cerr)
+		}
+	}
+	if q.deleteHouseTagsStmt != nil {
+		if cerr := q.deleteHouseTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteHouseTagsStmt: %w", cerr)
+		}
+	}
+	if q.deleteJournalEntryStmt != nil {
+		if cerr := q.deleteJournalEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteJournalEntryStmt: %w", cerr)
+		}
+	}
+	if q.deleteMapBoundariesStmt != nil {
+		if cerr := q.deleteMapBoundariesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMapBoundariesStmt: %w", cerr)
+		}
+	}
+	if q.deleteNeighbourhoodStmt != nil {
+		if cerr := q.deleteNeighbourhoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNeighbourhoodStmt: %w", cerr)
+		}
+	}
+	if q.deletePointOfInterestStmt != nil {
+		if cerr := q.deletePointOfInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePointOfInterestStmt: %w", cerr)
+		}
+	}
 	if q.deletePublicationURLStmt != nil {
 		if cerr := q.deletePublicationURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deletePublicationURLStmt: %w", cerr)
 		}
 	}
+	if q.deleteTagStmt != nil {
+		if cerr := q.deleteTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTagStmt: %w", cerr)
+		}
+	}
+	if q.deleteTaskStmt != nil {
+		if cerr := q.deleteTaskStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTaskStmt: %w", cerr)
+		}
+	}
 	if q.getCityStmt != nil {
 		if cerr := q.getCityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCityStmt: %w", cerr)
 		}
 	}
+	if q.getCityByNameStmt != nil {
+		if cerr := q.getCityByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCityByNameStmt: %w", cerr)
+		}
+	}
+	if q.getCityByNameAndPostalCodeStmt != nil {
+		if cerr := q.getCityByNameAndPostalCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCityByNameAndPostalCodeStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldStmt != nil {
+		if cerr := q.getCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldStmt: %w", cerr)
+		}
+	}
+	if q.getCustomFieldByNameStmt != nil {
+		if cerr := q.getCustomFieldByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCustomFieldByNameStmt: %w", cerr)
+		}
+	}
 	if q.getHouseStmt != nil {
 		if cerr := q.getHouseStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getHouseStmt: %w", cerr)
 		}
 	}
+	if q.getLastPublicationCheckTimeStmt != nil {
+		if cerr := q.getLastPublicationCheckTimeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastPublicationCheckTimeStmt: %w", cerr)
+		}
+	}
+	if q.getLastPublicationPriceStmt != nil {
+		if cerr := q.getLastPublicationPriceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastPublicationPriceStmt: %w", cerr)
+		}
+	}
+	if q.getLatestPublicationCheckStmt != nil {
+		if cerr := q.getLatestPublicationCheckStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestPublicationCheckStmt: %w", cerr)
+		}
+	}
+	if q.getNeighbourhoodStmt != nil {
+		if cerr := q.getNeighbourhoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNeighbourhoodStmt: %w", cerr)
+		}
+	}
+	if q.getNeighbourhoodByNameStmt != nil {
+		if cerr := q.getNeighbourhoodByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNeighbourhoodByNameStmt: %w", cerr)
+		}
+	}
+	if q.getPointOfInterestByNameStmt != nil {
+		if cerr := q.getPointOfInterestByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPointOfInterestByNameStmt: %w", cerr)
+		}
+	}
+	if q.getPublicationURLStmt != nil {
+		if cerr := q.getPublicationURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPublicationURLStmt: %w", cerr)
+		}
+	}
 	if q.getPublicationURLsStmt != nil {
 		if cerr := q.getPublicationURLsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getPublicationURLsStmt: %w", cerr)
 		}
 	}
+	if q.getTagByNameStmt != nil {
+		if cerr := q.getTagByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagByNameStmt: %w", cerr)
+		}
+	}
+	if q.listAllCustomValuesStmt != nil {
+		if cerr := q.listAllCustomValuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllCustomValuesStmt: %w", cerr)
+		}
+	}
+	if q.listAllHouseTagsStmt != nil {
+		if cerr := q.listAllHouseTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllHouseTagsStmt: %w", cerr)
+		}
+	}
+	if q.listAllPublicationURLsStmt != nil {
+		if cerr := q.listAllPublicationURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllPublicationURLsStmt: %w", cerr)
+		}
+	}
+	if q.listBANStreetAddressesStmt != nil {
+		if cerr := q.listBANStreetAddressesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBANStreetAddressesStmt: %w", cerr)
+		}
+	}
 	if q.listCitiesStmt != nil {
 		if cerr := q.listCitiesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listCitiesStmt: %w", cerr)
 		}
 	}
+	if q.listCityNeighbourhoodsStmt != nil {
+		if cerr := q.listCityNeighbourhoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCityNeighbourhoodsStmt: %w", cerr)
+		}
+	}
+	if q.listCustomFieldUsedValuesStmt != nil {
+		if cerr := q.listCustomFieldUsedValuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCustomFieldUsedValuesStmt: %w", cerr)
+		}
+	}
+	if q.listCustomFieldsStmt != nil {
+		if cerr := q.listCustomFieldsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCustomFieldsStmt: %w", cerr)
+		}
+	}
+	if q.listDueFollowUpsStmt != nil {
+		if cerr := q.listDueFollowUpsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueFollowUpsStmt: %w", cerr)
+		}
+	}
+	if q.listDueTasksStmt != nil {
+		if cerr := q.listDueTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueTasksStmt: %w", cerr)
+		}
+	}
+	if q.listDuplicateDismissalsStmt != nil {
+		if cerr := q.listDuplicateDismissalsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDuplicateDismissalsStmt: %w", cerr)
+		}
+	}
+	if q.listHouseCustomValuesStmt != nil {
+		if cerr := q.listHouseCustomValuesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseCustomValuesStmt: %w", cerr)
+		}
+	}
+	if q.listHouseNotificationsStmt != nil {
+		if cerr := q.listHouseNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.listHousePublicationChecksStmt != nil {
+		if cerr := q.listHousePublicationChecksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHousePublicationChecksStmt: %w", cerr)
+		}
+	}
+	if q.listHouseTagsStmt != nil {
+		if cerr := q.listHouseTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseTagsStmt: %w", cerr)
+		}
+	}
+	if q.listHouseTasksStmt != nil {
+		if cerr := q.listHouseTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHouseTasksStmt: %w", cerr)
+		}
+	}
 	if q.listHousesStmt != nil {
 		if cerr := q.listHousesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listHousesStmt: %w", cerr)
 		}
 	}
+	if q.listJournalEntriesStmt != nil {
+		if cerr := q.listJournalEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listJournalEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listLatestPublicationChecksStmt != nil {
+		if cerr := q.listLatestPublicationChecksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLatestPublicationChecksStmt: %w", cerr)
+		}
+	}
+	if q.listMapBoundariesInBoxStmt != nil {
+		if cerr := q.listMapBoundariesInBoxStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMapBoundariesInBoxStmt: %w", cerr)
+		}
+	}
+	if q.listNeighbourhoodsStmt != nil {
+		if cerr := q.listNeighbourhoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNeighbourhoodsStmt: %w", cerr)
+		}
+	}
+	if q.listNotificationsStmt != nil {
+		if cerr := q.listNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.listPointsOfInterestStmt != nil {
+		if cerr := q.listPointsOfInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPointsOfInterestStmt: %w", cerr)
+		}
+	}
+	if q.listTagsStmt != nil {
+		if cerr := q.listTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTagsStmt: %w", cerr)
+		}
+	}
+	if q.listTasksStmt != nil {
+		if cerr := q.listTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTasksStmt: %w", cerr)
+		}
+	}
+	if q.markAllNotificationsReadStmt != nil {
+		if cerr := q.markAllNotificationsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllNotificationsReadStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationReadStmt != nil {
+		if cerr := q.markNotificationReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationReadStmt: %w", cerr)
+		}
+	}
+	if q.moveCityHousesStmt != nil {
+		if cerr := q.moveCityHousesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveCityHousesStmt: %w", cerr)
+		}
+	}
+	if q.moveCityNeighbourhoodsStmt != nil {
+		if cerr := q.moveCityNeighbourhoodsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveCityNeighbourhoodsStmt: %w", cerr)
+		}
+	}
+	if q.moveJournalEntriesStmt != nil {
+		if cerr := q.moveJournalEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveJournalEntriesStmt: %w", cerr)
+		}
+	}
+	if q.moveNeighbourhoodHousesStmt != nil {
+		if cerr := q.moveNeighbourhoodHousesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveNeighbourhoodHousesStmt: %w", cerr)
+		}
+	}
+	if q.moveNotificationsStmt != nil {
+		if cerr := q.moveNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.movePublicationURLsStmt != nil {
+		if cerr := q.movePublicationURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing movePublicationURLsStmt: %w", cerr)
+		}
+	}
+	if q.moveTasksStmt != nil {
+		if cerr := q.moveTasksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing moveTasksStmt: %w", cerr)
+		}
+	}
+	if q.renameNeighbourhoodStmt != nil {
+		if cerr := q.renameNeighbourhoodStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing renameNeighbourhoodStmt: %w", cerr)
+		}
+	}
+	if q.searchCitiesStmt != nil {
+		if cerr := q.searchCitiesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchCitiesStmt: %w", cerr)
+		}
+	}
+	if q.setHouseCoordinatesStmt != nil {
+		if cerr := q.setHouseCoordinatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setHouseCoordinatesStmt: %w", cerr)
+		}
+	}
+	if q.setTaskDoneStmt != nil {
+		if cerr := q.setTaskDoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setTaskDoneStmt: %w", cerr)
+		}
+	}
 	if q.updateCityStmt != nil {
 		if cerr := q.updateCityStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCityStmt: %w", cerr)
 		}
 	}
+	if q.updateCustomFieldStmt != nil {
+		if cerr := q.updateCustomFieldStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCustomFieldStmt: %w", cerr)
+		}
+	}
 	if q.updateHouseStmt != nil {
 		if cerr := q.updateHouseStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateHouseStmt: %w", cerr)
 		}
 	}
+	if q.updatePointOfInterestStmt != nil {
+		if cerr := q.updatePointOfInterestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updatePointOfInterestStmt: %w", cerr)
+		}
+	}
 	if q.updatePublicationURLStmt != nil {
 		if cerr := q.updatePublicationURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updatePublicationURLStmt: %w", cerr)
 		}
 	}
+	if q.updateTagStmt != nil {
+		if cerr := q.updateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTagStmt: %w", cerr)
+		}
+	}
+	if q.upsertBANAddressStmt != nil {
+		if cerr := q.upsertBANAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertBANAddressStmt: %w", cerr)
+		}
+	}
+	if q.upsertMapBoundaryStmt != nil {
+		if cerr := q.upsertMapBoundaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertMapBoundaryStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -186,43 +818,201 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                           DBTX
-	tx                           *sql.Tx
-	createCityStmt               *sql.Stmt
-	createHouseStmt              *sql.Stmt
-	createPublicationURLStmt     *sql.Stmt
-	deleteAllPublicationURLsStmt *sql.Stmt
-	deleteCityStmt               *sql.Stmt
-	deleteHouseStmt              *sql.Stmt
-	deletePublicationURLStmt     *sql.Stmt
-	getCityStmt                  *sql.Stmt
-	getHouseStmt                 *sql.Stmt
-	getPublicationURLsStmt       *sql.Stmt
-	listCitiesStmt               *sql.Stmt
-	listHousesStmt               *sql.Stmt
-	updateCityStmt               *sql.Stmt
-	updateHouseStmt              *sql.Stmt
-	updatePublicationURLStmt     *sql.Stmt
+	db                                 DBTX
+	tx                                 *sql.Tx
+	addHouseTagStmt                    *sql.Stmt
+	completeFollowUpStmt               *sql.Stmt
+	countBANAddressesStmt              *sql.Stmt
+	countMapBoundariesStmt             *sql.Stmt
+	countOverdueTasksStmt              *sql.Stmt
+	countUnreadNotificationsStmt       *sql.Stmt
+	createCityStmt                     *sql.Stmt
+	createCustomFieldStmt              *sql.Stmt
+	createCustomValueStmt              *sql.Stmt
+	createDuplicateDismissalStmt       *sql.Stmt
+	createHouseStmt                    *sql.Stmt
+	createJournalEntryStmt             *sql.Stmt
+	createNeighbourhoodStmt            *sql.Stmt
+	createNotificationStmt             *sql.Stmt
+	createPointOfInterestStmt          *sql.Stmt
+	createPublicationCheckStmt         *sql.Stmt
+	createPublicationURLStmt           *sql.Stmt
+	createTagStmt                      *sql.Stmt
+	createTaskStmt                     *sql.Stmt
+	deleteAllPublicationURLsStmt       *sql.Stmt
+	deleteCityStmt                     *sql.Stmt
+	deleteCustomFieldStmt              *sql.Stmt
+	deleteDuplicatePublicationURLsStmt *sql.Stmt
+	deleteHouseStmt                    *sql.Stmt
+	deleteHouseCustomValuesStmt        *sql.Stmt
+	deleteHouseTagsStmt                *sql.Stmt
+	deleteJournalEntryStmt             *sql.Stmt
+	deleteMapBoundariesStmt            *sql.Stmt
+	deleteNeighbourhoodStmt            *sql.Stmt
+	deletePointOfInterestStmt          *sql.Stmt
+	deletePublicationURLStmt           *sql.Stmt
+	deleteTagStmt                      *sql.Stmt
+	deleteTaskStmt                     *sql.Stmt
+	getCityStmt                        *sql.Stmt
+	getCityByNameStmt                  *sql.Stmt
+	getCityByNameAndPostalCodeStmt     *sql.Stmt
+	getCustomFieldStmt                 *sql.Stmt
+	getCustomFieldByNameStmt           *sql.Stmt
+	getHouseStmt                       *sql.Stmt
+	getLastPublicationCheckTimeStmt    *sql.Stmt
+	getLastPublicationPriceStmt        *sql.Stmt
+	getLatestPublicationCheckStmt      *sql.Stmt
+	getNeighbourhoodStmt               *sql.Stmt
+	getNeighbourhoodByNameStmt         *sql.Stmt
+	getPointOfInterestByNameStmt       *sql.Stmt
+	getPublicationURLStmt              *sql.Stmt
+	getPublicationURLsStmt             *sql.Stmt
+	getTagByNameStmt                   *sql.Stmt
+	listAllCustomValuesStmt            *sql.Stmt
+	listAllHouseTagsStmt               *sql.Stmt
+	listAllPublicationURLsStmt         *sql.Stmt
+	listBANStreetAddressesStmt         *sql.Stmt
+	listCitiesStmt                     *sql.Stmt
+	listCityNeighbourhoodsStmt         *sql.Stmt
+	listCustomFieldUsedValuesStmt      *sql.Stmt
+	listCustomFieldsStmt               *sql.Stmt
+	listDueFollowUpsStmt               *sql.Stmt
+	listDueTasksStmt                   *sql.Stmt
+	listDuplicateDismissalsStmt        *sql.Stmt
+	listHouseCustomValuesStmt          *sql.Stmt
+	listHouseNotificationsStmt         *sql.Stmt
+	listHousePublicationChecksStmt     *sql.Stmt
+	listHouseTagsStmt                  *sql.Stmt
+	listHouseTasksStmt                 *sql.Stmt
+	listHousesStmt                     *sql.Stmt
+	listJournalEntriesStmt             *sql.Stmt
+	listLatestPublicationChecksStmt    *sql.Stmt
+	listMapBoundariesInBoxStmt         *sql.Stmt
+	listNeighbourhoodsStmt             *sql.Stmt
+	listNotificationsStmt              *sql.Stmt
+	listPointsOfInterestStmt           *sql.Stmt
+	listTagsStmt                       *sql.Stmt
+	listTasksStmt                      *sql.Stmt
+	markAllNotificationsReadStmt       *sql.Stmt
+	markNotificationReadStmt           *sql.Stmt
+	moveCityHousesStmt                 *sql.Stmt
+	moveCityNeighbourhoodsStmt         *sql.Stmt
+	moveJournalEntriesStmt             *sql.Stmt
+	moveNeighbourhoodHousesStmt        *sql.Stmt
+	moveNotificationsStmt              *sql.Stmt
+	movePublicationURLsStmt            *sql.Stmt
+	moveTasksStmt                      *sql.Stmt
+	renameNeighbourhoodStmt            *sql.Stmt
+	searchCitiesStmt                   *sql.Stmt
+	setHouseCoordinatesStmt            *sql.Stmt
+	setTaskDoneStmt                    *sql.Stmt
+	updateCityStmt                     *sql.Stmt
+	updateCustomFieldStmt              *sql.Stmt
+	updateHouseStmt                    *sql.Stmt
+	updatePointOfInterestStmt          *sql.Stmt
+	updatePublicationURLStmt           *sql.Stmt
+	updateTagStmt                      *sql.Stmt
+	upsertBANAddressStmt               *sql.Stmt
+	upsertMapBoundaryStmt              *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                           tx,
-		tx:                           tx,
-		createCityStmt:               q.createCityStmt,
-		createHouseStmt:              q.createHouseStmt,
-		createPublicationURLStmt:     q.createPublicationURLStmt,
-		deleteAllPublicationURLsStmt: q.deleteAllPublicationURLsStmt,
-		deleteCityStmt:               q.deleteCityStmt,
-		deleteHouseStmt:              q.deleteHouseStmt,
-		deletePublicationURLStmt:     q.deletePublicationURLStmt,
-		getCityStmt:                  q.getCityStmt,
-		getHouseStmt:                 q.getHouseStmt,
-		getPublicationURLsStmt:       q.getPublicationURLsStmt,
-		listCitiesStmt:               q.listCitiesStmt,
-		listHousesStmt:               q.listHousesStmt,
-		updateCityStmt:               q.updateCityStmt,
-		updateHouseStmt:              q.updateHouseStmt,
-		updatePublicationURLStmt:     q.updatePublicationURLStmt,
+		db:                                 tx,
+		tx:                                 tx,
+		addHouseTagStmt:                    q.addHouseTagStmt,
+		completeFollowUpStmt:               q.completeFollowUpStmt,
+		countBANAddressesStmt:              q.countBANAddressesStmt,
+		countMapBoundariesStmt:             q.countMapBoundariesStmt,
+		countOverdueTasksStmt:              q.countOverdueTasksStmt,
+		countUnreadNotificationsStmt:       q.countUnreadNotificationsStmt,
+		createCityStmt:                     q.createCityStmt,
+		createCustomFieldStmt:              q.createCustomFieldStmt,
+		createCustomValueStmt:              q.createCustomValueStmt,
+		createDuplicateDismissalStmt:       q.createDuplicateDismissalStmt,
+		createHouseStmt:                    q.createHouseStmt,
+		createJournalEntryStmt:             q.createJournalEntryStmt,
+		createNeighbourhoodStmt:            q.createNeighbourhoodStmt,
+		createNotificationStmt:             q.createNotificationStmt,
+		createPointOfInterestStmt:          q.createPointOfInterestStmt,
+		createPublicationCheckStmt:         q.createPublicationCheckStmt,
+		createPublicationURLStmt:           q.createPublicationURLStmt,
+		createTagStmt:                      q.createTagStmt,
+		createTaskStmt:                     q.createTaskStmt,
+		deleteAllPublicationURLsStmt:       q.deleteAllPublicationURLsStmt,
+		deleteCityStmt:                     q.deleteCityStmt,
+		deleteCustomFieldStmt:              q.deleteCustomFieldStmt,
+		deleteDuplicatePublicationURLsStmt: q.deleteDuplicatePublicationURLsStmt,
+		deleteHouseStmt:                    q.deleteHouseStmt,
+		deleteHouseCustomValuesStmt:        q.deleteHouseCustomValuesStmt,
+		deleteHouseTagsStmt:                q.deleteHouseTagsStmt,
+		deleteJournalEntryStmt:             q.deleteJournalEntryStmt,
+		deleteMapBoundariesStmt:            q.deleteMapBoundariesStmt,
+		deleteNeighbourhoodStmt:            q.deleteNeighbourhoodStmt,
+		deletePointOfInterestStmt:          q.deletePointOfInterestStmt,
+		deletePublicationURLStmt:           q.deletePublicationURLStmt,
+		deleteTagStmt:                      q.deleteTagStmt,
+		deleteTaskStmt:                     q.deleteTaskStmt,
+		getCityStmt:                        q.getCityStmt,
+		getCityByNameStmt:                  q.getCityByNameStmt,
+		getCityByNameAndPostalCodeStmt:     q.getCityByNameAndPostalCodeStmt,
+		getCustomFieldStmt:                 q.getCustomFieldStmt,
+		getCustomFieldByNameStmt:           q.getCustomFieldByNameStmt,
+		getHouseStmt:                       q.getHouseStmt,
+		getLastPublicationCheckTimeStmt:    q.getLastPublicationCheckTimeStmt,
+		getLastPublicationPriceStmt:        q.getLastPublicationPriceStmt,
+		getLatestPublicationCheckStmt:      q.getLatestPublicationCheckStmt,
+		getNeighbourhoodStmt:               q.getNeighbourhoodStmt,
+		getNeighbourhoodByNameStmt:         q.getNeighbourhoodByNameStmt,
+		getPointOfInterestByNameStmt:       q.getPointOfInterestByNameStmt,
+		getPublicationURLStmt:              q.getPublicationURLStmt,
+		getPublicationURLsStmt:             q.getPublicationURLsStmt,
+		getTagByNameStmt:                   q.getTagByNameStmt,
+		listAllCustomValuesStmt:            q.listAllCustomValuesStmt,
+		listAllHouseTagsStmt:               q.listAllHouseTagsStmt,
+		listAllPublicationURLsStmt:         q.listAllPublicationURLsStmt,
+		listBANStreetAddressesStmt:         q.listBANStreetAddressesStmt,
+		listCitiesStmt:                     q.listCitiesStmt,
+		listCityNeighbourhoodsStmt:         q.listCityNeighbourhoodsStmt,
+		listCustomFieldUsedValuesStmt:      q.listCustomFieldUsedValuesStmt,
+		listCustomFieldsStmt:               q.listCustomFieldsStmt,
+		listDueFollowUpsStmt:               q.listDueFollowUpsStmt,
+		listDueTasksStmt:                   q.listDueTasksStmt,
+		listDuplicateDismissalsStmt:        q.listDuplicateDismissalsStmt,
+		listHouseCustomValuesStmt:          q.listHouseCustomValuesStmt,
+		listHouseNotificationsStmt:         q.listHouseNotificationsStmt,
+		listHousePublicationChecksStmt:     q.listHousePublicationChecksStmt,
+		listHouseTagsStmt:                  q.listHouseTagsStmt,
+		listHouseTasksStmt:                 q.listHouseTasksStmt,
+		listHousesStmt:                     q.listHousesStmt,
+		listJournalEntriesStmt:             q.listJournalEntriesStmt,
+		listLatestPublicationChecksStmt:    q.listLatestPublicationChecksStmt,
+		listMapBoundariesInBoxStmt:         q.listMapBoundariesInBoxStmt,
+		listNeighbourhoodsStmt:             q.listNeighbourhoodsStmt,
+		listNotificationsStmt:              q.listNotificationsStmt,
+		listPointsOfInterestStmt:           q.listPointsOfInterestStmt,
+		listTagsStmt:                       q.listTagsStmt,
+		listTasksStmt:                      q.listTasksStmt,
+		markAllNotificationsReadStmt:       q.markAllNotificationsReadStmt,
+		markNotificationReadStmt:           q.markNotificationReadStmt,
+		moveCityHousesStmt:                 q.moveCityHousesStmt,
+		moveCityNeighbourhoodsStmt:         q.moveCityNeighbourhoodsStmt,
+		moveJournalEntriesStmt:             q.moveJournalEntriesStmt,
+		moveNeighbourhoodHousesStmt:        q.moveNeighbourhoodHousesStmt,
+		moveNotificationsStmt:              q.moveNotificationsStmt,
+		movePublicationURLsStmt:            q.movePublicationURLsStmt,
+		moveTasksStmt:                      q.moveTasksStmt,
+		renameNeighbourhoodStmt:            q.renameNeighbourhoodStmt,
+		searchCitiesStmt:                   q.searchCitiesStmt,
+		setHouseCoordinatesStmt:            q.setHouseCoordinatesStmt,
+		setTaskDoneStmt:                    q.setTaskDoneStmt,
+		updateCityStmt:                     q.updateCityStmt,
+		updateCustomFieldStmt:              q.updateCustomFieldStmt,
+		updateHouseStmt:                    q.updateHouseStmt,
+		updatePointOfInterestStmt:          q.updatePointOfInterestStmt,
+		updatePublicationURLStmt:           q.updatePublicationURLStmt,
+		updateTagStmt:                      q.updateTagStmt,
+		upsertBANAddressStmt:               q.upsertBANAddressStmt,
+		upsertMapBoundaryStmt:              q.upsertMapBoundaryStmt,
 	}
 }
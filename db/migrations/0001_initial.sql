@@ -0,0 +1,105 @@
+CREATE TABLE IF NOT EXISTS cities (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS houses (
+    id INTEGER PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    title TEXT NOT NULL,
+    city_id INTEGER NOT NULL REFERENCES cities(id) ON DELETE RESTRICT,
+    address TEXT NOT NULL DEFAULT '',
+    price INTEGER NOT NULL,
+    surface INTEGER NOT NULL,
+    rooms INTEGER NOT NULL,
+    bedrooms INTEGER NOT NULL,
+    bathrooms INTEGER NOT NULL,
+    floors INTEGER NOT NULL,
+    construction_year INTEGER NOT NULL DEFAULT 0,
+    house_type TEXT NOT NULL, -- 'maison' or 'appartement'
+    land_surface INTEGER NOT NULL DEFAULT 0,
+    has_garage BOOLEAN NOT NULL DEFAULT FALSE,
+    outdoor_parking_spaces INTEGER NOT NULL DEFAULT 0,
+    main_photo TEXT NOT NULL DEFAULT '', -- filename of the main photo
+    notes TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS publication_urls (
+    id INTEGER PRIMARY KEY,
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    publication_date DATE NOT NULL
+);
+
+CREATE VIEW IF NOT EXISTS cities_with_used
+AS SELECT cities.*, CAST(EXISTS (SELECT 1 FROM houses WHERE houses.city_id = cities.id) AS BOOLEAN) AS is_used
+FROM cities;
+
+CREATE VIEW IF NOT EXISTS houses_with_cities
+AS SELECT houses.*, cities.name AS city_name
+FROM houses JOIN cities ON houses.city_id = cities.id;
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id INTEGER PRIMARY KEY,
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    entry_type TEXT NOT NULL, -- 'appel', 'email', 'message' or 'autre'
+    entry_date DATE NOT NULL,
+    contact TEXT NOT NULL DEFAULT '',
+    summary TEXT NOT NULL,
+    follow_up_date DATE NOT NULL DEFAULT '0001-01-01', -- zero value when no follow-up is planned
+    follow_up_done BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE VIEW IF NOT EXISTS journal_entries_with_houses
+AS SELECT journal_entries.*, houses.title AS house_title
+FROM journal_entries JOIN houses ON journal_entries.house_id = houses.id;
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id INTEGER PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    due_date DATE NOT NULL DEFAULT '0001-01-01', -- zero value when there is no due date
+    assignee TEXT NOT NULL DEFAULT '',
+    done BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE VIEW IF NOT EXISTS tasks_with_houses
+AS SELECT tasks.*, houses.title AS house_title
+FROM tasks JOIN houses ON tasks.house_id = houses.id;
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    color TEXT NOT NULL -- CSS hexadecimal color, such as '#9c4dcc'
+);
+
+CREATE TABLE IF NOT EXISTS house_tags (
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (house_id, tag_id)
+);
+
+CREATE VIEW IF NOT EXISTS tags_with_usage
+AS SELECT tags.*, (SELECT COUNT(*) FROM house_tags WHERE house_tags.tag_id = tags.id) AS house_count
+FROM tags;
+
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    field_type TEXT NOT NULL, -- 'entier', 'booleen', 'texte' or 'choix'
+    unit TEXT NOT NULL DEFAULT '',
+    choices TEXT NOT NULL DEFAULT '' -- one choice per line, for the 'choix' type
+);
+
+CREATE TABLE IF NOT EXISTS custom_field_values (
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    field_id INTEGER NOT NULL REFERENCES custom_fields(id) ON DELETE CASCADE,
+    value TEXT NOT NULL,
+    PRIMARY KEY (house_id, field_id)
+);
+
+CREATE VIEW IF NOT EXISTS custom_fields_with_usage
+AS SELECT custom_fields.*, (SELECT COUNT(*) FROM custom_field_values WHERE custom_field_values.field_id = custom_fields.id) AS value_count
+FROM custom_fields;
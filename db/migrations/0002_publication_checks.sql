@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS publication_checks (
+    id INTEGER PRIMARY KEY,
+    publication_url_id INTEGER NOT NULL REFERENCES publication_urls(id) ON DELETE CASCADE,
+    url TEXT NOT NULL, -- URL at the time of the check, the publication URL may be modified afterwards
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status_code INTEGER NOT NULL DEFAULT 0, -- zero value when the request failed
+    final_url TEXT NOT NULL DEFAULT '', -- URL reached after redirections
+    removed BOOLEAN NOT NULL DEFAULT FALSE,
+    price INTEGER NOT NULL DEFAULT 0, -- zero value when no price was found
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS publication_checks_publication_url_id ON publication_checks(publication_url_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    notification_type TEXT NOT NULL, -- 'annonce_retiree', 'annonce_retablie' or 'prix'
+    url TEXT NOT NULL,
+    old_price INTEGER NOT NULL DEFAULT 0,
+    new_price INTEGER NOT NULL DEFAULT 0,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE VIEW IF NOT EXISTS notifications_with_houses
+AS SELECT notifications.*, houses.title AS house_title
+FROM notifications JOIN houses ON notifications.house_id = houses.id;
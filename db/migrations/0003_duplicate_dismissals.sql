@@ -0,0 +1,7 @@
+-- Pairs of houses which have been marked as not being duplicates, the
+-- smallest house ID first
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    other_house_id INTEGER NOT NULL REFERENCES houses(id) ON DELETE CASCADE,
+    PRIMARY KEY (house_id, other_house_id)
+);
@@ -0,0 +1,32 @@
+-- Coordinates of the houses, zero values when unknown
+ALTER TABLE houses ADD COLUMN latitude REAL NOT NULL DEFAULT 0;
+ALTER TABLE houses ADD COLUMN longitude REAL NOT NULL DEFAULT 0;
+
+-- The view is recreated so that it includes the new columns
+DROP VIEW IF EXISTS houses_with_cities;
+CREATE VIEW houses_with_cities
+AS SELECT houses.*, cities.name AS city_name
+FROM houses JOIN cities ON houses.city_id = cities.id;
+
+-- Places distances are computed from, such as work, school or parents
+CREATE TABLE IF NOT EXISTS points_of_interest (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL
+);
+
+-- Addresses imported from the Base Adresse Nationale, used for offline
+-- geocoding. Street and city names are normalised: lower case, without
+-- accents nor punctuation, abbreviations expanded.
+CREATE TABLE IF NOT EXISTS ban_addresses (
+    id TEXT PRIMARY KEY, -- BAN identifier, so that a file can be imported again
+    number TEXT NOT NULL DEFAULT '', -- house number with its suffix, such as '12bis'
+    street TEXT NOT NULL,
+    postal_code TEXT NOT NULL DEFAULT '',
+    city_name TEXT NOT NULL,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ban_addresses_city_street ON ban_addresses(city_name, street);
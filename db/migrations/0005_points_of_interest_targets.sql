@@ -0,0 +1,5 @@
+-- Category of the points of interest, and the longest acceptable travel time
+-- from a house, used to compute the proximity score of the houses
+ALTER TABLE points_of_interest ADD COLUMN category TEXT NOT NULL DEFAULT 'autre';
+ALTER TABLE points_of_interest ADD COLUMN travel_mode TEXT NOT NULL DEFAULT 'voiture'; -- 'pied', 'velo' or 'voiture'
+ALTER TABLE points_of_interest ADD COLUMN max_travel_time INTEGER NOT NULL DEFAULT 0; -- in minutes, zero value when there is no target
@@ -0,0 +1,13 @@
+-- Boundaries of the cities, imported from a GeoJSON file, drawn on the map
+-- of the houses without any connection to internet
+CREATE TABLE IF NOT EXISTS map_boundaries (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    code TEXT NOT NULL DEFAULT '', -- INSEE code, zero value when unknown
+    rings TEXT NOT NULL, -- JSON array of rings, each an array of [longitude, latitude] positions
+    min_latitude REAL NOT NULL,
+    max_latitude REAL NOT NULL,
+    min_longitude REAL NOT NULL,
+    max_longitude REAL NOT NULL,
+    UNIQUE (name, code)
+);
@@ -0,0 +1,28 @@
+-- Details of the cities. Two cities may have the same name, such as the
+-- many Saint-Martin villages, as long as their postal codes differ: the
+-- table is rebuilt to replace the uniqueness of the name.
+DROP VIEW IF EXISTS cities_with_used;
+DROP VIEW IF EXISTS houses_with_cities;
+
+CREATE TABLE cities_new (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    postal_code TEXT NOT NULL DEFAULT '',
+    insee_code TEXT NOT NULL DEFAULT '',
+    department TEXT NOT NULL DEFAULT '',
+    population INTEGER NOT NULL DEFAULT 0, -- zero value when unknown
+    notes TEXT NOT NULL DEFAULT '', -- schools, transports, ambiance...
+    UNIQUE (name, postal_code)
+);
+
+INSERT INTO cities_new (id, name) SELECT id, name FROM cities;
+DROP TABLE cities;
+ALTER TABLE cities_new RENAME TO cities;
+
+CREATE VIEW cities_with_used
+AS SELECT cities.*, CAST(EXISTS (SELECT 1 FROM houses WHERE houses.city_id = cities.id) AS BOOLEAN) AS is_used
+FROM cities;
+
+CREATE VIEW houses_with_cities
+AS SELECT houses.*, cities.name AS city_name, cities.postal_code AS city_postal_code
+FROM houses JOIN cities ON houses.city_id = cities.id;
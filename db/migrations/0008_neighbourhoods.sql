@@ -0,0 +1,26 @@
+-- Neighbourhoods of the cities, where the neighbourhood matters more than
+-- the city itself. They are deleted with their city.
+CREATE TABLE IF NOT EXISTS neighbourhoods (
+    id INTEGER PRIMARY KEY,
+    city_id INTEGER NOT NULL REFERENCES cities(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    UNIQUE (city_id, name)
+);
+
+CREATE VIEW neighbourhoods_with_used
+AS SELECT neighbourhoods.*, cities.name AS city_name, cities.postal_code AS city_postal_code,
+    CAST(EXISTS (SELECT 1 FROM houses WHERE houses.neighbourhood_id = neighbourhoods.id) AS BOOLEAN) AS is_used
+FROM neighbourhoods JOIN cities ON neighbourhoods.city_id = cities.id;
+
+-- Neighbourhood of the houses, NULL rather than zero when unknown because of
+-- the foreign key
+ALTER TABLE houses ADD COLUMN neighbourhood_id INTEGER REFERENCES neighbourhoods(id) ON DELETE RESTRICT;
+
+-- The view is recreated so that it includes the new column and the name of
+-- the neighbourhood
+DROP VIEW IF EXISTS houses_with_cities;
+CREATE VIEW houses_with_cities
+AS SELECT houses.*, cities.name AS city_name, cities.postal_code AS city_postal_code,
+    CAST(COALESCE(neighbourhoods.name, '') AS TEXT) AS neighbourhood_name
+FROM houses JOIN cities ON houses.city_id = cities.id
+LEFT JOIN neighbourhoods ON houses.neighbourhood_id = neighbourhoods.id;
@@ -5,13 +5,65 @@
 package db
 
 import (
+	"database/sql"
 	"time"
 )
 
+type BANAddress struct {
+	ID         string
+	Number     string
+	Street     string
+	PostalCode string
+	CityName   string
+	Latitude   float64
+	Longitude  float64
+}
+
 type City struct {
-	ID     int64
-	Name   string
-	IsUsed bool
+	ID         int64
+	Name       string
+	PostalCode string
+	INSEECode  string
+	Department string
+	Population int64
+	Notes      string
+	IsUsed     bool
+}
+
+type CustomField struct {
+	ID         int64
+	Name       string
+	FieldType  string
+	Unit       string
+	Choices    string
+	ValueCount int64
+}
+
+type CustomFieldValue struct {
+	HouseID int64
+	FieldID int64
+	Value   string
+}
+
+type DBPointOfInterest struct {
+	ID            int64
+	Name          string
+	Latitude      float64
+	Longitude     float64
+	Category      string
+	TravelMode    string
+	MaxTravelTime int64
+}
+
+type DBTag struct {
+	ID    int64
+	Name  string
+	Color string
+}
+
+type DuplicateDismissal struct {
+	HouseID      int64
+	OtherHouseID int64
 }
 
 type House struct {
@@ -34,7 +86,68 @@ type House struct {
 	OutdoorParkingSpaces int64
 	MainPhoto            string
 	Notes                string
+	Latitude             float64
+	Longitude            float64
+	NeighbourhoodID      sql.NullInt64
 	CityName             string
+	CityPostalCode       string
+	NeighbourhoodName    string
+}
+
+type JournalEntry struct {
+	ID           int64
+	HouseID      int64
+	EntryType    string
+	EntryDate    time.Time
+	Contact      string
+	Summary      string
+	FollowUpDate time.Time
+	FollowUpDone bool
+	HouseTitle   string
+}
+
+type MapBoundary struct {
+	ID           int64
+	Name         string
+	Code         string
+	Rings        string
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+type Neighbourhood struct {
+	ID             int64
+	CityID         int64
+	Name           string
+	CityName       string
+	CityPostalCode string
+	IsUsed         bool
+}
+
+type Notification struct {
+	ID               int64
+	CreatedAt        time.Time
+	HouseID          int64
+	NotificationType string
+	URL              string
+	OldPrice         int64
+	NewPrice         int64
+	IsRead           bool
+	HouseTitle       string
+}
+
+type PublicationCheck struct {
+	ID               int64
+	PublicationURLID int64
+	URL              string
+	CheckedAt        time.Time
+	StatusCode       int64
+	FinalURL         string
+	Removed          bool
+	Price            int64
+	Error            string
 }
 
 type PublicationURL struct {
@@ -43,3 +156,21 @@ type PublicationURL struct {
 	URL             string
 	PublicationDate time.Time
 }
+
+type Tag struct {
+	ID         int64
+	Name       string
+	Color      string
+	HouseCount int64
+}
+
+type Task struct {
+	ID         int64
+	CreatedAt  time.Time
+	HouseID    int64
+	Title      string
+	DueDate    time.Time
+	Assignee   string
+	Done       bool
+	HouseTitle string
+}
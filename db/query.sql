@@ -2,27 +2,101 @@
 SELECT * FROM cities_with_used
 WHERE id = ? LIMIT 1;
 
+-- name: GetCityByName :one
+SELECT * FROM cities_with_used
+WHERE name = ?
+ORDER BY id LIMIT 1;
+
+-- name: GetCityByNameAndPostalCode :one
+SELECT * FROM cities_with_used
+WHERE name = ? AND postal_code = ? LIMIT 1;
+
 -- name: ListCities :many
 SELECT * FROM cities_with_used
-ORDER BY name;
+ORDER BY name, postal_code;
+
+-- name: SearchCities :many
+SELECT * FROM cities_with_used
+WHERE name LIKE '%' || CAST(sqlc.arg(search) AS TEXT) || '%' OR postal_code LIKE CAST(sqlc.arg(search) AS TEXT) || '%'
+ORDER BY is_used DESC, name, postal_code
+LIMIT 20;
 
 -- name: CreateCity :exec
 INSERT INTO cities (
-	name
+	name,
+	postal_code,
+	insee_code,
+	department,
+	population,
+	notes
 ) VALUES (
-	?
+	?, ?, ?, ?, ?, ?
 )
 RETURNING *;
 
 -- name: UpdateCity :exec
 UPDATE cities
-SET name = ?
+SET name = ?,
+	postal_code = ?,
+	insee_code = ?,
+	department = ?,
+	population = ?,
+	notes = ?
 WHERE cities.id = sqlc.arg(id);
 
 -- name: DeleteCity :exec
 DELETE FROM cities
 WHERE id = ?;
 
+-- name: MoveCityHouses :exec
+UPDATE houses
+SET city_id = sqlc.arg(target_id)
+WHERE city_id = sqlc.arg(source_id);
+
+-- name: GetNeighbourhood :one
+SELECT * FROM neighbourhoods_with_used
+WHERE id = ? LIMIT 1;
+
+-- name: GetNeighbourhoodByName :one
+SELECT * FROM neighbourhoods_with_used
+WHERE city_id = ? AND name = ? LIMIT 1;
+
+-- name: ListNeighbourhoods :many
+SELECT * FROM neighbourhoods_with_used
+ORDER BY city_name, city_postal_code, name;
+
+-- name: ListCityNeighbourhoods :many
+SELECT * FROM neighbourhoods_with_used
+WHERE city_id = ?
+ORDER BY name;
+
+-- name: CreateNeighbourhood :exec
+INSERT INTO neighbourhoods (
+	city_id,
+	name
+) VALUES (
+	?, ?
+);
+
+-- name: RenameNeighbourhood :exec
+UPDATE neighbourhoods
+SET name = ?
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteNeighbourhood :exec
+DELETE FROM neighbourhoods
+WHERE id = ?;
+
+-- name: MoveNeighbourhoodHouses :exec
+UPDATE houses
+SET neighbourhood_id = sqlc.arg(target_id)
+WHERE neighbourhood_id = sqlc.arg(source_id);
+
+-- name: MoveCityNeighbourhoods :exec
+UPDATE neighbourhoods
+SET city_id = sqlc.arg(target_id)
+WHERE city_id = sqlc.arg(source_id);
+
 -- name: GetHouse :one
 SELECT * FROM houses_with_cities
 WHERE id = ? LIMIT 1;
@@ -48,9 +122,12 @@ INSERT INTO houses (
 	has_garage,
 	outdoor_parking_spaces,
 	main_photo,
-	notes
+	notes,
+	latitude,
+	longitude,
+	neighbourhood_id
 ) VALUES (
-	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: UpdateHouse :exec
@@ -72,19 +149,26 @@ SET
 	has_garage = ?,
 	outdoor_parking_spaces = ?,
 	main_photo = ?,
-	notes = ?
+	notes = ?,
+	latitude = ?,
+	longitude = ?,
+	neighbourhood_id = ?
 WHERE id = ?;
 
 -- name: DeleteHouse :exec
 DELETE FROM houses
 WHERE id = ?;
 
+-- name: GetPublicationURL :one
+SELECT * FROM publication_urls
+WHERE id = ? LIMIT 1;
+
 -- name: GetPublicationURLs :many
 SELECT * FROM publication_urls
 WHERE house_id = ?
 ORDER BY publication_date DESC;
 
--- name: CreatePublicationURL :exec
+-- name: CreatePublicationURL :execlastid
 INSERT INTO publication_urls (
 	house_id,
 	url,
@@ -107,3 +191,402 @@ WHERE id = ?;
 -- name: DeleteAllPublicationURLs :exec
 DELETE FROM publication_urls
 WHERE house_id = ?;
+
+-- name: ListAllPublicationURLs :many
+SELECT * FROM publication_urls
+ORDER BY id;
+
+-- name: GetLatestPublicationCheck :one
+SELECT * FROM publication_checks
+WHERE publication_url_id = ? AND url = ? AND error = ''
+ORDER BY id DESC LIMIT 1;
+
+-- name: GetLastPublicationCheckTime :one
+SELECT checked_at FROM publication_checks
+WHERE publication_url_id = ? AND url = ?
+ORDER BY id DESC LIMIT 1;
+
+-- name: GetLastPublicationPrice :one
+SELECT price FROM publication_checks
+WHERE publication_url_id = ? AND url = ? AND price > 0
+ORDER BY id DESC LIMIT 1;
+
+-- name: ListHousePublicationChecks :many
+SELECT publication_checks.* FROM publication_checks
+JOIN publication_urls ON publication_checks.publication_url_id = publication_urls.id
+WHERE publication_urls.house_id = ?
+AND publication_checks.url = publication_urls.url
+AND publication_checks.id = (
+	SELECT MAX(latest.id) FROM publication_checks AS latest
+	WHERE latest.publication_url_id = publication_checks.publication_url_id
+);
+
+-- name: CreatePublicationCheck :exec
+INSERT INTO publication_checks (
+	publication_url_id,
+	url,
+	status_code,
+	final_url,
+	removed,
+	price,
+	error
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListNotifications :many
+SELECT * FROM notifications_with_houses
+ORDER BY is_read, created_at DESC, id DESC;
+
+-- name: ListHouseNotifications :many
+SELECT * FROM notifications_with_houses
+WHERE house_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE is_read = FALSE;
+
+-- name: CreateNotification :exec
+INSERT INTO notifications (
+	house_id,
+	notification_type,
+	url,
+	old_price,
+	new_price
+) VALUES (
+	?, ?, ?, ?, ?
+);
+
+-- name: MarkNotificationRead :exec
+UPDATE notifications
+SET is_read = TRUE
+WHERE id = ?;
+
+-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET is_read = TRUE
+WHERE is_read = FALSE;
+
+-- name: DeleteDuplicatePublicationURLs :exec
+DELETE FROM publication_urls
+WHERE publication_urls.house_id = sqlc.arg(source_id)
+AND publication_urls.url IN (SELECT target.url FROM publication_urls AS target WHERE target.house_id = sqlc.arg(target_id));
+
+-- name: MovePublicationURLs :exec
+UPDATE publication_urls
+SET house_id = sqlc.arg(target_id)
+WHERE house_id = sqlc.arg(source_id);
+
+-- name: MoveJournalEntries :exec
+UPDATE journal_entries
+SET house_id = sqlc.arg(target_id)
+WHERE house_id = sqlc.arg(source_id);
+
+-- name: MoveTasks :exec
+UPDATE tasks
+SET house_id = sqlc.arg(target_id)
+WHERE house_id = sqlc.arg(source_id);
+
+-- name: MoveNotifications :exec
+UPDATE notifications
+SET house_id = sqlc.arg(target_id)
+WHERE house_id = sqlc.arg(source_id);
+
+-- name: ListDuplicateDismissals :many
+SELECT * FROM duplicate_dismissals;
+
+-- name: CreateDuplicateDismissal :exec
+INSERT OR IGNORE INTO duplicate_dismissals (
+	house_id,
+	other_house_id
+) VALUES (
+	?, ?
+);
+
+-- name: ListJournalEntries :many
+SELECT * FROM journal_entries_with_houses
+WHERE house_id = ?
+ORDER BY entry_date DESC, id DESC;
+
+-- name: ListDueFollowUps :many
+SELECT * FROM journal_entries_with_houses
+WHERE follow_up_done = FALSE
+	AND date(follow_up_date) > '0001-01-01'
+	AND follow_up_date <= sqlc.arg(until)
+ORDER BY follow_up_date;
+
+-- name: CreateJournalEntry :exec
+INSERT INTO journal_entries (
+	house_id,
+	entry_type,
+	entry_date,
+	contact,
+	summary,
+	follow_up_date
+) VALUES (
+	?, ?, ?, ?, ?, ?
+);
+
+-- name: CompleteFollowUp :execrows
+UPDATE journal_entries
+SET follow_up_done = TRUE
+WHERE id = sqlc.arg(id) AND house_id = sqlc.arg(house_id);
+
+-- name: DeleteJournalEntry :execrows
+DELETE FROM journal_entries
+WHERE id = sqlc.arg(id) AND house_id = sqlc.arg(house_id);
+
+-- name: ListTasks :many
+SELECT * FROM tasks_with_houses
+ORDER BY done, date(due_date) = '0001-01-01', due_date, id;
+
+-- name: ListHouseTasks :many
+SELECT * FROM tasks_with_houses
+WHERE house_id = ?
+ORDER BY done, date(due_date) = '0001-01-01', due_date, id;
+
+-- name: CountOverdueTasks :one
+SELECT COUNT(*) FROM tasks
+WHERE done = FALSE
+	AND date(due_date) > '0001-01-01'
+	AND due_date < sqlc.arg(today);
+
+-- name: ListDueTasks :many
+SELECT * FROM tasks_with_houses
+WHERE done = FALSE
+	AND date(due_date) > '0001-01-01'
+	AND due_date <= sqlc.arg(until)
+ORDER BY due_date, id;
+
+-- name: CreateTask :exec
+INSERT INTO tasks (
+	house_id,
+	title,
+	due_date,
+	assignee
+) VALUES (
+	?, ?, ?, ?
+);
+
+-- name: SetTaskDone :exec
+UPDATE tasks
+SET done = ?
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteTask :exec
+DELETE FROM tasks
+WHERE id = ?;
+
+-- name: ListTags :many
+SELECT * FROM tags_with_usage
+ORDER BY name;
+
+-- name: GetTagByName :one
+SELECT * FROM tags
+WHERE name = ?;
+
+-- name: CreateTag :exec
+INSERT INTO tags (
+	name,
+	color
+) VALUES (
+	?, ?
+);
+
+-- name: UpdateTag :exec
+UPDATE tags
+SET
+	name = ?,
+	color = ?
+WHERE id = ?;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = ?;
+
+-- name: ListHouseTags :many
+SELECT tags_with_usage.* FROM tags_with_usage
+JOIN house_tags ON house_tags.tag_id = tags_with_usage.id
+WHERE house_tags.house_id = ?
+ORDER BY tags_with_usage.name;
+
+-- name: ListAllHouseTags :many
+SELECT house_tags.house_id, sqlc.embed(tags_with_usage) FROM house_tags
+JOIN tags_with_usage ON house_tags.tag_id = tags_with_usage.id
+ORDER BY tags_with_usage.name;
+
+-- name: AddHouseTag :exec
+INSERT INTO house_tags (
+	house_id,
+	tag_id
+) VALUES (
+	?, ?
+);
+
+-- name: DeleteHouseTags :exec
+DELETE FROM house_tags
+WHERE house_id = ?;
+
+-- name: ListCustomFields :many
+SELECT * FROM custom_fields_with_usage
+ORDER BY id;
+
+-- name: GetCustomField :one
+SELECT * FROM custom_fields_with_usage
+WHERE id = ? LIMIT 1;
+
+-- name: GetCustomFieldByName :one
+SELECT * FROM custom_fields_with_usage
+WHERE name = ? LIMIT 1;
+
+-- name: CreateCustomField :exec
+INSERT INTO custom_fields (
+	name,
+	field_type,
+	unit,
+	choices
+) VALUES (
+	?, ?, ?, ?
+);
+
+-- name: UpdateCustomField :exec
+UPDATE custom_fields
+SET
+	name = ?,
+	unit = ?,
+	choices = ?
+WHERE id = ?;
+
+-- name: DeleteCustomField :exec
+DELETE FROM custom_fields
+WHERE id = ?;
+
+-- name: ListHouseCustomValues :many
+SELECT * FROM custom_field_values
+WHERE house_id = ?;
+
+-- name: ListCustomFieldUsedValues :many
+SELECT DISTINCT value FROM custom_field_values
+WHERE field_id = ?
+ORDER BY value;
+
+-- name: ListAllCustomValues :many
+SELECT * FROM custom_field_values;
+
+-- name: CreateCustomValue :exec
+INSERT INTO custom_field_values (
+	house_id,
+	field_id,
+	value
+) VALUES (
+	?, ?, ?
+);
+
+-- name: DeleteHouseCustomValues :exec
+DELETE FROM custom_field_values
+WHERE house_id = ?;
+
+-- name: SetHouseCoordinates :exec
+UPDATE houses
+SET
+	updated_at = CURRENT_TIMESTAMP,
+	latitude = ?,
+	longitude = ?
+WHERE id = sqlc.arg(id);
+
+-- name: ListPointsOfInterest :many
+SELECT * FROM points_of_interest
+ORDER BY name;
+
+-- name: GetPointOfInterestByName :one
+SELECT * FROM points_of_interest
+WHERE name = ?;
+
+-- name: CreatePointOfInterest :exec
+INSERT INTO points_of_interest (
+	name,
+	category,
+	latitude,
+	longitude,
+	travel_mode,
+	max_travel_time
+) VALUES (
+	?, ?, ?, ?, ?, ?
+);
+
+-- name: UpdatePointOfInterest :exec
+UPDATE points_of_interest
+SET
+	name = ?,
+	category = ?,
+	latitude = ?,
+	longitude = ?,
+	travel_mode = ?,
+	max_travel_time = ?
+WHERE id = ?;
+
+-- name: DeletePointOfInterest :exec
+DELETE FROM points_of_interest
+WHERE id = ?;
+
+-- name: CountBANAddresses :one
+SELECT COUNT(*) FROM ban_addresses;
+
+-- name: UpsertBANAddress :exec
+INSERT OR REPLACE INTO ban_addresses (
+	id,
+	number,
+	street,
+	postal_code,
+	city_name,
+	latitude,
+	longitude
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListBANStreetAddresses :many
+SELECT * FROM ban_addresses
+WHERE city_name = ? AND street = ?;
+
+-- name: UpsertMapBoundary :exec
+INSERT INTO map_boundaries (
+	name,
+	code,
+	rings,
+	min_latitude,
+	max_latitude,
+	min_longitude,
+	max_longitude
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (name, code) DO UPDATE SET
+	rings = excluded.rings,
+	min_latitude = excluded.min_latitude,
+	max_latitude = excluded.max_latitude,
+	min_longitude = excluded.min_longitude,
+	max_longitude = excluded.max_longitude;
+
+-- name: ListMapBoundariesInBox :many
+SELECT * FROM map_boundaries
+WHERE max_latitude >= sqlc.arg(min_latitude) AND min_latitude <= sqlc.arg(max_latitude)
+AND max_longitude >= sqlc.arg(min_longitude) AND min_longitude <= sqlc.arg(max_longitude)
+ORDER BY name;
+
+-- name: CountMapBoundaries :one
+SELECT COUNT(*) FROM map_boundaries;
+
+-- name: DeleteMapBoundaries :exec
+DELETE FROM map_boundaries;
+
+-- name: ListLatestPublicationChecks :many
+SELECT publication_urls.house_id, publication_checks.removed, publication_checks.error
+FROM publication_checks
+JOIN publication_urls ON publication_checks.publication_url_id = publication_urls.id
+WHERE publication_checks.url = publication_urls.url
+AND publication_checks.id = (
+	SELECT MAX(latest.id) FROM publication_checks AS latest
+	WHERE latest.publication_url_id = publication_checks.publication_url_id
+);
@@ -7,20 +7,181 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const addHouseTag = `-- name: AddHouseTag :exec
+INSERT INTO house_tags (
+	house_id,
+	tag_id
+) VALUES (
+	?, ?
+)
+`
+
+func (q *Queries) AddHouseTag(ctx context.Context, houseID int64, tagID int64) error {
+	_, err := q.exec(ctx, q.addHouseTagStmt, addHouseTag, houseID, tagID)
+	return err
+}
+
+const completeFollowUp = `-- name: CompleteFollowUp :execrows
+UPDATE journal_entries
+SET follow_up_done = TRUE
+WHERE id = ?1 AND house_id = ?2
+`
+
+func (q *Queries) CompleteFollowUp(ctx context.Context, iD int64, houseID int64) (int64, error) {
+	result, err := q.exec(ctx, q.completeFollowUpStmt, completeFollowUp, iD, houseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countBANAddresses = `-- name: CountBANAddresses :one
+SELECT COUNT(*) FROM ban_addresses
+`
+
+func (q *Queries) CountBANAddresses(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countBANAddressesStmt, countBANAddresses)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countMapBoundaries = `-- name: CountMapBoundaries :one
+SELECT COUNT(*) FROM map_boundaries
+`
+
+func (q *Queries) CountMapBoundaries(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countMapBoundariesStmt, countMapBoundaries)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOverdueTasks = `-- name: CountOverdueTasks :one
+SELECT COUNT(*) FROM tasks
+WHERE done = FALSE
+	AND date(due_date) > '0001-01-01'
+	AND due_date < ?1
+`
+
+func (q *Queries) CountOverdueTasks(ctx context.Context, today time.Time) (int64, error) {
+	row := q.queryRow(ctx, q.countOverdueTasksStmt, countOverdueTasks, today)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE is_read = FALSE
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countUnreadNotificationsStmt, countUnreadNotifications)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCity = `-- name: CreateCity :exec
 INSERT INTO cities (
-	name
+	name,
+	postal_code,
+	insee_code,
+	department,
+	population,
+	notes
+) VALUES (
+	?, ?, ?, ?, ?, ?
+)
+RETURNING id, name, postal_code, insee_code, department, population, notes
+`
+
+type CreateCityParams struct {
+	Name       string
+	PostalCode string
+	INSEECode  string
+	Department string
+	Population int64
+	Notes      string
+}
+
+func (q *Queries) CreateCity(ctx context.Context, arg CreateCityParams) error {
+	_, err := q.exec(ctx, q.createCityStmt, createCity,
+		arg.Name,
+		arg.PostalCode,
+		arg.INSEECode,
+		arg.Department,
+		arg.Population,
+		arg.Notes,
+	)
+	return err
+}
+
+const createCustomField = `-- name: CreateCustomField :exec
+INSERT INTO custom_fields (
+	name,
+	field_type,
+	unit,
+	choices
+) VALUES (
+	?, ?, ?, ?
+)
+`
+
+type CreateCustomFieldParams struct {
+	Name      string
+	FieldType string
+	Unit      string
+	Choices   string
+}
+
+func (q *Queries) CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) error {
+	_, err := q.exec(ctx, q.createCustomFieldStmt, createCustomField,
+		arg.Name,
+		arg.FieldType,
+		arg.Unit,
+		arg.Choices,
+	)
+	return err
+}
+
+const createCustomValue = `-- name: CreateCustomValue :exec
+INSERT INTO custom_field_values (
+	house_id,
+	field_id,
+	value
+) VALUES (
+	?, ?, ?
+)
+`
+
+type CreateCustomValueParams struct {
+	HouseID int64
+	FieldID int64
+	Value   string
+}
+
+func (q *Queries) CreateCustomValue(ctx context.Context, arg CreateCustomValueParams) error {
+	_, err := q.exec(ctx, q.createCustomValueStmt, createCustomValue, arg.HouseID, arg.FieldID, arg.Value)
+	return err
+}
+
+const createDuplicateDismissal = `-- name: CreateDuplicateDismissal :exec
+INSERT OR IGNORE INTO duplicate_dismissals (
+	house_id,
+	other_house_id
 ) VALUES (
-	?
+	?, ?
 )
-RETURNING id, name
 `
 
-func (q *Queries) CreateCity(ctx context.Context, name string) error {
-	_, err := q.exec(ctx, q.createCityStmt, createCity, name)
+func (q *Queries) CreateDuplicateDismissal(ctx context.Context, houseID int64, otherHouseID int64) error {
+	_, err := q.exec(ctx, q.createDuplicateDismissalStmt, createDuplicateDismissal, houseID, otherHouseID)
 	return err
 }
 
@@ -41,9 +202,12 @@ INSERT INTO houses (
 	has_garage,
 	outdoor_parking_spaces,
 	main_photo,
-	notes
+	notes,
+	latitude,
+	longitude,
+	neighbourhood_id
 ) VALUES (
-	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+	?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
@@ -64,6 +228,9 @@ type CreateHouseParams struct {
 	OutdoorParkingSpaces int64
 	MainPhoto            string
 	Notes                string
+	Latitude             float64
+	Longitude            float64
+	NeighbourhoodID      sql.NullInt64
 }
 
 func (q *Queries) CreateHouse(ctx context.Context, arg CreateHouseParams) (int64, error) {
@@ -84,6 +251,9 @@ func (q *Queries) CreateHouse(ctx context.Context, arg CreateHouseParams) (int64
 		arg.OutdoorParkingSpaces,
 		arg.MainPhoto,
 		arg.Notes,
+		arg.Latitude,
+		arg.Longitude,
+		arg.NeighbourhoodID,
 	)
 	if err != nil {
 		return 0, err
@@ -91,7 +261,157 @@ func (q *Queries) CreateHouse(ctx context.Context, arg CreateHouseParams) (int64
 	return result.LastInsertId()
 }
 
-const createPublicationURL = `-- name: CreatePublicationURL :exec
+const createJournalEntry = `-- name: CreateJournalEntry :exec
+INSERT INTO journal_entries (
+	house_id,
+	entry_type,
+	entry_date,
+	contact,
+	summary,
+	follow_up_date
+) VALUES (
+	?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateJournalEntryParams struct {
+	HouseID      int64
+	EntryType    string
+	EntryDate    time.Time
+	Contact      string
+	Summary      string
+	FollowUpDate time.Time
+}
+
+func (q *Queries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) error {
+	_, err := q.exec(ctx, q.createJournalEntryStmt, createJournalEntry,
+		arg.HouseID,
+		arg.EntryType,
+		arg.EntryDate,
+		arg.Contact,
+		arg.Summary,
+		arg.FollowUpDate,
+	)
+	return err
+}
+
+const createNeighbourhood = `-- name: CreateNeighbourhood :exec
+INSERT INTO neighbourhoods (
+	city_id,
+	name
+) VALUES (
+	?, ?
+)
+`
+
+func (q *Queries) CreateNeighbourhood(ctx context.Context, cityID int64, name string) error {
+	_, err := q.exec(ctx, q.createNeighbourhoodStmt, createNeighbourhood, cityID, name)
+	return err
+}
+
+const createNotification = `-- name: CreateNotification :exec
+INSERT INTO notifications (
+	house_id,
+	notification_type,
+	url,
+	old_price,
+	new_price
+) VALUES (
+	?, ?, ?, ?, ?
+)
+`
+
+type CreateNotificationParams struct {
+	HouseID          int64
+	NotificationType string
+	URL              string
+	OldPrice         int64
+	NewPrice         int64
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) error {
+	_, err := q.exec(ctx, q.createNotificationStmt, createNotification,
+		arg.HouseID,
+		arg.NotificationType,
+		arg.URL,
+		arg.OldPrice,
+		arg.NewPrice,
+	)
+	return err
+}
+
+const createPointOfInterest = `-- name: CreatePointOfInterest :exec
+INSERT INTO points_of_interest (
+	name,
+	category,
+	latitude,
+	longitude,
+	travel_mode,
+	max_travel_time
+) VALUES (
+	?, ?, ?, ?, ?, ?
+)
+`
+
+type CreatePointOfInterestParams struct {
+	Name          string
+	Category      string
+	Latitude      float64
+	Longitude     float64
+	TravelMode    string
+	MaxTravelTime int64
+}
+
+func (q *Queries) CreatePointOfInterest(ctx context.Context, arg CreatePointOfInterestParams) error {
+	_, err := q.exec(ctx, q.createPointOfInterestStmt, createPointOfInterest,
+		arg.Name,
+		arg.Category,
+		arg.Latitude,
+		arg.Longitude,
+		arg.TravelMode,
+		arg.MaxTravelTime,
+	)
+	return err
+}
+
+const createPublicationCheck = `-- name: CreatePublicationCheck :exec
+INSERT INTO publication_checks (
+	publication_url_id,
+	url,
+	status_code,
+	final_url,
+	removed,
+	price,
+	error
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreatePublicationCheckParams struct {
+	PublicationURLID int64
+	URL              string
+	StatusCode       int64
+	FinalURL         string
+	Removed          bool
+	Price            int64
+	Error            string
+}
+
+func (q *Queries) CreatePublicationCheck(ctx context.Context, arg CreatePublicationCheckParams) error {
+	_, err := q.exec(ctx, q.createPublicationCheckStmt, createPublicationCheck,
+		arg.PublicationURLID,
+		arg.URL,
+		arg.StatusCode,
+		arg.FinalURL,
+		arg.Removed,
+		arg.Price,
+		arg.Error,
+	)
+	return err
+}
+
+const createPublicationURL = `-- name: CreatePublicationURL :execlastid
 INSERT INTO publication_urls (
 	house_id,
 	url,
@@ -107,8 +427,53 @@ type CreatePublicationURLParams struct {
 	PublicationDate time.Time
 }
 
-func (q *Queries) CreatePublicationURL(ctx context.Context, arg CreatePublicationURLParams) error {
-	_, err := q.exec(ctx, q.createPublicationURLStmt, createPublicationURL, arg.HouseID, arg.URL, arg.PublicationDate)
+func (q *Queries) CreatePublicationURL(ctx context.Context, arg CreatePublicationURLParams) (int64, error) {
+	result, err := q.exec(ctx, q.createPublicationURLStmt, createPublicationURL, arg.HouseID, arg.URL, arg.PublicationDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const createTag = `-- name: CreateTag :exec
+INSERT INTO tags (
+	name,
+	color
+) VALUES (
+	?, ?
+)
+`
+
+func (q *Queries) CreateTag(ctx context.Context, name string, color string) error {
+	_, err := q.exec(ctx, q.createTagStmt, createTag, name, color)
+	return err
+}
+
+const createTask = `-- name: CreateTask :exec
+INSERT INTO tasks (
+	house_id,
+	title,
+	due_date,
+	assignee
+) VALUES (
+	?, ?, ?, ?
+)
+`
+
+type CreateTaskParams struct {
+	HouseID  int64
+	Title    string
+	DueDate  time.Time
+	Assignee string
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
+	_, err := q.exec(ctx, q.createTaskStmt, createTask,
+		arg.HouseID,
+		arg.Title,
+		arg.DueDate,
+		arg.Assignee,
+	)
 	return err
 }
 
@@ -132,6 +497,27 @@ func (q *Queries) DeleteCity(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteCustomField = `-- name: DeleteCustomField :exec
+DELETE FROM custom_fields
+WHERE id = ?
+`
+
+func (q *Queries) DeleteCustomField(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteCustomFieldStmt, deleteCustomField, id)
+	return err
+}
+
+const deleteDuplicatePublicationURLs = `-- name: DeleteDuplicatePublicationURLs :exec
+DELETE FROM publication_urls
+WHERE publication_urls.house_id = ?1
+AND publication_urls.url IN (SELECT target.url FROM publication_urls AS target WHERE target.house_id = ?2)
+`
+
+func (q *Queries) DeleteDuplicatePublicationURLs(ctx context.Context, sourceID int64, targetID int64) error {
+	_, err := q.exec(ctx, q.deleteDuplicatePublicationURLsStmt, deleteDuplicatePublicationURLs, sourceID, targetID)
+	return err
+}
+
 const deleteHouse = `-- name: DeleteHouse :exec
 DELETE FROM houses
 WHERE id = ?
@@ -142,6 +528,68 @@ func (q *Queries) DeleteHouse(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteHouseCustomValues = `-- name: DeleteHouseCustomValues :exec
+DELETE FROM custom_field_values
+WHERE house_id = ?
+`
+
+func (q *Queries) DeleteHouseCustomValues(ctx context.Context, houseID int64) error {
+	_, err := q.exec(ctx, q.deleteHouseCustomValuesStmt, deleteHouseCustomValues, houseID)
+	return err
+}
+
+const deleteHouseTags = `-- name: DeleteHouseTags :exec
+DELETE FROM house_tags
+WHERE house_id = ?
+`
+
+func (q *Queries) DeleteHouseTags(ctx context.Context, houseID int64) error {
+	_, err := q.exec(ctx, q.deleteHouseTagsStmt, deleteHouseTags, houseID)
+	return err
+}
+
+const deleteJournalEntry = `-- name: DeleteJournalEntry :execrows
+DELETE FROM journal_entries
+WHERE id = ?1 AND house_id = ?2
+`
+
+func (q *Queries) DeleteJournalEntry(ctx context.Context, iD int64, houseID int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteJournalEntryStmt, deleteJournalEntry, iD, houseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMapBoundaries = `-- name: DeleteMapBoundaries :exec
+DELETE FROM map_boundaries
+`
+
+func (q *Queries) DeleteMapBoundaries(ctx context.Context) error {
+	_, err := q.exec(ctx, q.deleteMapBoundariesStmt, deleteMapBoundaries)
+	return err
+}
+
+const deleteNeighbourhood = `-- name: DeleteNeighbourhood :exec
+DELETE FROM neighbourhoods
+WHERE id = ?
+`
+
+func (q *Queries) DeleteNeighbourhood(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteNeighbourhoodStmt, deleteNeighbourhood, id)
+	return err
+}
+
+const deletePointOfInterest = `-- name: DeletePointOfInterest :exec
+DELETE FROM points_of_interest
+WHERE id = ?
+`
+
+func (q *Queries) DeletePointOfInterest(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deletePointOfInterestStmt, deletePointOfInterest, id)
+	return err
+}
+
 const deletePublicationURL = `-- name: DeletePublicationURL :exec
 DELETE FROM publication_urls
 WHERE id = ?
@@ -152,20 +600,130 @@ func (q *Queries) DeletePublicationURL(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteTag = `-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = ?
+`
+
+func (q *Queries) DeleteTag(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteTagStmt, deleteTag, id)
+	return err
+}
+
+const deleteTask = `-- name: DeleteTask :exec
+DELETE FROM tasks
+WHERE id = ?
+`
+
+func (q *Queries) DeleteTask(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteTaskStmt, deleteTask, id)
+	return err
+}
+
 const getCity = `-- name: GetCity :one
-SELECT id, name, is_used FROM cities_with_used
+SELECT id, name, postal_code, insee_code, department, population, notes, is_used FROM cities_with_used
 WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetCity(ctx context.Context, id int64) (City, error) {
 	row := q.queryRow(ctx, q.getCityStmt, getCity, id)
 	var i City
-	err := row.Scan(&i.ID, &i.Name, &i.IsUsed)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PostalCode,
+		&i.INSEECode,
+		&i.Department,
+		&i.Population,
+		&i.Notes,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getCityByName = `-- name: GetCityByName :one
+SELECT id, name, postal_code, insee_code, department, population, notes, is_used FROM cities_with_used
+WHERE name = ?
+ORDER BY id LIMIT 1
+`
+
+func (q *Queries) GetCityByName(ctx context.Context, name string) (City, error) {
+	row := q.queryRow(ctx, q.getCityByNameStmt, getCityByName, name)
+	var i City
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PostalCode,
+		&i.INSEECode,
+		&i.Department,
+		&i.Population,
+		&i.Notes,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getCityByNameAndPostalCode = `-- name: GetCityByNameAndPostalCode :one
+SELECT id, name, postal_code, insee_code, department, population, notes, is_used FROM cities_with_used
+WHERE name = ? AND postal_code = ? LIMIT 1
+`
+
+func (q *Queries) GetCityByNameAndPostalCode(ctx context.Context, name string, postalCode string) (City, error) {
+	row := q.queryRow(ctx, q.getCityByNameAndPostalCodeStmt, getCityByNameAndPostalCode, name, postalCode)
+	var i City
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PostalCode,
+		&i.INSEECode,
+		&i.Department,
+		&i.Population,
+		&i.Notes,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getCustomField = `-- name: GetCustomField :one
+SELECT id, name, field_type, unit, choices, value_count FROM custom_fields_with_usage
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetCustomField(ctx context.Context, id int64) (CustomField, error) {
+	row := q.queryRow(ctx, q.getCustomFieldStmt, getCustomField, id)
+	var i CustomField
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.FieldType,
+		&i.Unit,
+		&i.Choices,
+		&i.ValueCount,
+	)
+	return i, err
+}
+
+const getCustomFieldByName = `-- name: GetCustomFieldByName :one
+SELECT id, name, field_type, unit, choices, value_count FROM custom_fields_with_usage
+WHERE name = ? LIMIT 1
+`
+
+func (q *Queries) GetCustomFieldByName(ctx context.Context, name string) (CustomField, error) {
+	row := q.queryRow(ctx, q.getCustomFieldByNameStmt, getCustomFieldByName, name)
+	var i CustomField
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.FieldType,
+		&i.Unit,
+		&i.Choices,
+		&i.ValueCount,
+	)
 	return i, err
 }
 
 const getHouse = `-- name: GetHouse :one
-SELECT id, created_at, updated_at, title, city_id, address, price, surface, rooms, bedrooms, bathrooms, floors, construction_year, house_type, land_surface, has_garage, outdoor_parking_spaces, main_photo, notes, city_name FROM houses_with_cities
+SELECT id, created_at, updated_at, title, city_id, address, price, surface, rooms, bedrooms, bathrooms, floors, construction_year, house_type, land_surface, has_garage, outdoor_parking_spaces, main_photo, notes, latitude, longitude, neighbourhood_id, city_name, city_postal_code, neighbourhood_name FROM houses_with_cities
 WHERE id = ? LIMIT 1
 `
 
@@ -192,31 +750,994 @@ func (q *Queries) GetHouse(ctx context.Context, id int64) (House, error) {
 		&i.OutdoorParkingSpaces,
 		&i.MainPhoto,
 		&i.Notes,
+		&i.Latitude,
+		&i.Longitude,
+		&i.NeighbourhoodID,
 		&i.CityName,
+		&i.CityPostalCode,
+		&i.NeighbourhoodName,
 	)
 	return i, err
 }
 
-const getPublicationURLs = `-- name: GetPublicationURLs :many
-SELECT id, house_id, url, publication_date FROM publication_urls
-WHERE house_id = ?
-ORDER BY publication_date DESC
+const getLastPublicationCheckTime = `-- name: GetLastPublicationCheckTime :one
+SELECT checked_at FROM publication_checks
+WHERE publication_url_id = ? AND url = ?
+ORDER BY id DESC LIMIT 1
 `
 
-func (q *Queries) GetPublicationURLs(ctx context.Context, houseID int64) ([]PublicationURL, error) {
-	rows, err := q.query(ctx, q.getPublicationURLsStmt, getPublicationURLs, houseID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+func (q *Queries) GetLastPublicationCheckTime(ctx context.Context, publicationURLID int64, uRL string) (time.Time, error) {
+	row := q.queryRow(ctx, q.getLastPublicationCheckTimeStmt, getLastPublicationCheckTime, publicationURLID, uRL)
+	var checked_at time.Time
+	err := row.Scan(&checked_at)
+	return checked_at, err
+}
+
+const getLastPublicationPrice = `-- name: GetLastPublicationPrice :one
+SELECT price FROM publication_checks
+WHERE publication_url_id = ? AND url = ? AND price > 0
+ORDER BY id DESC LIMIT 1
+`
+
+func (q *Queries) GetLastPublicationPrice(ctx context.Context, publicationURLID int64, uRL string) (int64, error) {
+	row := q.queryRow(ctx, q.getLastPublicationPriceStmt, getLastPublicationPrice, publicationURLID, uRL)
+	var price int64
+	err := row.Scan(&price)
+	return price, err
+}
+
+const getLatestPublicationCheck = `-- name: GetLatestPublicationCheck :one
+SELECT id, publication_url_id, url, checked_at, status_code, final_url, removed, price, error FROM publication_checks
+WHERE publication_url_id = ? AND url = ? AND error = ''
+ORDER BY id DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestPublicationCheck(ctx context.Context, publicationURLID int64, uRL string) (PublicationCheck, error) {
+	row := q.queryRow(ctx, q.getLatestPublicationCheckStmt, getLatestPublicationCheck, publicationURLID, uRL)
+	var i PublicationCheck
+	err := row.Scan(
+		&i.ID,
+		&i.PublicationURLID,
+		&i.URL,
+		&i.CheckedAt,
+		&i.StatusCode,
+		&i.FinalURL,
+		&i.Removed,
+		&i.Price,
+		&i.Error,
+	)
+	return i, err
+}
+
+const getNeighbourhood = `-- name: GetNeighbourhood :one
+SELECT id, city_id, name, city_name, city_postal_code, is_used FROM neighbourhoods_with_used
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetNeighbourhood(ctx context.Context, id int64) (Neighbourhood, error) {
+	row := q.queryRow(ctx, q.getNeighbourhoodStmt, getNeighbourhood, id)
+	var i Neighbourhood
+	err := row.Scan(
+		&i.ID,
+		&i.CityID,
+		&i.Name,
+		&i.CityName,
+		&i.CityPostalCode,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getNeighbourhoodByName = `-- name: GetNeighbourhoodByName :one
+SELECT id, city_id, name, city_name, city_postal_code, is_used FROM neighbourhoods_with_used
+WHERE city_id = ? AND name = ? LIMIT 1
+`
+
+func (q *Queries) GetNeighbourhoodByName(ctx context.Context, cityID int64, name string) (Neighbourhood, error) {
+	row := q.queryRow(ctx, q.getNeighbourhoodByNameStmt, getNeighbourhoodByName, cityID, name)
+	var i Neighbourhood
+	err := row.Scan(
+		&i.ID,
+		&i.CityID,
+		&i.Name,
+		&i.CityName,
+		&i.CityPostalCode,
+		&i.IsUsed,
+	)
+	return i, err
+}
+
+const getPointOfInterestByName = `-- name: GetPointOfInterestByName :one
+SELECT id, name, latitude, longitude, category, travel_mode, max_travel_time FROM points_of_interest
+WHERE name = ?
+`
+
+func (q *Queries) GetPointOfInterestByName(ctx context.Context, name string) (DBPointOfInterest, error) {
+	row := q.queryRow(ctx, q.getPointOfInterestByNameStmt, getPointOfInterestByName, name)
+	var i DBPointOfInterest
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Category,
+		&i.TravelMode,
+		&i.MaxTravelTime,
+	)
+	return i, err
+}
+
+const getPublicationURL = `-- name: GetPublicationURL :one
+SELECT id, house_id, url, publication_date FROM publication_urls
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetPublicationURL(ctx context.Context, id int64) (PublicationURL, error) {
+	row := q.queryRow(ctx, q.getPublicationURLStmt, getPublicationURL, id)
+	var i PublicationURL
+	err := row.Scan(
+		&i.ID,
+		&i.HouseID,
+		&i.URL,
+		&i.PublicationDate,
+	)
+	return i, err
+}
+
+const getPublicationURLs = `-- name: GetPublicationURLs :many
+SELECT id, house_id, url, publication_date FROM publication_urls
+WHERE house_id = ?
+ORDER BY publication_date DESC
+`
+
+func (q *Queries) GetPublicationURLs(ctx context.Context, houseID int64) ([]PublicationURL, error) {
+	rows, err := q.query(ctx, q.getPublicationURLsStmt, getPublicationURLs, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PublicationURL
+	for rows.Next() {
+		var i PublicationURL
+		if err := rows.Scan(
+			&i.ID,
+			&i.HouseID,
+			&i.URL,
+			&i.PublicationDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagByName = `-- name: GetTagByName :one
+SELECT id, name, color FROM tags
+WHERE name = ?
+`
+
+func (q *Queries) GetTagByName(ctx context.Context, name string) (DBTag, error) {
+	row := q.queryRow(ctx, q.getTagByNameStmt, getTagByName, name)
+	var i DBTag
+	err := row.Scan(&i.ID, &i.Name, &i.Color)
+	return i, err
+}
+
+const listAllCustomValues = `-- name: ListAllCustomValues :many
+SELECT house_id, field_id, value FROM custom_field_values
+`
+
+func (q *Queries) ListAllCustomValues(ctx context.Context) ([]CustomFieldValue, error) {
+	rows, err := q.query(ctx, q.listAllCustomValuesStmt, listAllCustomValues)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomFieldValue
+	for rows.Next() {
+		var i CustomFieldValue
+		if err := rows.Scan(&i.HouseID, &i.FieldID, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllHouseTags = `-- name: ListAllHouseTags :many
+SELECT house_tags.house_id, tags_with_usage.id, tags_with_usage.name, tags_with_usage.color, tags_with_usage.house_count FROM house_tags
+JOIN tags_with_usage ON house_tags.tag_id = tags_with_usage.id
+ORDER BY tags_with_usage.name
+`
+
+type ListAllHouseTagsRow struct {
+	HouseID int64
+	Tag     Tag
+}
+
+func (q *Queries) ListAllHouseTags(ctx context.Context) ([]ListAllHouseTagsRow, error) {
+	rows, err := q.query(ctx, q.listAllHouseTagsStmt, listAllHouseTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllHouseTagsRow
+	for rows.Next() {
+		var i ListAllHouseTagsRow
+		if err := rows.Scan(
+			&i.HouseID,
+			&i.Tag.ID,
+			&i.Tag.Name,
+			&i.Tag.Color,
+			&i.Tag.HouseCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllPublicationURLs = `-- name: ListAllPublicationURLs :many
+SELECT id, house_id, url, publication_date FROM publication_urls
+ORDER BY id
+`
+
+func (q *Queries) ListAllPublicationURLs(ctx context.Context) ([]PublicationURL, error) {
+	rows, err := q.query(ctx, q.listAllPublicationURLsStmt, listAllPublicationURLs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	var items []PublicationURL
 	for rows.Next() {
-		var i PublicationURL
+		var i PublicationURL
+		if err := rows.Scan(
+			&i.ID,
+			&i.HouseID,
+			&i.URL,
+			&i.PublicationDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBANStreetAddresses = `-- name: ListBANStreetAddresses :many
+SELECT id, number, street, postal_code, city_name, latitude, longitude FROM ban_addresses
+WHERE city_name = ? AND street = ?
+`
+
+func (q *Queries) ListBANStreetAddresses(ctx context.Context, cityName string, street string) ([]BANAddress, error) {
+	rows, err := q.query(ctx, q.listBANStreetAddressesStmt, listBANStreetAddresses, cityName, street)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BANAddress
+	for rows.Next() {
+		var i BANAddress
+		if err := rows.Scan(
+			&i.ID,
+			&i.Number,
+			&i.Street,
+			&i.PostalCode,
+			&i.CityName,
+			&i.Latitude,
+			&i.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCities = `-- name: ListCities :many
+SELECT id, name, postal_code, insee_code, department, population, notes, is_used FROM cities_with_used
+ORDER BY name, postal_code
+`
+
+func (q *Queries) ListCities(ctx context.Context) ([]City, error) {
+	rows, err := q.query(ctx, q.listCitiesStmt, listCities)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []City
+	for rows.Next() {
+		var i City
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PostalCode,
+			&i.INSEECode,
+			&i.Department,
+			&i.Population,
+			&i.Notes,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCityNeighbourhoods = `-- name: ListCityNeighbourhoods :many
+SELECT id, city_id, name, city_name, city_postal_code, is_used FROM neighbourhoods_with_used
+WHERE city_id = ?
+ORDER BY name
+`
+
+func (q *Queries) ListCityNeighbourhoods(ctx context.Context, cityID int64) ([]Neighbourhood, error) {
+	rows, err := q.query(ctx, q.listCityNeighbourhoodsStmt, listCityNeighbourhoods, cityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Neighbourhood
+	for rows.Next() {
+		var i Neighbourhood
+		if err := rows.Scan(
+			&i.ID,
+			&i.CityID,
+			&i.Name,
+			&i.CityName,
+			&i.CityPostalCode,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomFieldUsedValues = `-- name: ListCustomFieldUsedValues :many
+SELECT DISTINCT value FROM custom_field_values
+WHERE field_id = ?
+ORDER BY value
+`
+
+func (q *Queries) ListCustomFieldUsedValues(ctx context.Context, fieldID int64) ([]string, error) {
+	rows, err := q.query(ctx, q.listCustomFieldUsedValuesStmt, listCustomFieldUsedValues, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomFields = `-- name: ListCustomFields :many
+SELECT id, name, field_type, unit, choices, value_count FROM custom_fields_with_usage
+ORDER BY id
+`
+
+func (q *Queries) ListCustomFields(ctx context.Context) ([]CustomField, error) {
+	rows, err := q.query(ctx, q.listCustomFieldsStmt, listCustomFields)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomField
+	for rows.Next() {
+		var i CustomField
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.FieldType,
+			&i.Unit,
+			&i.Choices,
+			&i.ValueCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueFollowUps = `-- name: ListDueFollowUps :many
+SELECT id, house_id, entry_type, entry_date, contact, summary, follow_up_date, follow_up_done, house_title FROM journal_entries_with_houses
+WHERE follow_up_done = FALSE
+	AND date(follow_up_date) > '0001-01-01'
+	AND follow_up_date <= ?1
+ORDER BY follow_up_date
+`
+
+func (q *Queries) ListDueFollowUps(ctx context.Context, until time.Time) ([]JournalEntry, error) {
+	rows, err := q.query(ctx, q.listDueFollowUpsStmt, listDueFollowUps, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JournalEntry
+	for rows.Next() {
+		var i JournalEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.HouseID,
+			&i.EntryType,
+			&i.EntryDate,
+			&i.Contact,
+			&i.Summary,
+			&i.FollowUpDate,
+			&i.FollowUpDone,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueTasks = `-- name: ListDueTasks :many
+SELECT id, created_at, house_id, title, due_date, assignee, done, house_title FROM tasks_with_houses
+WHERE done = FALSE
+	AND date(due_date) > '0001-01-01'
+	AND due_date <= ?1
+ORDER BY due_date, id
+`
+
+func (q *Queries) ListDueTasks(ctx context.Context, until time.Time) ([]Task, error) {
+	rows, err := q.query(ctx, q.listDueTasksStmt, listDueTasks, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.HouseID,
+			&i.Title,
+			&i.DueDate,
+			&i.Assignee,
+			&i.Done,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDuplicateDismissals = `-- name: ListDuplicateDismissals :many
+SELECT house_id, other_house_id FROM duplicate_dismissals
+`
+
+func (q *Queries) ListDuplicateDismissals(ctx context.Context) ([]DuplicateDismissal, error) {
+	rows, err := q.query(ctx, q.listDuplicateDismissalsStmt, listDuplicateDismissals)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DuplicateDismissal
+	for rows.Next() {
+		var i DuplicateDismissal
+		if err := rows.Scan(&i.HouseID, &i.OtherHouseID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHouseCustomValues = `-- name: ListHouseCustomValues :many
+SELECT house_id, field_id, value FROM custom_field_values
+WHERE house_id = ?
+`
+
+func (q *Queries) ListHouseCustomValues(ctx context.Context, houseID int64) ([]CustomFieldValue, error) {
+	rows, err := q.query(ctx, q.listHouseCustomValuesStmt, listHouseCustomValues, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CustomFieldValue
+	for rows.Next() {
+		var i CustomFieldValue
+		if err := rows.Scan(&i.HouseID, &i.FieldID, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHouseNotifications = `-- name: ListHouseNotifications :many
+SELECT id, created_at, house_id, notification_type, url, old_price, new_price, is_read, house_title FROM notifications_with_houses
+WHERE house_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListHouseNotifications(ctx context.Context, houseID int64) ([]Notification, error) {
+	rows, err := q.query(ctx, q.listHouseNotificationsStmt, listHouseNotifications, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.HouseID,
+			&i.NotificationType,
+			&i.URL,
+			&i.OldPrice,
+			&i.NewPrice,
+			&i.IsRead,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHousePublicationChecks = `-- name: ListHousePublicationChecks :many
+SELECT publication_checks.id, publication_checks.publication_url_id, publication_checks.url, publication_checks.checked_at, publication_checks.status_code, publication_checks.final_url, publication_checks.removed, publication_checks.price, publication_checks.error FROM publication_checks
+JOIN publication_urls ON publication_checks.publication_url_id = publication_urls.id
+WHERE publication_urls.house_id = ?
+AND publication_checks.url = publication_urls.url
+AND publication_checks.id = (
+	SELECT MAX(latest.id) FROM publication_checks AS latest
+	WHERE latest.publication_url_id = publication_checks.publication_url_id
+)
+`
+
+func (q *Queries) ListHousePublicationChecks(ctx context.Context, houseID int64) ([]PublicationCheck, error) {
+	rows, err := q.query(ctx, q.listHousePublicationChecksStmt, listHousePublicationChecks, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PublicationCheck
+	for rows.Next() {
+		var i PublicationCheck
+		if err := rows.Scan(
+			&i.ID,
+			&i.PublicationURLID,
+			&i.URL,
+			&i.CheckedAt,
+			&i.StatusCode,
+			&i.FinalURL,
+			&i.Removed,
+			&i.Price,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHouseTags = `-- name: ListHouseTags :many
+SELECT tags_with_usage.id, tags_with_usage.name, tags_with_usage.color, tags_with_usage.house_count FROM tags_with_usage
+JOIN house_tags ON house_tags.tag_id = tags_with_usage.id
+WHERE house_tags.house_id = ?
+ORDER BY tags_with_usage.name
+`
+
+func (q *Queries) ListHouseTags(ctx context.Context, houseID int64) ([]Tag, error) {
+	rows, err := q.query(ctx, q.listHouseTagsStmt, listHouseTags, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tag
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Color,
+			&i.HouseCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHouseTasks = `-- name: ListHouseTasks :many
+SELECT id, created_at, house_id, title, due_date, assignee, done, house_title FROM tasks_with_houses
+WHERE house_id = ?
+ORDER BY done, date(due_date) = '0001-01-01', due_date, id
+`
+
+func (q *Queries) ListHouseTasks(ctx context.Context, houseID int64) ([]Task, error) {
+	rows, err := q.query(ctx, q.listHouseTasksStmt, listHouseTasks, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.HouseID,
+			&i.Title,
+			&i.DueDate,
+			&i.Assignee,
+			&i.Done,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHouses = `-- name: ListHouses :many
+SELECT id, created_at, updated_at, title, city_id, address, price, surface, rooms, bedrooms, bathrooms, floors, construction_year, house_type, land_surface, has_garage, outdoor_parking_spaces, main_photo, notes, latitude, longitude, neighbourhood_id, city_name, city_postal_code, neighbourhood_name FROM houses_with_cities
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListHouses(ctx context.Context) ([]House, error) {
+	rows, err := q.query(ctx, q.listHousesStmt, listHouses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []House
+	for rows.Next() {
+		var i House
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.CityID,
+			&i.Address,
+			&i.Price,
+			&i.Surface,
+			&i.Rooms,
+			&i.Bedrooms,
+			&i.Bathrooms,
+			&i.Floors,
+			&i.ConstructionYear,
+			&i.HouseType,
+			&i.LandSurface,
+			&i.HasGarage,
+			&i.OutdoorParkingSpaces,
+			&i.MainPhoto,
+			&i.Notes,
+			&i.Latitude,
+			&i.Longitude,
+			&i.NeighbourhoodID,
+			&i.CityName,
+			&i.CityPostalCode,
+			&i.NeighbourhoodName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJournalEntries = `-- name: ListJournalEntries :many
+SELECT id, house_id, entry_type, entry_date, contact, summary, follow_up_date, follow_up_done, house_title FROM journal_entries_with_houses
+WHERE house_id = ?
+ORDER BY entry_date DESC, id DESC
+`
+
+func (q *Queries) ListJournalEntries(ctx context.Context, houseID int64) ([]JournalEntry, error) {
+	rows, err := q.query(ctx, q.listJournalEntriesStmt, listJournalEntries, houseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JournalEntry
+	for rows.Next() {
+		var i JournalEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.HouseID,
+			&i.EntryType,
+			&i.EntryDate,
+			&i.Contact,
+			&i.Summary,
+			&i.FollowUpDate,
+			&i.FollowUpDone,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestPublicationChecks = `-- name: ListLatestPublicationChecks :many
+SELECT publication_urls.house_id, publication_checks.removed, publication_checks.error
+FROM publication_checks
+JOIN publication_urls ON publication_checks.publication_url_id = publication_urls.id
+WHERE publication_checks.url = publication_urls.url
+AND publication_checks.id = (
+	SELECT MAX(latest.id) FROM publication_checks AS latest
+	WHERE latest.publication_url_id = publication_checks.publication_url_id
+)
+`
+
+type ListLatestPublicationChecksRow struct {
+	HouseID int64
+	Removed bool
+	Error   string
+}
+
+func (q *Queries) ListLatestPublicationChecks(ctx context.Context) ([]ListLatestPublicationChecksRow, error) {
+	rows, err := q.query(ctx, q.listLatestPublicationChecksStmt, listLatestPublicationChecks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLatestPublicationChecksRow
+	for rows.Next() {
+		var i ListLatestPublicationChecksRow
+		if err := rows.Scan(&i.HouseID, &i.Removed, &i.Error); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMapBoundariesInBox = `-- name: ListMapBoundariesInBox :many
+SELECT id, name, code, rings, min_latitude, max_latitude, min_longitude, max_longitude FROM map_boundaries
+WHERE max_latitude >= ?1 AND min_latitude <= ?2
+AND max_longitude >= ?3 AND min_longitude <= ?4
+ORDER BY name
+`
+
+type ListMapBoundariesInBoxParams struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+func (q *Queries) ListMapBoundariesInBox(ctx context.Context, arg ListMapBoundariesInBoxParams) ([]MapBoundary, error) {
+	rows, err := q.query(ctx, q.listMapBoundariesInBoxStmt, listMapBoundariesInBox,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MapBoundary
+	for rows.Next() {
+		var i MapBoundary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Code,
+			&i.Rings,
+			&i.MinLatitude,
+			&i.MaxLatitude,
+			&i.MinLongitude,
+			&i.MaxLongitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNeighbourhoods = `-- name: ListNeighbourhoods :many
+SELECT id, city_id, name, city_name, city_postal_code, is_used FROM neighbourhoods_with_used
+ORDER BY city_name, city_postal_code, name
+`
+
+func (q *Queries) ListNeighbourhoods(ctx context.Context) ([]Neighbourhood, error) {
+	rows, err := q.query(ctx, q.listNeighbourhoodsStmt, listNeighbourhoods)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Neighbourhood
+	for rows.Next() {
+		var i Neighbourhood
+		if err := rows.Scan(
+			&i.ID,
+			&i.CityID,
+			&i.Name,
+			&i.CityName,
+			&i.CityPostalCode,
+			&i.IsUsed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotifications = `-- name: ListNotifications :many
+SELECT id, created_at, house_id, notification_type, url, old_price, new_price, is_read, house_title FROM notifications_with_houses
+ORDER BY is_read, created_at DESC, id DESC
+`
+
+func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error) {
+	rows, err := q.query(ctx, q.listNotificationsStmt, listNotifications)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
 		if err := rows.Scan(
 			&i.ID,
+			&i.CreatedAt,
 			&i.HouseID,
+			&i.NotificationType,
 			&i.URL,
-			&i.PublicationDate,
+			&i.OldPrice,
+			&i.NewPrice,
+			&i.IsRead,
+			&i.HouseTitle,
 		); err != nil {
 			return nil, err
 		}
@@ -231,21 +1752,29 @@ func (q *Queries) GetPublicationURLs(ctx context.Context, houseID int64) ([]Publ
 	return items, nil
 }
 
-const listCities = `-- name: ListCities :many
-SELECT id, name, is_used FROM cities_with_used
+const listPointsOfInterest = `-- name: ListPointsOfInterest :many
+SELECT id, name, latitude, longitude, category, travel_mode, max_travel_time FROM points_of_interest
 ORDER BY name
 `
 
-func (q *Queries) ListCities(ctx context.Context) ([]City, error) {
-	rows, err := q.query(ctx, q.listCitiesStmt, listCities)
+func (q *Queries) ListPointsOfInterest(ctx context.Context) ([]DBPointOfInterest, error) {
+	rows, err := q.query(ctx, q.listPointsOfInterestStmt, listPointsOfInterest)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []City
+	var items []DBPointOfInterest
 	for rows.Next() {
-		var i City
-		if err := rows.Scan(&i.ID, &i.Name, &i.IsUsed); err != nil {
+		var i DBPointOfInterest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Category,
+			&i.TravelMode,
+			&i.MaxTravelTime,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -259,41 +1788,211 @@ func (q *Queries) ListCities(ctx context.Context) ([]City, error) {
 	return items, nil
 }
 
-const listHouses = `-- name: ListHouses :many
-SELECT id, created_at, updated_at, title, city_id, address, price, surface, rooms, bedrooms, bathrooms, floors, construction_year, house_type, land_surface, has_garage, outdoor_parking_spaces, main_photo, notes, city_name FROM houses_with_cities
-ORDER BY created_at DESC
+const listTags = `-- name: ListTags :many
+SELECT id, name, color, house_count FROM tags_with_usage
+ORDER BY name
 `
 
-func (q *Queries) ListHouses(ctx context.Context) ([]House, error) {
-	rows, err := q.query(ctx, q.listHousesStmt, listHouses)
+func (q *Queries) ListTags(ctx context.Context) ([]Tag, error) {
+	rows, err := q.query(ctx, q.listTagsStmt, listTags)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []House
+	var items []Tag
 	for rows.Next() {
-		var i House
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Color,
+			&i.HouseCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTasks = `-- name: ListTasks :many
+SELECT id, created_at, house_id, title, due_date, assignee, done, house_title FROM tasks_with_houses
+ORDER BY done, date(due_date) = '0001-01-01', due_date, id
+`
+
+func (q *Queries) ListTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.query(ctx, q.listTasksStmt, listTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
 		if err := rows.Scan(
 			&i.ID,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.HouseID,
 			&i.Title,
-			&i.CityID,
-			&i.Address,
-			&i.Price,
-			&i.Surface,
-			&i.Rooms,
-			&i.Bedrooms,
-			&i.Bathrooms,
-			&i.Floors,
-			&i.ConstructionYear,
-			&i.HouseType,
-			&i.LandSurface,
-			&i.HasGarage,
-			&i.OutdoorParkingSpaces,
-			&i.MainPhoto,
+			&i.DueDate,
+			&i.Assignee,
+			&i.Done,
+			&i.HouseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET is_read = TRUE
+WHERE is_read = FALSE
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context) error {
+	_, err := q.exec(ctx, q.markAllNotificationsReadStmt, markAllNotificationsRead)
+	return err
+}
+
+const markNotificationRead = `-- name: MarkNotificationRead :exec
+UPDATE notifications
+SET is_read = TRUE
+WHERE id = ?
+`
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.markNotificationReadStmt, markNotificationRead, id)
+	return err
+}
+
+const moveCityHouses = `-- name: MoveCityHouses :exec
+UPDATE houses
+SET city_id = ?1
+WHERE city_id = ?2
+`
+
+func (q *Queries) MoveCityHouses(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.moveCityHousesStmt, moveCityHouses, targetID, sourceID)
+	return err
+}
+
+const moveCityNeighbourhoods = `-- name: MoveCityNeighbourhoods :exec
+UPDATE neighbourhoods
+SET city_id = ?1
+WHERE city_id = ?2
+`
+
+func (q *Queries) MoveCityNeighbourhoods(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.moveCityNeighbourhoodsStmt, moveCityNeighbourhoods, targetID, sourceID)
+	return err
+}
+
+const moveJournalEntries = `-- name: MoveJournalEntries :exec
+UPDATE journal_entries
+SET house_id = ?1
+WHERE house_id = ?2
+`
+
+func (q *Queries) MoveJournalEntries(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.moveJournalEntriesStmt, moveJournalEntries, targetID, sourceID)
+	return err
+}
+
+const moveNeighbourhoodHouses = `-- name: MoveNeighbourhoodHouses :exec
+UPDATE houses
+SET neighbourhood_id = ?1
+WHERE neighbourhood_id = ?2
+`
+
+func (q *Queries) MoveNeighbourhoodHouses(ctx context.Context, targetID sql.NullInt64, sourceID sql.NullInt64) error {
+	_, err := q.exec(ctx, q.moveNeighbourhoodHousesStmt, moveNeighbourhoodHouses, targetID, sourceID)
+	return err
+}
+
+const moveNotifications = `-- name: MoveNotifications :exec
+UPDATE notifications
+SET house_id = ?1
+WHERE house_id = ?2
+`
+
+func (q *Queries) MoveNotifications(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.moveNotificationsStmt, moveNotifications, targetID, sourceID)
+	return err
+}
+
+const movePublicationURLs = `-- name: MovePublicationURLs :exec
+UPDATE publication_urls
+SET house_id = ?1
+WHERE house_id = ?2
+`
+
+func (q *Queries) MovePublicationURLs(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.movePublicationURLsStmt, movePublicationURLs, targetID, sourceID)
+	return err
+}
+
+const moveTasks = `-- name: MoveTasks :exec
+UPDATE tasks
+SET house_id = ?1
+WHERE house_id = ?2
+`
+
+func (q *Queries) MoveTasks(ctx context.Context, targetID int64, sourceID int64) error {
+	_, err := q.exec(ctx, q.moveTasksStmt, moveTasks, targetID, sourceID)
+	return err
+}
+
+const renameNeighbourhood = `-- name: RenameNeighbourhood :exec
+UPDATE neighbourhoods
+SET name = ?
+WHERE id = ?2
+`
+
+func (q *Queries) RenameNeighbourhood(ctx context.Context, name string, iD int64) error {
+	_, err := q.exec(ctx, q.renameNeighbourhoodStmt, renameNeighbourhood, name, iD)
+	return err
+}
+
+const searchCities = `-- name: SearchCities :many
+SELECT id, name, postal_code, insee_code, department, population, notes, is_used FROM cities_with_used
+WHERE name LIKE '%' || CAST(?1 AS TEXT) || '%' OR postal_code LIKE CAST(?1 AS TEXT) || '%'
+ORDER BY is_used DESC, name, postal_code
+LIMIT 20
+`
+
+func (q *Queries) SearchCities(ctx context.Context, search string) ([]City, error) {
+	rows, err := q.query(ctx, q.searchCitiesStmt, searchCities, search)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []City
+	for rows.Next() {
+		var i City
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PostalCode,
+			&i.INSEECode,
+			&i.Department,
+			&i.Population,
 			&i.Notes,
-			&i.CityName,
+			&i.IsUsed,
 		); err != nil {
 			return nil, err
 		}
@@ -308,14 +2007,94 @@ func (q *Queries) ListHouses(ctx context.Context) ([]House, error) {
 	return items, nil
 }
 
+const setHouseCoordinates = `-- name: SetHouseCoordinates :exec
+UPDATE houses
+SET
+	updated_at = CURRENT_TIMESTAMP,
+	latitude = ?,
+	longitude = ?
+WHERE id = ?3
+`
+
+type SetHouseCoordinatesParams struct {
+	Latitude  float64
+	Longitude float64
+	ID        int64
+}
+
+func (q *Queries) SetHouseCoordinates(ctx context.Context, arg SetHouseCoordinatesParams) error {
+	_, err := q.exec(ctx, q.setHouseCoordinatesStmt, setHouseCoordinates, arg.Latitude, arg.Longitude, arg.ID)
+	return err
+}
+
+const setTaskDone = `-- name: SetTaskDone :exec
+UPDATE tasks
+SET done = ?
+WHERE id = ?2
+`
+
+func (q *Queries) SetTaskDone(ctx context.Context, done bool, iD int64) error {
+	_, err := q.exec(ctx, q.setTaskDoneStmt, setTaskDone, done, iD)
+	return err
+}
+
 const updateCity = `-- name: UpdateCity :exec
 UPDATE cities
-SET name = ?
-WHERE cities.id = ?2
+SET name = ?,
+	postal_code = ?,
+	insee_code = ?,
+	department = ?,
+	population = ?,
+	notes = ?
+WHERE cities.id = ?7
+`
+
+type UpdateCityParams struct {
+	Name       string
+	PostalCode string
+	INSEECode  string
+	Department string
+	Population int64
+	Notes      string
+	ID         int64
+}
+
+func (q *Queries) UpdateCity(ctx context.Context, arg UpdateCityParams) error {
+	_, err := q.exec(ctx, q.updateCityStmt, updateCity,
+		arg.Name,
+		arg.PostalCode,
+		arg.INSEECode,
+		arg.Department,
+		arg.Population,
+		arg.Notes,
+		arg.ID,
+	)
+	return err
+}
+
+const updateCustomField = `-- name: UpdateCustomField :exec
+UPDATE custom_fields
+SET
+	name = ?,
+	unit = ?,
+	choices = ?
+WHERE id = ?
 `
 
-func (q *Queries) UpdateCity(ctx context.Context, name string, iD int64) error {
-	_, err := q.exec(ctx, q.updateCityStmt, updateCity, name, iD)
+type UpdateCustomFieldParams struct {
+	Name    string
+	Unit    string
+	Choices string
+	ID      int64
+}
+
+func (q *Queries) UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) error {
+	_, err := q.exec(ctx, q.updateCustomFieldStmt, updateCustomField,
+		arg.Name,
+		arg.Unit,
+		arg.Choices,
+		arg.ID,
+	)
 	return err
 }
 
@@ -338,7 +2117,10 @@ SET
 	has_garage = ?,
 	outdoor_parking_spaces = ?,
 	main_photo = ?,
-	notes = ?
+	notes = ?,
+	latitude = ?,
+	longitude = ?,
+	neighbourhood_id = ?
 WHERE id = ?
 `
 
@@ -359,6 +2141,9 @@ type UpdateHouseParams struct {
 	OutdoorParkingSpaces int64
 	MainPhoto            string
 	Notes                string
+	Latitude             float64
+	Longitude            float64
+	NeighbourhoodID      sql.NullInt64
 	ID                   int64
 }
 
@@ -380,6 +2165,44 @@ func (q *Queries) UpdateHouse(ctx context.Context, arg UpdateHouseParams) error
 		arg.OutdoorParkingSpaces,
 		arg.MainPhoto,
 		arg.Notes,
+		arg.Latitude,
+		arg.Longitude,
+		arg.NeighbourhoodID,
+		arg.ID,
+	)
+	return err
+}
+
+const updatePointOfInterest = `-- name: UpdatePointOfInterest :exec
+UPDATE points_of_interest
+SET
+	name = ?,
+	category = ?,
+	latitude = ?,
+	longitude = ?,
+	travel_mode = ?,
+	max_travel_time = ?
+WHERE id = ?
+`
+
+type UpdatePointOfInterestParams struct {
+	Name          string
+	Category      string
+	Latitude      float64
+	Longitude     float64
+	TravelMode    string
+	MaxTravelTime int64
+	ID            int64
+}
+
+func (q *Queries) UpdatePointOfInterest(ctx context.Context, arg UpdatePointOfInterestParams) error {
+	_, err := q.exec(ctx, q.updatePointOfInterestStmt, updatePointOfInterest,
+		arg.Name,
+		arg.Category,
+		arg.Latitude,
+		arg.Longitude,
+		arg.TravelMode,
+		arg.MaxTravelTime,
 		arg.ID,
 	)
 	return err
@@ -403,3 +2226,102 @@ func (q *Queries) UpdatePublicationURL(ctx context.Context, arg UpdatePublicatio
 	_, err := q.exec(ctx, q.updatePublicationURLStmt, updatePublicationURL, arg.URL, arg.PublicationDate, arg.ID)
 	return err
 }
+
+const updateTag = `-- name: UpdateTag :exec
+UPDATE tags
+SET
+	name = ?,
+	color = ?
+WHERE id = ?
+`
+
+type UpdateTagParams struct {
+	Name  string
+	Color string
+	ID    int64
+}
+
+func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) error {
+	_, err := q.exec(ctx, q.updateTagStmt, updateTag, arg.Name, arg.Color, arg.ID)
+	return err
+}
+
+const upsertBANAddress = `-- name: UpsertBANAddress :exec
+INSERT OR REPLACE INTO ban_addresses (
+	id,
+	number,
+	street,
+	postal_code,
+	city_name,
+	latitude,
+	longitude
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type UpsertBANAddressParams struct {
+	ID         string
+	Number     string
+	Street     string
+	PostalCode string
+	CityName   string
+	Latitude   float64
+	Longitude  float64
+}
+
+func (q *Queries) UpsertBANAddress(ctx context.Context, arg UpsertBANAddressParams) error {
+	_, err := q.exec(ctx, q.upsertBANAddressStmt, upsertBANAddress,
+		arg.ID,
+		arg.Number,
+		arg.Street,
+		arg.PostalCode,
+		arg.CityName,
+		arg.Latitude,
+		arg.Longitude,
+	)
+	return err
+}
+
+const upsertMapBoundary = `-- name: UpsertMapBoundary :exec
+INSERT INTO map_boundaries (
+	name,
+	code,
+	rings,
+	min_latitude,
+	max_latitude,
+	min_longitude,
+	max_longitude
+) VALUES (
+	?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (name, code) DO UPDATE SET
+	rings = excluded.rings,
+	min_latitude = excluded.min_latitude,
+	max_latitude = excluded.max_latitude,
+	min_longitude = excluded.min_longitude,
+	max_longitude = excluded.max_longitude
+`
+
+type UpsertMapBoundaryParams struct {
+	Name         string
+	Code         string
+	Rings        string
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+func (q *Queries) UpsertMapBoundary(ctx context.Context, arg UpsertMapBoundaryParams) error {
+	_, err := q.exec(ctx, q.upsertMapBoundaryStmt, upsertMapBoundary,
+		arg.Name,
+		arg.Code,
+		arg.Rings,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+	)
+	return err
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"time"
+)
+
+// APIDateLayout is the format of the dates without time in the API
+const APIDateLayout = "2006-01-02"
+
+// APIError is the body of the API responses for errors
+type APIError struct {
+	Error APIErrorDetails `json:"error"`
+}
+
+// APIErrorDetails describes an API error
+type APIErrorDetails struct {
+	Code    string            `json:"code"`             // Stable identifier of the error, such as "not_found"
+	Message string            `json:"message"`          // Human readable message, in french
+	Fields  map[string]string `json:"fields,omitempty"` // Error of each invalid field, by property name
+}
+
+// APITag is the API representation of a tag
+type APITag struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// APIHouse is the API representation of a house
+type APIHouse struct {
+	ID                   int64            `json:"id"`
+	Title                string           `json:"title"`
+	CityID               int64            `json:"city_id"`
+	CityName             string           `json:"city_name"`
+	NeighbourhoodID      int64            `json:"neighbourhood_id"` // Zero when unknown
+	NeighbourhoodName    string           `json:"neighbourhood_name"`
+	Address              string           `json:"address"`
+	Price                int64            `json:"price"`
+	Surface              int64            `json:"surface"`
+	Rooms                int64            `json:"rooms"`
+	Bedrooms             int64            `json:"bedrooms"`
+	Bathrooms            int64            `json:"bathrooms"`
+	Floors               int64            `json:"floors"`
+	ConstructionYear     int64            `json:"construction_year"`
+	HouseType            string           `json:"house_type"`
+	LandSurface          int64            `json:"land_surface"`
+	HasGarage            bool             `json:"has_garage"`
+	OutdoorParkingSpaces int64            `json:"outdoor_parking_spaces"`
+	MainPhoto            string           `json:"main_photo"`
+	Notes                string           `json:"notes"`
+	Latitude             float64          `json:"latitude"` // Zero when unknown, like longitude
+	Longitude            float64          `json:"longitude"`
+	Tags                 []APITag         `json:"tags"`
+	CustomValues         map[int64]string `json:"custom_values"` // By custom field ID
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+}
+
+// NewAPIHouse converts a models.House to its API representation
+func NewAPIHouse(h House) APIHouse {
+	tags := make([]APITag, len(h.Tags))
+	for i, tag := range h.Tags {
+		tags[i] = APITag{ID: tag.ID, Name: tag.Name, Color: tag.Color}
+	}
+
+	customValues := h.CustomValues
+	if customValues == nil {
+		customValues = map[int64]string{}
+	}
+
+	return APIHouse{
+		ID:                   h.ID,
+		Title:                h.Title,
+		CityID:               h.CityID,
+		CityName:             h.CityName,
+		NeighbourhoodID:      h.NeighbourhoodID,
+		NeighbourhoodName:    h.NeighbourhoodName,
+		Address:              h.Address,
+		Price:                h.Price,
+		Surface:              h.Surface,
+		Rooms:                h.Rooms,
+		Bedrooms:             h.Bedrooms,
+		Bathrooms:            h.Bathrooms,
+		Floors:               h.Floors,
+		ConstructionYear:     h.ConstructionYear,
+		HouseType:            h.HouseType,
+		LandSurface:          h.LandSurface,
+		HasGarage:            h.HasGarage,
+		OutdoorParkingSpaces: h.OutdoorParkingSpaces,
+		MainPhoto:            h.MainPhoto,
+		Notes:                h.Notes,
+		Latitude:             h.Latitude,
+		Longitude:            h.Longitude,
+		Tags:                 tags,
+		CustomValues:         customValues,
+		CreatedAt:            h.CreatedAt,
+		UpdatedAt:            h.UpdatedAt,
+	}
+}
+
+// APIHouseInput is the body of the API requests creating or replacing a house
+type APIHouseInput struct {
+	Title                string           `json:"title"`
+	CityID               int64            `json:"city_id"`
+	NeighbourhoodID      int64            `json:"neighbourhood_id"`
+	Address              string           `json:"address"`
+	Price                int64            `json:"price"`
+	Surface              int64            `json:"surface"`
+	Rooms                int64            `json:"rooms"`
+	Bedrooms             int64            `json:"bedrooms"`
+	Bathrooms            int64            `json:"bathrooms"`
+	Floors               int64            `json:"floors"`
+	ConstructionYear     int64            `json:"construction_year"`
+	HouseType            string           `json:"house_type"`
+	LandSurface          int64            `json:"land_surface"`
+	HasGarage            bool             `json:"has_garage"`
+	OutdoorParkingSpaces int64            `json:"outdoor_parking_spaces"`
+	MainPhoto            string           `json:"main_photo"`
+	Notes                string           `json:"notes"`
+	Latitude             float64          `json:"latitude"`
+	Longitude            float64          `json:"longitude"`
+	TagIDs               []int64          `json:"tag_ids"`
+	CustomValues         map[int64]string `json:"custom_values"` // By custom field ID
+}
+
+// House converts the input to a models.House
+func (in APIHouseInput) House() House {
+	tags := make([]Tag, len(in.TagIDs))
+	for i, id := range in.TagIDs {
+		tags[i] = Tag{ID: id}
+	}
+
+	return House{
+		Title:                in.Title,
+		CityID:               in.CityID,
+		NeighbourhoodID:      in.NeighbourhoodID,
+		Address:              in.Address,
+		Price:                in.Price,
+		Surface:              in.Surface,
+		Rooms:                in.Rooms,
+		Bedrooms:             in.Bedrooms,
+		Bathrooms:            in.Bathrooms,
+		Floors:               in.Floors,
+		ConstructionYear:     in.ConstructionYear,
+		HouseType:            in.HouseType,
+		LandSurface:          in.LandSurface,
+		HasGarage:            in.HasGarage,
+		OutdoorParkingSpaces: in.OutdoorParkingSpaces,
+		MainPhoto:            in.MainPhoto,
+		Notes:                in.Notes,
+		Latitude:             in.Latitude,
+		Longitude:            in.Longitude,
+		Tags:                 tags,
+		CustomValues:         in.CustomValues,
+	}
+}
+
+// APICity is the API representation of a city
+type APICity struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	PostalCode string `json:"postal_code"`
+	INSEECode  string `json:"insee_code"`
+	Department string `json:"department"`
+	Population int64  `json:"population"`
+	Notes      string `json:"notes"`
+	IsUsed     bool   `json:"is_used"`
+}
+
+// NewAPICity converts a models.City to its API representation
+func NewAPICity(c City) APICity {
+	return APICity{
+		ID:         c.ID,
+		Name:       c.Name,
+		PostalCode: c.PostalCode,
+		INSEECode:  c.INSEECode,
+		Department: c.Department,
+		Population: c.Population,
+		Notes:      c.Notes,
+		IsUsed:     c.IsUsed,
+	}
+}
+
+// APICityInput is the body of the API requests creating or updating a city
+type APICityInput struct {
+	Name       string `json:"name"`
+	PostalCode string `json:"postal_code"`
+	INSEECode  string `json:"insee_code"`
+	Department string `json:"department"`
+	Population int64  `json:"population"`
+	Notes      string `json:"notes"`
+}
+
+// City converts the input to a models.City
+func (in APICityInput) City() City {
+	return City{
+		Name:       in.Name,
+		PostalCode: in.PostalCode,
+		INSEECode:  in.INSEECode,
+		Department: in.Department,
+		Population: in.Population,
+		Notes:      in.Notes,
+	}
+}
+
+// APIPublicationURL is the API representation of a publication URL
+type APIPublicationURL struct {
+	ID              int64  `json:"id"`
+	HouseID         int64  `json:"house_id"`
+	URL             string `json:"url"`
+	PublicationDate string `json:"publication_date"` // YYYY-MM-DD
+}
+
+// NewAPIPublicationURL converts a models.PublicationURL to its API representation
+func NewAPIPublicationURL(p PublicationURL) APIPublicationURL {
+	return APIPublicationURL{
+		ID:              p.ID,
+		HouseID:         p.HouseID,
+		URL:             p.URL,
+		PublicationDate: p.PublicationDate.Format(APIDateLayout),
+	}
+}
+
+// APIPublicationURLInput is the body of the API requests creating or
+// replacing a publication URL
+type APIPublicationURLInput struct {
+	URL             string `json:"url"`
+	PublicationDate string `json:"publication_date"` // YYYY-MM-DD
+}
+
+// APIFile is the API representation of a file uploaded for a house
+type APIFile struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIHouseFiles lists the files uploaded for a house
+type APIHouseFiles struct {
+	Photos      []APIFile `json:"photos"`
+	Attachments []APIFile `json:"attachments"`
+}
@@ -6,17 +6,36 @@ import (
 
 // City represents a city
 type City struct {
-	ID     int64
-	Name   string
-	IsUsed bool
+	ID         int64
+	Name       string
+	PostalCode string
+	INSEECode  string
+	Department string
+	Population int64 // Zero value when unknown
+	Notes      string
+	IsUsed     bool
+}
+
+// DisplayName returns the name of the city, followed by its postal code when
+// known, to tell apart cities with the same name
+func (c City) DisplayName() string {
+	if c.PostalCode == "" {
+		return c.Name
+	}
+	return c.Name + " (" + c.PostalCode + ")"
 }
 
 // FromDBCity converts a db.City to a models.City
 func FromDBCity(dbCity db.City) City {
 	return City{
-		ID:     dbCity.ID,
-		Name:   dbCity.Name,
-		IsUsed: dbCity.IsUsed,
+		ID:         dbCity.ID,
+		Name:       dbCity.Name,
+		PostalCode: dbCity.PostalCode,
+		INSEECode:  dbCity.INSEECode,
+		Department: dbCity.Department,
+		Population: dbCity.Population,
+		Notes:      dbCity.Notes,
+		IsUsed:     dbCity.IsUsed,
 	}
 }
 
@@ -28,3 +47,78 @@ func FromDBCities(dbCities []db.City) []City {
 	}
 	return cities
 }
+
+// CityFormError is an error about a form of the cities pages, shown next to
+// the form with the submitted values
+type CityFormError struct {
+	CityID  int64  // Zero for the creation form
+	Action  string // create, update, merge or delete
+	City    City   // Submitted city fields
+	Target  string // Submitted merge target
+	Message string
+}
+
+// For reports whether the error is about the form of the given action for the
+// given city
+func (e CityFormError) For(cityID int64, action string) bool {
+	return e.Message != "" && e.CityID == cityID && e.Action == action
+}
+
+// Values returns the values to show in the form of a city: the submitted
+// ones when the error is about this form, the city otherwise
+func (e CityFormError) Values(city City, action string) City {
+	if !e.For(city.ID, action) {
+		return city
+	}
+	values := e.City
+	values.ID = city.ID
+	return values
+}
+
+// CityStats represents aggregate figures about the houses of a city. Averages
+// only account for the houses where the value is known.
+type CityStats struct {
+	HouseCount                 int
+	MinPrice                   int64
+	MaxPrice                   int64
+	AveragePrice               int64
+	AverageSurface             int64
+	AveragePricePerSquareMeter int64
+}
+
+// NewCityStats computes the aggregate figures about the houses of a city
+func NewCityStats(houses []House) CityStats {
+	stats := CityStats{HouseCount: len(houses)}
+
+	var priceSum, priceCount, surfaceSum, surfaceCount, perMeterSum, perMeterCount int64
+	for _, house := range houses {
+		if house.Price > 0 {
+			if stats.MinPrice == 0 || house.Price < stats.MinPrice {
+				stats.MinPrice = house.Price
+			}
+			stats.MaxPrice = max(stats.MaxPrice, house.Price)
+			priceSum += house.Price
+			priceCount++
+		}
+		if house.Surface > 0 {
+			surfaceSum += house.Surface
+			surfaceCount++
+		}
+		if house.Price > 0 && house.Surface > 0 {
+			perMeterSum += house.Price / house.Surface
+			perMeterCount++
+		}
+	}
+
+	if priceCount > 0 {
+		stats.AveragePrice = priceSum / priceCount
+	}
+	if surfaceCount > 0 {
+		stats.AverageSurface = surfaceSum / surfaceCount
+	}
+	if perMeterCount > 0 {
+		stats.AveragePricePerSquareMeter = perMeterSum / perMeterCount
+	}
+
+	return stats
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strings"
+)
+
+// Commune represents a french commune of the official list, which may be
+// imported as a city
+type Commune struct {
+	Name        string
+	PostalCode  string
+	INSEECode   string
+	Department  string
+	Population  int64 // Zero value when unknown
+	Coordinates Coordinates
+}
+
+// City returns the city matching the commune
+func (c Commune) City() City {
+	return City{
+		Name:       c.Name,
+		PostalCode: c.PostalCode,
+		INSEECode:  c.INSEECode,
+		Department: c.Department,
+		Population: c.Population,
+	}
+}
+
+// CommuneSelection selects the communes to import: those of the given
+// departments, and those within a radius around a point. An empty selection
+// selects all communes.
+type CommuneSelection struct {
+	Departments []string
+	Center      Coordinates
+	Radius      float64 // Kilometers, zero when not selecting by distance
+}
+
+// IsEmpty reports whether the selection selects all communes
+func (s CommuneSelection) IsEmpty() bool {
+	return len(s.Departments) == 0 && s.Radius == 0
+}
+
+// Matches reports whether a commune is selected
+func (s CommuneSelection) Matches(c Commune) bool {
+	if s.IsEmpty() {
+		return true
+	}
+	for _, department := range s.Departments {
+		if NormalizeDepartment(c.Department) == department {
+			return true
+		}
+	}
+	if s.Radius > 0 && !c.Coordinates.IsZero() {
+		return s.Center.DistanceTo(c.Coordinates) <= s.Radius*1000
+	}
+	return false
+}
+
+// ParseDepartments parses a list of department codes separated with commas
+// or spaces, such as "44, 85"
+func ParseDepartments(s string) []string {
+	var departments []string
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' '
+	}) {
+		departments = append(departments, NormalizeDepartment(field))
+	}
+	return departments
+}
+
+// NormalizeDepartment returns a department code in its usual form, with two
+// digits for the departments before the tenth and upper-case letters for
+// Corsica, as in "01" and "2A"
+func NormalizeDepartment(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) == 1 {
+		return "0" + code
+	}
+	return code
+}
+
+// DepartmentFromINSEECode returns the department code of a commune from its
+// INSEE code, the overseas departments having three digits
+func DepartmentFromINSEECode(code string) string {
+	switch {
+	case len(code) < 3:
+		return ""
+	case strings.HasPrefix(code, "97"), strings.HasPrefix(code, "98"):
+		return code[:3]
+	default:
+		return NormalizeDepartment(code[:2])
+	}
+}
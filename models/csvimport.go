@@ -0,0 +1,86 @@
+package models
+
+// CSVImportColumn is a house field that a column of an imported CSV file can be mapped to
+type CSVImportColumn struct {
+	Name     string   // Identifier of the field, used in forms
+	Label    string   // Displayed name of the field
+	Aliases  []string // Normalized headers automatically mapped to the field
+	Required bool
+}
+
+// CSV import column names
+const (
+	CSVImportTitle                = "title"
+	CSVImportCity                 = "city"
+	CSVImportAddress              = "address"
+	CSVImportPrice                = "price"
+	CSVImportSurface              = "surface"
+	CSVImportRooms                = "rooms"
+	CSVImportBedrooms             = "bedrooms"
+	CSVImportBathrooms            = "bathrooms"
+	CSVImportFloors               = "floors"
+	CSVImportConstructionYear     = "construction_year"
+	CSVImportHouseType            = "house_type"
+	CSVImportLandSurface          = "land_surface"
+	CSVImportHasGarage            = "has_garage"
+	CSVImportOutdoorParkingSpaces = "outdoor_parking_spaces"
+	CSVImportNotes                = "notes"
+	CSVImportPublicationURLs      = "publication_urls"
+	CSVImportPublicationDate      = "publication_date"
+)
+
+// CSVImportColumns lists the fields that CSV columns can be mapped to
+var CSVImportColumns = []CSVImportColumn{
+	{CSVImportTitle, "Titre", []string{"titre", "title", "nom", "annonce"}, true},
+	{CSVImportCity, "Ville", []string{"ville", "city", "commune"}, true},
+	{CSVImportAddress, "Adresse", []string{"adresse", "address"}, false},
+	{CSVImportPrice, "Prix (€)", []string{"prix", "price"}, true},
+	{CSVImportSurface, "Surface (m²)", []string{"surface", "surfacehabitable"}, true},
+	{CSVImportRooms, "Pièces", []string{"pieces", "nombredepieces", "rooms"}, true},
+	{CSVImportBedrooms, "Chambres", []string{"chambres", "nombredechambres", "bedrooms"}, false},
+	{CSVImportBathrooms, "Salles de bain", []string{"sallesdebain", "sallesdebains", "sallesdeau", "sdb", "bathrooms"}, false},
+	{CSVImportFloors, "Niveaux", []string{"niveaux", "etages", "floors"}, true},
+	{CSVImportConstructionYear, "Année de construction", []string{"anneedeconstruction", "annee", "constructionyear"}, false},
+	{CSVImportHouseType, "Type", []string{"type", "typedemaison", "typedebien", "housetype"}, true},
+	{CSVImportLandSurface, "Surface du terrain (m²)", []string{"surfaceduterrain", "terrain", "landsurface"}, false},
+	{CSVImportHasGarage, "Garage", []string{"garage", "hasgarage"}, false},
+	{CSVImportOutdoorParkingSpaces, "Places de stationnement extérieures", []string{"placesdestationnementexterieures", "stationnement", "parking", "outdoorparkingspaces"}, false},
+	{CSVImportNotes, "Notes", []string{"notes", "remarques", "commentaires"}, false},
+	{CSVImportPublicationURLs, "Publications (URL)", []string{"publications", "publication", "url", "urls", "lien", "liens"}, false},
+	{CSVImportPublicationDate, "Date de publication", []string{"datedepublication", "publicationdate"}, false},
+}
+
+// CSVImportPreview is the result of the analysis of a CSV file before its import
+type CSVImportPreview struct {
+	Headers []string
+	Mapping []string // Column name mapped to each CSV column, empty when the column is ignored
+	Rows    []CSVImportRow
+	Errors  []string // Errors about the whole file, such as a missing required column
+}
+
+// HasErrors reports whether the file cannot be imported as is
+func (p CSVImportPreview) HasErrors() bool {
+	if len(p.Errors) > 0 {
+		return true
+	}
+	for _, row := range p.Rows {
+		if row.HasErrors() {
+			return true
+		}
+	}
+	return false
+}
+
+// CSVImportRow is a row of an imported CSV file
+type CSVImportRow struct {
+	Line            int // Line number in the file, starting at 1 for the headers
+	Values          []string
+	Errors          map[int]string // Error messages, by column index
+	House           House
+	PublicationURLs []PublicationURL
+}
+
+// HasErrors reports whether the row cannot be imported as is
+func (r CSVImportRow) HasErrors() bool {
+	return len(r.Errors) > 0
+}
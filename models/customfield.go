@@ -0,0 +1,259 @@
+package models
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Custom field types
+const (
+	CustomFieldInteger = "entier"
+	CustomFieldBoolean = "booleen"
+	CustomFieldText    = "texte"
+	CustomFieldChoice  = "choix"
+)
+
+// CustomFieldTypes lists the available custom field types, in display order
+var CustomFieldTypes = []string{
+	CustomFieldInteger,
+	CustomFieldBoolean,
+	CustomFieldText,
+	CustomFieldChoice,
+}
+
+// ErrInvalidCustomValue is returned when a value does not match the type of its custom field
+var ErrInvalidCustomValue = errors.New("invalid custom field value")
+
+// CustomFieldTypeLabel returns the french label for a custom field type
+func CustomFieldTypeLabel(fieldType string) string {
+	switch fieldType {
+	case CustomFieldInteger:
+		return "Nombre entier"
+	case CustomFieldBoolean:
+		return "Oui / non"
+	case CustomFieldText:
+		return "Texte"
+	case CustomFieldChoice:
+		return "Choix dans une liste"
+	default:
+		return fieldType
+	}
+}
+
+// IsValidCustomFieldType reports whether fieldType is a known custom field type
+func IsValidCustomFieldType(fieldType string) bool {
+	for _, t := range CustomFieldTypes {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomField represents a user-defined criterion on houses
+type CustomField struct {
+	ID         int64
+	Name       string
+	Type       string
+	Unit       string
+	Choices    []string // Available values, for the choice type
+	ValueCount int64    // Number of houses having a value for this field
+}
+
+// FromDBCustomField converts a db.CustomField to a models.CustomField
+func FromDBCustomField(dbField db.CustomField) CustomField {
+	return CustomField{
+		ID:         dbField.ID,
+		Name:       dbField.Name,
+		Type:       dbField.FieldType,
+		Unit:       dbField.Unit,
+		Choices:    ParseCustomFieldChoices(dbField.Choices),
+		ValueCount: dbField.ValueCount,
+	}
+}
+
+// FromDBCustomFields converts a slice of db.CustomField to a slice of models.CustomField
+func FromDBCustomFields(dbFields []db.CustomField) []CustomField {
+	fields := make([]CustomField, len(dbFields))
+	for i, dbField := range dbFields {
+		fields[i] = FromDBCustomField(dbField)
+	}
+	return fields
+}
+
+// CustomFieldFormError is an error about a form of the custom fields page,
+// shown next to the form with the submitted values
+type CustomFieldFormError struct {
+	FieldID int64       // Zero for the creation form
+	Action  string      // create, update or delete
+	Field   CustomField // Submitted field definition
+	Message string
+}
+
+// For reports whether the error is about the form of the given action for
+// the given field
+func (e CustomFieldFormError) For(fieldID int64, action string) bool {
+	return e.Message != "" && e.FieldID == fieldID && e.Action == action
+}
+
+// Values returns the values to show in the form of a field: the submitted
+// ones when the error is about this form, the field otherwise
+func (e CustomFieldFormError) Values(field CustomField, action string) CustomField {
+	if !e.For(field.ID, action) {
+		return field
+	}
+	values := field
+	values.Name = e.Field.Name
+	values.Unit = e.Field.Unit
+	values.Choices = e.Field.Choices
+	if action == "create" {
+		values.Type = e.Field.Type
+	}
+	return values
+}
+
+// ParseCustomFieldChoices splits choices written one per line, ignoring empty lines
+func ParseCustomFieldChoices(choices string) []string {
+	var result []string
+	for _, choice := range strings.Split(choices, "\n") {
+		if choice = strings.TrimSpace(choice); choice != "" {
+			result = append(result, choice)
+		}
+	}
+	return result
+}
+
+// FormatCustomFieldChoices joins choices one per line, as stored in the database
+func FormatCustomFieldChoices(choices []string) string {
+	return strings.Join(choices, "\n")
+}
+
+// ParseValue validates and normalizes a raw value for this field.
+// An empty result means the house has no value for this field.
+func (f CustomField) ParseValue(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	switch f.Type {
+	case CustomFieldInteger:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "", ErrInvalidCustomValue
+		}
+		return strconv.FormatInt(value, 10), nil
+	case CustomFieldBoolean:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", ErrInvalidCustomValue
+		}
+		return strconv.FormatBool(value), nil
+	case CustomFieldChoice:
+		for _, choice := range f.Choices {
+			if choice == raw {
+				return raw, nil
+			}
+		}
+		return "", ErrInvalidCustomValue
+	default:
+		return raw, nil
+	}
+}
+
+// FormatValue returns a displayable version of a value of this field
+func (f CustomField) FormatValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	switch f.Type {
+	case CustomFieldBoolean:
+		if value == "true" {
+			return "Oui"
+		}
+		return "Non"
+	case CustomFieldInteger:
+		if f.Unit != "" {
+			return value + " " + f.Unit
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// CompareValues compares two non-empty values of this field, in the order of
+// the field: numerically for integers, "no" before "yes" for booleans, in
+// the order of the choices for choices, alphabetically for texts
+func (f CustomField) CompareValues(a, b string) int {
+	switch f.Type {
+	case CustomFieldInteger:
+		valueA, _ := strconv.ParseInt(a, 10, 64)
+		valueB, _ := strconv.ParseInt(b, 10, 64)
+		return cmp.Compare(valueA, valueB)
+	case CustomFieldBoolean:
+		valueA, _ := strconv.ParseBool(a)
+		valueB, _ := strconv.ParseBool(b)
+		return cmp.Compare(boolRank(valueA), boolRank(valueB))
+	case CustomFieldChoice:
+		return cmp.Compare(slices.Index(f.Choices, a), slices.Index(f.Choices, b))
+	default:
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+// boolRank returns 1 for true and 0 for false, so that "no" comes first
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// CustomFieldCondition represents a criterion on a custom field in a house filter
+type CustomFieldCondition struct {
+	Field CustomField
+	Value string // Expected value for booleans and choices, searched text for texts
+	Min   string // Minimum value for integers, empty when not set
+	Max   string // Maximum value for integers, empty when not set
+}
+
+// IsEmpty reports whether the condition has no criteria
+func (c CustomFieldCondition) IsEmpty() bool {
+	return c.Value == "" && c.Min == "" && c.Max == ""
+}
+
+// Matches reports whether a custom field value matches the condition
+func (c CustomFieldCondition) Matches(value string) bool {
+	if c.IsEmpty() {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+
+	switch c.Field.Type {
+	case CustomFieldInteger:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		if minimum, err := strconv.ParseInt(c.Min, 10, 64); err == nil && number < minimum {
+			return false
+		}
+		if maximum, err := strconv.ParseInt(c.Max, 10, 64); err == nil && number > maximum {
+			return false
+		}
+		return true
+	case CustomFieldText:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	default:
+		return value == c.Value
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// DuplicateCandidate represents a house which may describe the same property as another one
+type DuplicateCandidate struct {
+	House   House
+	Score   int      // The higher, the more likely the houses are duplicates
+	Reasons []string // French descriptions of the similarities
+}
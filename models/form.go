@@ -0,0 +1,22 @@
+package models
+
+// FormErrors holds the validation errors of a form, as french messages by
+// form field name. A nil FormErrors has no errors.
+type FormErrors map[string]string
+
+// Add records the error of a field, unless the field already has one
+func (e FormErrors) Add(field, message string) {
+	if _, ok := e[field]; !ok {
+		e[field] = message
+	}
+}
+
+// Get returns the error of a field, empty if the field is valid
+func (e FormErrors) Get(field string) string {
+	return e[field]
+}
+
+// IsEmpty reports whether the form is valid
+func (e FormErrors) IsEmpty() bool {
+	return len(e) == 0
+}
@@ -0,0 +1,375 @@
+package models
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// ErrInvalidCoordinates is returned when coordinates cannot be parsed or are out of range
+var ErrInvalidCoordinates = errors.New("invalid coordinates")
+
+// earthRadius is the mean radius of the Earth, in meters
+const earthRadius = 6371000
+
+// Coordinates represents a WGS84 position, in decimal degrees
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// IsZero reports whether the coordinates are unknown
+func (c Coordinates) IsZero() bool {
+	return c.Latitude == 0 && c.Longitude == 0
+}
+
+// String returns the coordinates as "latitude, longitude", or an empty
+// string if they are unknown
+func (c Coordinates) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return strconv.FormatFloat(c.Latitude, 'f', 6, 64) + ", " + strconv.FormatFloat(c.Longitude, 'f', 6, 64)
+}
+
+// DistanceTo returns the distance as the crow flies to other coordinates, in meters
+func (c Coordinates) DistanceTo(other Coordinates) float64 {
+	lat1 := c.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - c.Longitude) * math.Pi / 180
+
+	// Haversine formula
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ParseCoordinates parses coordinates written as "latitude, longitude", as
+// copied from most online maps. The separator may also be a semicolon or
+// spaces, and decimal commas are accepted. An empty string gives zero coordinates.
+func ParseCoordinates(s string) (Coordinates, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Coordinates{}, nil
+	}
+
+	var parts []string
+	if strings.Contains(s, ";") {
+		parts = strings.Split(s, ";")
+	} else if fields := strings.Fields(s); len(fields) == 2 {
+		parts = []string{strings.TrimSuffix(fields[0], ","), fields[1]}
+	} else {
+		parts = strings.Split(s, ",")
+	}
+	if len(parts) != 2 {
+		return Coordinates{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+
+	latitude, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(parts[0]), ",", "."), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+	longitude, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(parts[1]), ",", "."), 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, s)
+	}
+
+	c := Coordinates{Latitude: latitude, Longitude: longitude}
+	if err := c.Validate(); err != nil {
+		return Coordinates{}, err
+	}
+	return c, nil
+}
+
+// Validate checks that the coordinates are in range
+func (c Coordinates) Validate() error {
+	if math.IsNaN(c.Latitude) || math.IsNaN(c.Longitude) ||
+		c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("%w: %g, %g", ErrInvalidCoordinates, c.Latitude, c.Longitude)
+	}
+	return nil
+}
+
+// Point of interest categories
+const (
+	PointOfInterestWork      = "travail"
+	PointOfInterestSchool    = "ecole"
+	PointOfInterestFamily    = "famille"
+	PointOfInterestShops     = "commerces"
+	PointOfInterestHealth    = "sante"
+	PointOfInterestTransport = "transports"
+	PointOfInterestLeisure   = "loisirs"
+	PointOfInterestOther     = "autre"
+)
+
+// PointOfInterestCategories lists the point of interest categories, in display order
+var PointOfInterestCategories = []string{
+	PointOfInterestWork,
+	PointOfInterestSchool,
+	PointOfInterestFamily,
+	PointOfInterestShops,
+	PointOfInterestHealth,
+	PointOfInterestTransport,
+	PointOfInterestLeisure,
+	PointOfInterestOther,
+}
+
+// PointOfInterestCategoryLabel returns the french label for a point of interest category
+func PointOfInterestCategoryLabel(category string) string {
+	switch category {
+	case PointOfInterestWork:
+		return "Travail"
+	case PointOfInterestSchool:
+		return "École"
+	case PointOfInterestFamily:
+		return "Famille"
+	case PointOfInterestShops:
+		return "Commerces"
+	case PointOfInterestHealth:
+		return "Santé"
+	case PointOfInterestTransport:
+		return "Transports"
+	case PointOfInterestLeisure:
+		return "Loisirs"
+	case PointOfInterestOther:
+		return "Autre"
+	default:
+		return category
+	}
+}
+
+// IsValidPointOfInterestCategory reports whether category is a known point of interest category
+func IsValidPointOfInterestCategory(category string) bool {
+	return slices.Contains(PointOfInterestCategories, category)
+}
+
+// Travel modes
+const (
+	TravelWalking = "pied"
+	TravelCycling = "velo"
+	TravelDriving = "voiture"
+)
+
+// TravelModes lists the travel modes, in display order
+var TravelModes = []string{
+	TravelWalking,
+	TravelCycling,
+	TravelDriving,
+}
+
+// TravelModeLabel returns the french label for a travel mode
+func TravelModeLabel(mode string) string {
+	switch mode {
+	case TravelWalking:
+		return "À pied"
+	case TravelCycling:
+		return "À vélo"
+	case TravelDriving:
+		return "En voiture"
+	default:
+		return mode
+	}
+}
+
+// IsValidTravelMode reports whether mode is a known travel mode
+func IsValidTravelMode(mode string) bool {
+	return slices.Contains(TravelModes, mode)
+}
+
+// detourFactor is the usual ratio between the distance by road and the
+// distance as the crow flies
+const detourFactor = 1.3
+
+// Average speeds used to estimate travel times, in km/h. Driving is slower
+// for the first kilometers, usually in town.
+const (
+	walkingSpeed     = 4.5
+	cyclingSpeed     = 15
+	townDrivingSpeed = 30
+	roadDrivingSpeed = 70
+	townDistance     = 5000 // In meters
+)
+
+// EstimateTravelTime returns the estimated travel time for a distance as the
+// crow flies, in meters, with the given travel mode
+func EstimateTravelTime(distance float64, mode string) time.Duration {
+	road := distance * detourFactor / 1000 // In kilometers
+
+	var hours float64
+	switch mode {
+	case TravelWalking:
+		hours = road / walkingSpeed
+	case TravelCycling:
+		hours = road / cyclingSpeed
+	default:
+		town := math.Min(road, townDistance/1000)
+		hours = town/townDrivingSpeed + (road-town)/roadDrivingSpeed
+	}
+
+	return time.Duration(hours * float64(time.Hour)).Round(time.Minute)
+}
+
+// PointOfInterest represents a place distances from houses are computed
+// from, such as work, school or parents
+type PointOfInterest struct {
+	ID            int64
+	Name          string
+	Category      string
+	Latitude      float64
+	Longitude     float64
+	TravelMode    string // Travel mode of the target
+	MaxTravelTime int64  // Longest acceptable travel time, in minutes, zero when there is no target
+}
+
+// Coordinates returns the position of the point of interest
+func (p PointOfInterest) Coordinates() Coordinates {
+	return Coordinates{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// HasTarget reports whether the point of interest has a longest acceptable travel time
+func (p PointOfInterest) HasTarget() bool {
+	return p.MaxTravelTime > 0
+}
+
+// PointOfInterestFormError is an error about a form of the points of interest
+// page, shown next to the form with the submitted values
+type PointOfInterestFormError struct {
+	PointID     int64           // Zero for the creation form
+	Action      string          // create or update
+	Point       PointOfInterest // Submitted point of interest fields
+	Coordinates string          // Submitted coordinates, as typed
+	Address     string          // Submitted address of the creation form
+	City        string          // Submitted city of the creation form
+	Message     string
+}
+
+// For reports whether the error is about the form of the given action for the
+// given point of interest
+func (e PointOfInterestFormError) For(pointID int64, action string) bool {
+	return e.Message != "" && e.PointID == pointID && e.Action == action
+}
+
+// Values returns the values to show in the form of a point of interest: the
+// submitted ones when the error is about this form, the point otherwise
+func (e PointOfInterestFormError) Values(point PointOfInterest, action string) PointOfInterest {
+	if !e.For(point.ID, action) {
+		return point
+	}
+	values := e.Point
+	values.ID = point.ID
+	return values
+}
+
+// CoordinatesValue returns the coordinates to show in the form of a point of
+// interest: the submitted ones when the error is about this form, the
+// coordinates of the point otherwise
+func (e PointOfInterestFormError) CoordinatesValue(point PointOfInterest, action string) string {
+	if !e.For(point.ID, action) {
+		if point.Coordinates().IsZero() {
+			return ""
+		}
+		return point.Coordinates().String()
+	}
+	return e.Coordinates
+}
+
+// FromDBPointOfInterest converts a db.DBPointOfInterest to a models.PointOfInterest
+func FromDBPointOfInterest(dbPoint db.DBPointOfInterest) PointOfInterest {
+	return PointOfInterest{
+		ID:            dbPoint.ID,
+		Name:          dbPoint.Name,
+		Category:      dbPoint.Category,
+		Latitude:      dbPoint.Latitude,
+		Longitude:     dbPoint.Longitude,
+		TravelMode:    dbPoint.TravelMode,
+		MaxTravelTime: dbPoint.MaxTravelTime,
+	}
+}
+
+// PointOfInterestDistance represents the distance from a house to a point of interest
+type PointOfInterestDistance struct {
+	PointOfInterest PointOfInterest
+	Distance        float64 // As the crow flies, in meters
+}
+
+// TravelTime returns the estimated travel time to the point of interest
+func (d PointOfInterestDistance) TravelTime(mode string) time.Duration {
+	return EstimateTravelTime(d.Distance, mode)
+}
+
+// Score returns how well the travel time to the point of interest meets its
+// target, from 1 within the target down to 0 at twice the target
+func (d PointOfInterestDistance) Score() float64 {
+	target := time.Duration(d.PointOfInterest.MaxTravelTime) * time.Minute
+	travelTime := d.TravelTime(d.PointOfInterest.TravelMode)
+	if travelTime <= target {
+		return 1
+	}
+	return math.Max(0, 2-float64(travelTime)/float64(target))
+}
+
+// MeetsTarget reports whether the travel time to the point of interest is
+// within its target
+func (d PointOfInterestDistance) MeetsTarget() bool {
+	return d.Score() == 1
+}
+
+// HouseProximity represents the distances from a house to the points of
+// interest, the nearest first, with its proximity score
+type HouseProximity struct {
+	Distances []PointOfInterestDistance
+	Score     int  // From 0 to 100
+	HasScore  bool // False when the house has no coordinates or no point of interest has a target
+}
+
+// NewHouseProximity computes the distances from a house to the points of
+// interest and its proximity score, the average score of the points of
+// interest which have a target
+func NewHouseProximity(house House, points []PointOfInterest) HouseProximity {
+	var proximity HouseProximity
+	if house.Coordinates().IsZero() {
+		return proximity
+	}
+
+	var total float64
+	var targets int
+	for _, point := range points {
+		distance := PointOfInterestDistance{
+			PointOfInterest: point,
+			Distance:        house.Coordinates().DistanceTo(point.Coordinates()),
+		}
+		proximity.Distances = append(proximity.Distances, distance)
+		if point.HasTarget() {
+			total += distance.Score()
+			targets++
+		}
+	}
+
+	slices.SortStableFunc(proximity.Distances, func(a, b PointOfInterestDistance) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+
+	if targets > 0 {
+		proximity.Score = int(math.Round(100 * total / float64(targets)))
+		proximity.HasScore = true
+	}
+
+	return proximity
+}
+
+// DistanceTo returns the distance to the given point of interest, if known
+func (p HouseProximity) DistanceTo(pointID int64) (PointOfInterestDistance, bool) {
+	for _, distance := range p.Distances {
+		if distance.PointOfInterest.ID == pointID {
+			return distance, true
+		}
+	}
+	return PointOfInterestDistance{}, false
+}
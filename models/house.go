@@ -1,17 +1,55 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/willoma/recherche-maison/db"
 )
 
+// House types
+const (
+	HouseTypeHouse     = "maison"
+	HouseTypeApartment = "appartement"
+)
+
+// HouseTypes lists the available house types, in display order
+var HouseTypes = []string{
+	HouseTypeHouse,
+	HouseTypeApartment,
+}
+
+// HouseTypeLabel returns the french label for a house type
+func HouseTypeLabel(houseType string) string {
+	switch houseType {
+	case HouseTypeHouse:
+		return "Maison"
+	case HouseTypeApartment:
+		return "Appartement"
+	default:
+		return houseType
+	}
+}
+
+// IsValidHouseType reports whether houseType is a known house type
+func IsValidHouseType(houseType string) bool {
+	for _, t := range HouseTypes {
+		if t == houseType {
+			return true
+		}
+	}
+	return false
+}
+
 // House represents a house with all its details
 type House struct {
 	ID                   int64
 	Title                string
 	CityID               int64
 	CityName             string
+	CityPostalCode       string
+	NeighbourhoodID      int64 // Zero when unknown
+	NeighbourhoodName    string
 	Address              string
 	Price                int64
 	Surface              int64
@@ -26,10 +64,141 @@ type House struct {
 	OutdoorParkingSpaces int64
 	MainPhoto            string
 	Notes                string
+	Latitude             float64 // Zero when unknown, like Longitude
+	Longitude            float64
+	Tags                 []Tag
+	CustomValues         map[int64]string // Custom field values, by custom field ID
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
 
+// Validate checks the values of the house, returning the errors by house
+// form field name. Only the presence of the city is checked, not its
+// existence.
+func (h House) Validate() FormErrors {
+	errs := FormErrors{}
+
+	if h.Title == "" {
+		errs.Add("title", "Le titre est obligatoire")
+	}
+	if h.CityID == 0 && h.CityName == "" {
+		errs.Add("city", "La ville est obligatoire")
+	}
+	if err := h.Coordinates().Validate(); err != nil {
+		errs.Add("coordinates", "Les coordonnées sont hors limites")
+	}
+
+	if h.Price <= 0 {
+		errs.Add("price", "Le prix doit être supérieur à zéro")
+	}
+	if h.Surface <= 0 {
+		errs.Add("surface", "La surface doit être supérieure à zéro")
+	}
+	if h.Rooms < 1 {
+		errs.Add("rooms", "La maison doit avoir au moins une pièce")
+	}
+	switch {
+	case h.Bedrooms < 0:
+		errs.Add("bedrooms", "Le nombre de chambres ne peut pas être négatif")
+	case h.Bedrooms > h.Rooms:
+		errs.Add("bedrooms", "Le nombre de chambres ne peut pas dépasser le nombre de pièces")
+	}
+	if h.Bathrooms < 0 {
+		errs.Add("bathrooms", "Le nombre de salles de bain ne peut pas être négatif")
+	}
+	if h.Floors < 1 {
+		errs.Add("floors", "La maison doit avoir au moins un niveau")
+	}
+
+	if maxYear := int64(time.Now().Year() + 5); h.ConstructionYear != 0 && (h.ConstructionYear < 1000 || h.ConstructionYear > maxYear) {
+		errs.Add("construction_year", fmt.Sprintf("L'année de construction doit être comprise entre 1000 et %d", maxYear))
+	}
+	if !IsValidHouseType(h.HouseType) {
+		errs.Add("house_type", "Choisissez le type de maison")
+	}
+	if h.LandSurface < 0 {
+		errs.Add("land_surface", "La surface du terrain ne peut pas être négative")
+	}
+	if h.OutdoorParkingSpaces < 0 {
+		errs.Add("outdoor_parking_spaces", "Le nombre de places de stationnement ne peut pas être négatif")
+	}
+
+	return errs
+}
+
+// HasTag reports whether the house is labelled with the given tag
+func (h House) HasTag(tagID int64) bool {
+	for _, tag := range h.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+// Coordinates returns the position of the house, zero if unknown
+func (h House) Coordinates() Coordinates {
+	return Coordinates{Latitude: h.Latitude, Longitude: h.Longitude}
+}
+
+// HouseFilter represents criteria to restrict a list of houses
+type HouseFilter struct {
+	NeighbourhoodID int64                  // Houses must be in this neighbourhood, if not zero
+	TagIDs          []int64                // Houses must be labelled with all these tags
+	CustomFields    []CustomFieldCondition // Houses must match all these conditions
+}
+
+// IsEmpty reports whether the filter has no criteria
+func (f HouseFilter) IsEmpty() bool {
+	if f.NeighbourhoodID != 0 || len(f.TagIDs) > 0 {
+		return false
+	}
+	for _, condition := range f.CustomFields {
+		if !condition.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether a house matches all criteria of the filter
+func (f HouseFilter) Matches(house House) bool {
+	if f.NeighbourhoodID != 0 && house.NeighbourhoodID != f.NeighbourhoodID {
+		return false
+	}
+	for _, tagID := range f.TagIDs {
+		if !house.HasTag(tagID) {
+			return false
+		}
+	}
+	for _, condition := range f.CustomFields {
+		if !condition.Matches(house.CustomValues[condition.Field.ID]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomFieldCondition returns the condition on the given custom field, if any
+func (f HouseFilter) CustomFieldCondition(fieldID int64) CustomFieldCondition {
+	for _, condition := range f.CustomFields {
+		if condition.Field.ID == fieldID {
+			return condition
+		}
+	}
+	return CustomFieldCondition{}
+}
+
+// HasTag reports whether the filter requires the given tag
+func (f HouseFilter) HasTag(tagID int64) bool {
+	for _, id := range f.TagIDs {
+		if id == tagID {
+			return true
+		}
+	}
+	return false
+}
+
 // FromDBHouse converts a db.House to a models.House
 func FromDBHouse(dbHouse db.House) House {
 	return House{
@@ -37,6 +206,9 @@ func FromDBHouse(dbHouse db.House) House {
 		Title:                dbHouse.Title,
 		CityID:               dbHouse.CityID,
 		CityName:             dbHouse.CityName,
+		CityPostalCode:       dbHouse.CityPostalCode,
+		NeighbourhoodID:      dbHouse.NeighbourhoodID.Int64,
+		NeighbourhoodName:    dbHouse.NeighbourhoodName,
 		Address:              dbHouse.Address,
 		Price:                dbHouse.Price,
 		Surface:              dbHouse.Surface,
@@ -51,6 +223,8 @@ func FromDBHouse(dbHouse db.House) House {
 		OutdoorParkingSpaces: dbHouse.OutdoorParkingSpaces,
 		MainPhoto:            dbHouse.MainPhoto,
 		Notes:                dbHouse.Notes,
+		Latitude:             dbHouse.Latitude,
+		Longitude:            dbHouse.Longitude,
 		CreatedAt:            dbHouse.CreatedAt,
 		UpdatedAt:            dbHouse.UpdatedAt,
 	}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Journal entry types
+const (
+	JournalEntryCall    = "appel"
+	JournalEntryEmail   = "email"
+	JournalEntryMessage = "message"
+	JournalEntryOther   = "autre"
+)
+
+// JournalEntryTypes lists the available journal entry types, in display order
+var JournalEntryTypes = []string{
+	JournalEntryCall,
+	JournalEntryEmail,
+	JournalEntryMessage,
+	JournalEntryOther,
+}
+
+// JournalEntryTypeLabel returns the french label for a journal entry type
+func JournalEntryTypeLabel(entryType string) string {
+	switch entryType {
+	case JournalEntryCall:
+		return "Appel"
+	case JournalEntryEmail:
+		return "E-mail"
+	case JournalEntryMessage:
+		return "Message"
+	default:
+		return "Autre"
+	}
+}
+
+// IsValidJournalEntryType reports whether entryType is a known journal entry type
+func IsValidJournalEntryType(entryType string) bool {
+	for _, t := range JournalEntryTypes {
+		if t == entryType {
+			return true
+		}
+	}
+	return false
+}
+
+// JournalEntry represents an interaction about a house (call, email, message...)
+type JournalEntry struct {
+	ID           int64
+	HouseID      int64
+	HouseTitle   string
+	EntryType    string
+	EntryDate    time.Time
+	Contact      string
+	Summary      string
+	FollowUpDate time.Time // Zero value when no follow-up is planned
+	FollowUpDone bool
+}
+
+// HasFollowUp reports whether a follow-up is planned for this entry
+func (e JournalEntry) HasFollowUp() bool {
+	return !e.FollowUpDate.IsZero()
+}
+
+// FromDBJournalEntry converts a db.JournalEntry to a models.JournalEntry
+func FromDBJournalEntry(dbEntry db.JournalEntry) JournalEntry {
+	return JournalEntry{
+		ID:           dbEntry.ID,
+		HouseID:      dbEntry.HouseID,
+		HouseTitle:   dbEntry.HouseTitle,
+		EntryType:    dbEntry.EntryType,
+		EntryDate:    dbEntry.EntryDate,
+		Contact:      dbEntry.Contact,
+		Summary:      dbEntry.Summary,
+		FollowUpDate: dbEntry.FollowUpDate,
+		FollowUpDone: dbEntry.FollowUpDone,
+	}
+}
+
+// FromDBJournalEntries converts a slice of db.JournalEntry to a slice of models.JournalEntry
+func FromDBJournalEntries(dbEntries []db.JournalEntry) []JournalEntry {
+	entries := make([]JournalEntry, len(dbEntries))
+	for i, dbEntry := range dbEntries {
+		entries[i] = FromDBJournalEntry(dbEntry)
+	}
+	return entries
+}
+
+// Timeline event kinds
+const (
+	TimelineEventCreated     = "creation"
+	TimelineEventPublication = "publication"
+	TimelineEventJournal     = "journal"
+	TimelineEventChange      = "changement"
+)
+
+// TimelineEvent represents one dated event in the history of a house
+type TimelineEvent struct {
+	Date    time.Time
+	Kind    string
+	Title   string
+	Details string
+	URL     string        // Optional link related to the event
+	Entry   *JournalEntry // Set when the event comes from the journal
+
+	// Set when the event is a removal, a return online or a price change
+	// detected on a publication
+	Notification *Notification
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Listing represents the data extracted from the page of a real estate ad
+type Listing struct {
+	URL             string
+	Title           string
+	Description     string
+	CityName        string
+	Price           int64
+	Surface         int64
+	Rooms           int64
+	Bedrooms        int64
+	PublicationDate time.Time // Zero if the ad does not tell
+	PhotoURLs       []string
+}
+
+// House returns a house pre-filled with the data of the listing, the city
+// being left to the caller because it must be matched with the known cities
+func (l Listing) House() House {
+	return House{
+		Title:    l.Title,
+		Price:    l.Price,
+		Surface:  l.Surface,
+		Rooms:    l.Rooms,
+		Bedrooms: l.Bedrooms,
+		Notes:    l.Description,
+	}
+}
+
+// PublicationURL returns the publication of the listing, dated today if the
+// ad does not tell its publication date
+func (l Listing) PublicationURL() PublicationURL {
+	date := l.PublicationDate
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return PublicationURL{
+		URL:             l.URL,
+		PublicationDate: date,
+	}
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Publication statuses of a house, from the latest checks of its publications
+const (
+	PublicationStatusOnline  = "en-ligne" // At least one publication is online
+	PublicationStatusRemoved = "retiree"  // All checked publications are removed
+	PublicationStatusUnknown = "inconnu"  // No publication has been checked
+)
+
+// PublicationStatuses lists the publication statuses, in display order
+var PublicationStatuses = []string{
+	PublicationStatusOnline,
+	PublicationStatusRemoved,
+	PublicationStatusUnknown,
+}
+
+// PublicationStatusLabel returns the french label for a publication status
+func PublicationStatusLabel(status string) string {
+	switch status {
+	case PublicationStatusOnline:
+		return "Annonce en ligne"
+	case PublicationStatusRemoved:
+		return "Annonces retirées"
+	case PublicationStatusUnknown:
+		return "Annonces non vérifiées"
+	default:
+		return status
+	}
+}
+
+// MapBoundary represents the boundary of a city, made of rings of positions
+type MapBoundary struct {
+	ID    int64
+	Name  string
+	Code  string // INSEE code, empty when unknown
+	Rings [][]Coordinates
+}
+
+// FromDBMapBoundary converts a db.MapBoundary to a models.MapBoundary
+func FromDBMapBoundary(dbBoundary db.MapBoundary) (MapBoundary, error) {
+	// Positions are stored as in GeoJSON, longitude first
+	var positions [][][2]float64
+	if err := json.Unmarshal([]byte(dbBoundary.Rings), &positions); err != nil {
+		return MapBoundary{}, fmt.Errorf("invalid rings of boundary %d: %w", dbBoundary.ID, err)
+	}
+
+	rings := make([][]Coordinates, len(positions))
+	for i, ring := range positions {
+		rings[i] = make([]Coordinates, len(ring))
+		for j, position := range ring {
+			rings[i][j] = Coordinates{Latitude: position[1], Longitude: position[0]}
+		}
+	}
+
+	return MapBoundary{
+		ID:    dbBoundary.ID,
+		Name:  dbBoundary.Name,
+		Code:  dbBoundary.Code,
+		Rings: rings,
+	}, nil
+}
+
+// Contains reports whether a position is inside the boundary
+func (b MapBoundary) Contains(c Coordinates) bool {
+	// Even-odd rule, so that holes are excluded
+	inside := false
+	for _, ring := range b.Rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.Latitude > c.Latitude) != (b.Latitude > c.Latitude) &&
+				c.Longitude < (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// HouseMap represents the map of the houses, projected to the coordinates of
+// an SVG image
+type HouseMap struct {
+	Width      float64
+	Height     float64
+	Areas      []MapArea
+	Houses     []MapHouse
+	Points     []MapPoint
+	Unlocated  []House // Houses without coordinates, which are not on the map
+	Boundaries int64   // Number of imported boundaries
+}
+
+// MapArea represents a boundary drawn on the map
+type MapArea struct {
+	Name      string
+	Path      string // SVG path data
+	HasHouses bool
+	LabelX    float64
+	LabelY    float64
+}
+
+// MapHouse represents a house drawn on the map, sized by price
+type MapHouse struct {
+	House  House
+	Status string
+	X      float64
+	Y      float64
+	Radius float64
+	PopupX float64 // Top left corner of the popup
+	PopupY float64
+	Width  float64 // Width of the popup
+}
+
+// MapPoint represents a point of interest drawn on the map
+type MapPoint struct {
+	PointOfInterest PointOfInterest
+	X               float64
+	Y               float64
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// ErrInvalidNeighbourhood is returned when the neighbourhood of a house is not in the city of the house
+var ErrInvalidNeighbourhood = errors.New("neighbourhood not in the city of the house")
+
+// Neighbourhood represents a neighbourhood of a city
+type Neighbourhood struct {
+	ID             int64
+	CityID         int64
+	CityName       string
+	CityPostalCode string
+	Name           string
+	IsUsed         bool
+}
+
+// City returns the city of the neighbourhood, with its name and postal code
+func (n Neighbourhood) City() City {
+	return City{ID: n.CityID, Name: n.CityName, PostalCode: n.CityPostalCode}
+}
+
+// FromDBNeighbourhood converts a db.Neighbourhood to a models.Neighbourhood
+func FromDBNeighbourhood(dbNeighbourhood db.Neighbourhood) Neighbourhood {
+	return Neighbourhood{
+		ID:             dbNeighbourhood.ID,
+		CityID:         dbNeighbourhood.CityID,
+		CityName:       dbNeighbourhood.CityName,
+		CityPostalCode: dbNeighbourhood.CityPostalCode,
+		Name:           dbNeighbourhood.Name,
+		IsUsed:         dbNeighbourhood.IsUsed,
+	}
+}
+
+// FromDBNeighbourhoods converts a slice of db.Neighbourhood to a slice of models.Neighbourhood
+func FromDBNeighbourhoods(dbNeighbourhoods []db.Neighbourhood) []Neighbourhood {
+	neighbourhoods := make([]Neighbourhood, len(dbNeighbourhoods))
+	for i, dbNeighbourhood := range dbNeighbourhoods {
+		neighbourhoods[i] = FromDBNeighbourhood(dbNeighbourhood)
+	}
+	return neighbourhoods
+}
+
+// NeighbourhoodID returns the nullable database value of a neighbourhood ID,
+// NULL for zero
+func NeighbourhoodID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// NeighbourhoodStats represents the aggregate figures about the houses of a
+// neighbourhood. The neighbourhood is zero for the houses without neighbourhood.
+type NeighbourhoodStats struct {
+	Neighbourhood Neighbourhood
+	Stats         CityStats
+}
+
+// NewNeighbourhoodStats computes the aggregate figures about the houses of
+// each neighbourhood of a city, followed by those of the houses without
+// neighbourhood if any. Neighbourhoods without houses are skipped.
+func NewNeighbourhoodStats(neighbourhoods []Neighbourhood, houses []House) []NeighbourhoodStats {
+	var stats []NeighbourhoodStats
+	for _, neighbourhood := range append(neighbourhoods, Neighbourhood{}) {
+		var neighbourhoodHouses []House
+		for _, house := range houses {
+			if house.NeighbourhoodID == neighbourhood.ID {
+				neighbourhoodHouses = append(neighbourhoodHouses, house)
+			}
+		}
+		if len(neighbourhoodHouses) > 0 {
+			stats = append(stats, NeighbourhoodStats{
+				Neighbourhood: neighbourhood,
+				Stats:         NewCityStats(neighbourhoodHouses),
+			})
+		}
+	}
+	return stats
+}
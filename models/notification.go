@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Notification types
+const (
+	NotificationListingRemoved  = "annonce_retiree"
+	NotificationListingRestored = "annonce_retablie"
+	NotificationPriceChanged    = "prix"
+)
+
+// PublicationCheck represents the result of a check of a publication URL
+type PublicationCheck struct {
+	ID               int64
+	PublicationURLID int64
+	URL              string
+	CheckedAt        time.Time
+	StatusCode       int64  // Zero value when the request failed
+	FinalURL         string // URL reached after redirections
+	Removed          bool
+	Price            int64 // Zero value when no price was found
+	Error            string
+}
+
+// Failed reports whether the publication could not be checked
+func (c PublicationCheck) Failed() bool {
+	return c.Error != ""
+}
+
+// FromDBPublicationCheck converts a db.PublicationCheck to a models.PublicationCheck
+func FromDBPublicationCheck(dbCheck db.PublicationCheck) PublicationCheck {
+	return PublicationCheck{
+		ID:               dbCheck.ID,
+		PublicationURLID: dbCheck.PublicationURLID,
+		URL:              dbCheck.URL,
+		CheckedAt:        dbCheck.CheckedAt,
+		StatusCode:       dbCheck.StatusCode,
+		FinalURL:         dbCheck.FinalURL,
+		Removed:          dbCheck.Removed,
+		Price:            dbCheck.Price,
+		Error:            dbCheck.Error,
+	}
+}
+
+// Notification represents a change detected on a publication of a house
+type Notification struct {
+	ID               int64
+	CreatedAt        time.Time
+	HouseID          int64
+	HouseTitle       string
+	NotificationType string
+	URL              string
+	OldPrice         int64 // Only for price changes
+	NewPrice         int64 // Only for price changes
+	IsRead           bool
+}
+
+// FromDBNotification converts a db.Notification to a models.Notification
+func FromDBNotification(dbNotification db.Notification) Notification {
+	return Notification{
+		ID:               dbNotification.ID,
+		CreatedAt:        dbNotification.CreatedAt,
+		HouseID:          dbNotification.HouseID,
+		HouseTitle:       dbNotification.HouseTitle,
+		NotificationType: dbNotification.NotificationType,
+		URL:              dbNotification.URL,
+		OldPrice:         dbNotification.OldPrice,
+		NewPrice:         dbNotification.NewPrice,
+		IsRead:           dbNotification.IsRead,
+	}
+}
+
+// FromDBNotifications converts a slice of db.Notification to a slice of models.Notification
+func FromDBNotifications(dbNotifications []db.Notification) []Notification {
+	notifications := make([]Notification, len(dbNotifications))
+	for i, dbNotification := range dbNotifications {
+		notifications[i] = FromDBNotification(dbNotification)
+	}
+	return notifications
+}
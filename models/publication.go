@@ -1,6 +1,7 @@
 package models
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/willoma/recherche-maison/db"
@@ -14,6 +15,12 @@ type PublicationURL struct {
 	PublicationDate time.Time
 }
 
+// IsValidPublicationURL reports whether rawURL is an absolute http or https URL
+func IsValidPublicationURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // FromDBPublicationURL converts a db.PublicationURL to a models.PublicationURL
 func FromDBPublicationURL(dbPub db.PublicationURL) PublicationURL {
 	return PublicationURL{
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSort is returned when a sort key is unknown
+var ErrInvalidSort = errors.New("invalid sort key")
+
+// Sort keys of the houses on the main page
+const (
+	SortTitle           = "titre"
+	SortCity            = "ville"
+	SortPrice           = "prix"
+	SortSurface         = "surface"
+	SortRooms           = "pieces"
+	SortCreated         = "ajout"
+	SortProximity       = "proximite"
+	SortPointOfInterest = "lieu-"    // Followed by the ID of the point of interest
+	SortCustomField     = "critere-" // Followed by the ID of the custom field
+)
+
+// HouseSort represents the order of the houses on the main page. The zero
+// value keeps the houses in their default order, the newest first.
+type HouseSort struct {
+	Key               string
+	PointOfInterestID int64 // With SortPointOfInterest
+	CustomFieldID     int64 // With SortCustomField
+	Descending        bool
+}
+
+// ParseHouseSort parses a sort such as "prix", "-surface", "lieu-3" or
+// "critere-2", where the "-" prefix means descending order
+func ParseHouseSort(s string) (HouseSort, error) {
+	var sort HouseSort
+	if s == "" {
+		return sort, nil
+	}
+
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sort.Descending = true
+		s = rest
+	}
+
+	if idStr, ok := strings.CutPrefix(s, SortPointOfInterest); ok {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return HouseSort{}, ErrInvalidSort
+		}
+		sort.Key = SortPointOfInterest
+		sort.PointOfInterestID = id
+		return sort, nil
+	}
+
+	if idStr, ok := strings.CutPrefix(s, SortCustomField); ok {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return HouseSort{}, ErrInvalidSort
+		}
+		sort.Key = SortCustomField
+		sort.CustomFieldID = id
+		return sort, nil
+	}
+
+	switch s {
+	case SortTitle, SortCity, SortPrice, SortSurface, SortRooms, SortCreated, SortProximity:
+		sort.Key = s
+		return sort, nil
+	default:
+		return HouseSort{}, ErrInvalidSort
+	}
+}
+
+// Column returns the sort key with its point of interest or custom field ID,
+// without the order
+func (s HouseSort) Column() string {
+	switch s.Key {
+	case SortPointOfInterest:
+		return SortPointOfInterest + strconv.FormatInt(s.PointOfInterestID, 10)
+	case SortCustomField:
+		return SortCustomField + strconv.FormatInt(s.CustomFieldID, 10)
+	default:
+		return s.Key
+	}
+}
+
+// String returns the sort in the format read by ParseHouseSort
+func (s HouseSort) String() string {
+	if s.Descending && s.Key != "" {
+		return "-" + s.Column()
+	}
+	return s.Column()
+}
+
+// SortHouses sorts the houses in place. Houses whose proximity is unknown,
+// when sorting by proximity or distance, and houses without a value, when
+// sorting by a custom field, are always last. Sorting by a custom field which
+// is not in fields returns ErrInvalidSort.
+func SortHouses(houses []House, sort HouseSort, proximities map[int64]HouseProximity, fields []CustomField) error {
+	if sort.Key == "" {
+		return nil
+	}
+
+	var field CustomField
+	if sort.Key == SortCustomField {
+		i := slices.IndexFunc(fields, func(f CustomField) bool { return f.ID == sort.CustomFieldID })
+		if i < 0 {
+			return ErrInvalidSort
+		}
+		field = fields[i]
+	}
+
+	slices.SortStableFunc(houses, func(a, b House) int {
+		// Unknown values are last, whatever the order
+		valueA, knownA := sortValue(a, sort, proximities)
+		valueB, knownB := sortValue(b, sort, proximities)
+		switch {
+		case !knownA && !knownB:
+			return 0
+		case !knownA:
+			return 1
+		case !knownB:
+			return -1
+		}
+
+		var result int
+		switch sort.Key {
+		case SortTitle:
+			result = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case SortCity:
+			result = cmp.Compare(strings.ToLower(a.CityName), strings.ToLower(b.CityName))
+		case SortCreated:
+			result = a.CreatedAt.Compare(b.CreatedAt)
+		case SortCustomField:
+			result = field.CompareValues(a.CustomValues[field.ID], b.CustomValues[field.ID])
+		default:
+			result = cmp.Compare(valueA, valueB)
+		}
+
+		if sort.Descending {
+			return -result
+		}
+		return result
+	})
+	return nil
+}
+
+// sortValue returns the numeric value of a house for the sort, and whether it is known
+func sortValue(house House, sort HouseSort, proximities map[int64]HouseProximity) (float64, bool) {
+	switch sort.Key {
+	case SortPrice:
+		return float64(house.Price), house.Price != 0
+	case SortSurface:
+		return float64(house.Surface), house.Surface != 0
+	case SortRooms:
+		return float64(house.Rooms), house.Rooms != 0
+	case SortProximity:
+		proximity := proximities[house.ID]
+		return float64(proximity.Score), proximity.HasScore
+	case SortPointOfInterest:
+		distance, ok := proximities[house.ID].DistanceTo(sort.PointOfInterestID)
+		return distance.Distance, ok
+	case SortCustomField:
+		return 0, house.CustomValues[sort.CustomFieldID] != ""
+	default:
+		return 0, true
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"github.com/willoma/recherche-maison/db"
+)
+
+// DefaultTagColor is the color proposed when creating a new tag
+const DefaultTagColor = "#9c4dcc"
+
+// Tag represents a custom label that can be attached to houses
+type Tag struct {
+	ID         int64
+	Name       string
+	Color      string
+	HouseCount int64
+}
+
+// FromDBTag converts a db.Tag to a models.Tag
+func FromDBTag(dbTag db.Tag) Tag {
+	return Tag{
+		ID:         dbTag.ID,
+		Name:       dbTag.Name,
+		Color:      dbTag.Color,
+		HouseCount: dbTag.HouseCount,
+	}
+}
+
+// FromDBTags converts a slice of db.Tag to a slice of models.Tag
+func FromDBTags(dbTags []db.Tag) []Tag {
+	tags := make([]Tag, len(dbTags))
+	for i, dbTag := range dbTags {
+		tags[i] = FromDBTag(dbTag)
+	}
+	return tags
+}
+
+// TagFormError is an error about a form of the tags page, shown next to the
+// form with the submitted values
+type TagFormError struct {
+	TagID   int64  // Zero for the creation form
+	Action  string // create, update or delete
+	Tag     Tag    // Submitted tag fields
+	Message string
+}
+
+// For reports whether the error is about the form of the given action for the
+// given tag
+func (e TagFormError) For(tagID int64, action string) bool {
+	return e.Message != "" && e.TagID == tagID && e.Action == action
+}
+
+// Values returns the values to show in the form of a tag: the submitted ones
+// when the error is about this form, the tag otherwise
+func (e TagFormError) Values(tag Tag, action string) Tag {
+	if !e.For(tag.ID, action) {
+		return tag
+	}
+	values := e.Tag
+	values.ID = tag.ID
+	values.HouseCount = tag.HouseCount
+	return values
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/willoma/recherche-maison/db"
+)
+
+// Task represents something to do about a house
+type Task struct {
+	ID         int64
+	HouseID    int64
+	HouseTitle string
+	Title      string
+	DueDate    time.Time // Zero value when there is no due date
+	Assignee   string
+	Done       bool
+	CreatedAt  time.Time
+}
+
+// HasDueDate reports whether the task has a due date
+func (t Task) HasDueDate() bool {
+	return !t.DueDate.IsZero()
+}
+
+// IsOverdue reports whether the task is not done and its due date is before the given day
+func (t Task) IsOverdue(today time.Time) bool {
+	return !t.Done && t.HasDueDate() && t.DueDate.Before(today)
+}
+
+// FromDBTask converts a db.Task to a models.Task
+func FromDBTask(dbTask db.Task) Task {
+	return Task{
+		ID:         dbTask.ID,
+		HouseID:    dbTask.HouseID,
+		HouseTitle: dbTask.HouseTitle,
+		Title:      dbTask.Title,
+		DueDate:    dbTask.DueDate,
+		Assignee:   dbTask.Assignee,
+		Done:       dbTask.Done,
+		CreatedAt:  dbTask.CreatedAt,
+	}
+}
+
+// FromDBTasks converts a slice of db.Task to a slice of models.Task
+func FromDBTasks(dbTasks []db.Task) []Task {
+	tasks := make([]Task, len(dbTasks))
+	for i, dbTask := range dbTasks {
+		tasks[i] = FromDBTask(dbTask)
+	}
+	return tasks
+}
@@ -13,6 +13,55 @@ document.addEventListener('DOMContentLoaded', function() {
     });
   });
   
+  // City pickers: suggest the matching cities while typing
+  document.querySelectorAll('input[data-city-search]').forEach(input => {
+    const suggestions = input.list;
+    let timer;
+    input.addEventListener('input', () => {
+      clearTimeout(timer);
+      const search = input.value.trim();
+      if (search.length < 2) {
+        suggestions.replaceChildren();
+        return;
+      }
+      timer = setTimeout(() => {
+        fetch(input.dataset.citySearch + '?q=' + encodeURIComponent(search))
+          .then(response => response.ok ? response.json() : [])
+          .then(names => {
+            suggestions.replaceChildren(...names.map(name => {
+              const option = document.createElement('option');
+              option.value = name;
+              return option;
+            }));
+          })
+          .catch(() => {});
+      }, 200);
+    });
+  });
+
+  // Neighbourhood lists: only show the neighbourhoods of the chosen city
+  document.querySelectorAll('select[data-city-picker]').forEach(select => {
+    const cityInput = document.getElementById(select.dataset.cityPicker);
+    if (!cityInput) {
+      return;
+    }
+    const update = () => {
+      const city = cityInput.value.trim().toLowerCase();
+      select.querySelectorAll('optgroup').forEach(group => {
+        const label = group.label.toLowerCase();
+        const visible = city !== '' && (label === city || label.startsWith(city + ' ('));
+        group.hidden = !visible;
+        group.disabled = !visible;
+      });
+      const selected = select.selectedOptions[0];
+      if (selected && selected.parentElement.disabled) {
+        select.value = '';
+      }
+    };
+    cityInput.addEventListener('input', update);
+    update();
+  });
+
   // Example: Add event listener for adding publication URLs
   const addUrlButton = document.getElementById('add-url');
   if (addUrlButton) {